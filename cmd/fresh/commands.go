@@ -0,0 +1,350 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"fresh/internal/bulk"
+	"fresh/internal/cli"
+	"fresh/internal/config"
+	"fresh/internal/doctor"
+	"fresh/internal/repofilter"
+	"fresh/internal/report"
+	"fresh/internal/scanner"
+)
+
+// app holds the values every command's flags are bound to.
+type app struct {
+	scan    scanOptions
+	version bool
+	status  statusOptions
+	bulk    bulkOptions
+	doctor  doctorOptions
+}
+
+type scanOptions struct {
+	Dir       string
+	Workspace string
+}
+
+type statusOptions struct {
+	Format       string
+	Fetch        bool
+	PullRequests bool
+}
+
+type doctorOptions struct {
+	Format string
+}
+
+type bulkOptions struct {
+	Format      string
+	DryRun      bool
+	Concurrency int
+	Filter      string
+	Exclude     string
+}
+
+var formats = []string{string(report.FormatTable), string(report.FormatJSON), string(report.FormatNDJSON)}
+
+func newApp() *app {
+	return &app{}
+}
+
+func (a *app) rootCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "fresh",
+		Summary:     "Keep your git repositories fresh",
+		Description: "Interactively manage the status of multiple git repositories. Without a command, fresh scans the directory and starts the TUI.",
+		Examples: []string{
+			"fresh --dir ~/projects",
+			"fresh status --workspace work --format json",
+		},
+		Flags: func(fs *cli.FlagSet) {
+			a.scanFlags(fs)
+			fs.Bool(&a.version, "version", "v", false, "Print version information")
+		},
+		Run: func(ctx *cli.Context, args []string) (int, error) {
+			if a.version {
+				printVersion(ctx.Stdout)
+				return 0, nil
+			}
+			if len(args) > 0 {
+				return 1, cli.Usagef("unknown command %q", args[0])
+			}
+			cfg, dir, err := a.loadScanDir()
+			if err != nil {
+				return 1, err
+			}
+			return runApp(cfg, dir)
+		},
+		Subcommands: []*cli.Command{
+			a.statusCommand(),
+			a.bulkCommand(bulk.ActionPull),
+			a.bulkCommand(bulk.ActionPrune),
+			a.configCommand(),
+			a.doctorCommand(),
+		},
+	}
+}
+
+func (a *app) statusCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "status",
+		Summary:     "Print the state of every repository",
+		Description: "Print the state of every repository without starting the TUI.\n\nExit status is 0 when every repository is clean and up to date, 1 on error,\nand otherwise the sum of: 2 any dirty, 4 any behind, 8 any diverged.",
+		Args:        "[repo...]",
+		Examples: []string{
+			"fresh status --dir ~/projects --format json",
+			`fresh status --format ndjson | jq 'select(.local.state == "dirty")'`,
+		},
+		Flags: func(fs *cli.FlagSet) {
+			a.scanFlags(fs)
+			fs.String(&a.status.Format, "format", "f", string(report.FormatTable), "Output format").OneOf(formats...)
+			fs.Bool(&a.status.Fetch, "fetch", "", false, "Fetch remotes before reading their state")
+			fs.Bool(&a.status.PullRequests, "prs", "", true, "Query pull request counts (requires gh); --prs=false skips them")
+		},
+		Run: func(ctx *cli.Context, args []string) (int, error) {
+			cfg, dir, err := a.loadScanDir()
+			if err != nil {
+				return 1, err
+			}
+			return runStatus(ctx.Stdout, cfg, dir, a.status, repofilter.Selection{Names: args})
+		},
+		CompleteArgs: a.completeRepositories,
+	}
+}
+
+func (a *app) bulkCommand(action bulk.Action) *cli.Command {
+	description := "Pull every repository that is behind its upstream, using git pull --rebase."
+	if action == bulk.ActionPrune {
+		description = "Delete local branches that are already merged, leaving protected and current branches alone."
+	}
+
+	return &cli.Command{
+		Name:        string(action),
+		Summary:     strings.TrimSuffix(strings.SplitN(description, ",", 2)[0], "."),
+		Description: description + "\n\nExit status is 0 when every targeted repository succeeded, 1 on error and 2 when any failed.",
+		Args:        "[repo...]",
+		Examples: []string{
+			fmt.Sprintf("fresh %s --dir ~/projects --dry-run", action),
+			fmt.Sprintf(`fresh %s --filter "is:clean" --exclude "legacy-*" --format json`, action),
+		},
+		Flags: func(fs *cli.FlagSet) {
+			a.scanFlags(fs)
+			fs.String(&a.bulk.Format, "format", "f", string(report.FormatTable), "Output format").OneOf(formats...)
+			fs.Bool(&a.bulk.DryRun, "dry-run", "n", false, "Report what would be done without changing anything")
+			fs.Int(&a.bulk.Concurrency, "concurrency", "j", 4, "Number of repositories to work on at once")
+			fs.String(&a.bulk.Filter, "filter", "", "", `Only target repositories matching a TUI filter, e.g. "is:clean api"`).Placeholder("query")
+			fs.String(&a.bulk.Exclude, "exclude", "", "", "Comma-separated repository name globs to leave alone").Placeholder("globs")
+		},
+		Run: func(ctx *cli.Context, args []string) (int, error) {
+			if a.bulk.Concurrency < 1 {
+				return 1, cli.Usagef("concurrency must be at least 1")
+			}
+			selection := repofilter.Selection{Query: a.bulk.Filter, Names: args, Exclude: splitList(a.bulk.Exclude)}
+			if err := selection.Validate(); err != nil {
+				return 1, cli.Usagef("%v", err)
+			}
+			cfg, dir, err := a.loadScanDir()
+			if err != nil {
+				return 1, err
+			}
+			return runBulk(ctx.Stdout, action, cfg, dir, a.bulk, selection)
+		},
+		CompleteArgs: a.completeRepositories,
+	}
+}
+
+func (a *app) configCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "config",
+		Summary:     "Inspect the configuration file",
+		Description: fmt.Sprintf("Inspect the configuration file. Its location can be overridden with $%s.", config.EnvConfigPath),
+		Subcommands: []*cli.Command{
+			{
+				Name:    "path",
+				Summary: "Print where the configuration file is read from",
+				Run: func(ctx *cli.Context, args []string) (int, error) {
+					path, err := config.FilePath()
+					if err != nil {
+						return 1, err
+					}
+					fmt.Fprintln(ctx.Stdout, path)
+					return 0, nil
+				},
+			},
+			{
+				Name:    "show",
+				Summary: "Print the configuration file's settings, with tokens redacted",
+				Run: func(ctx *cli.Context, args []string) (int, error) {
+					path, err := config.FilePath()
+					if err != nil {
+						return 1, err
+					}
+					file, err := config.LoadFile(path)
+					if err != nil {
+						return 1, err
+					}
+					encoder := json.NewEncoder(ctx.Stdout)
+					encoder.SetIndent("", "  ")
+					return 0, encoder.Encode(file.Redacted())
+				},
+			},
+			{
+				Name:    "workspaces",
+				Summary: "List the configured workspaces",
+				Run: func(ctx *cli.Context, args []string) (int, error) {
+					cfg, err := config.Load()
+					if err != nil {
+						return 1, err
+					}
+					tw := tabwriter.NewWriter(ctx.Stdout, 0, 0, 2, ' ', 0)
+					for _, name := range sortedKeys(cfg.Workspaces) {
+						fmt.Fprintf(tw, "%s\t%s\n", name, cfg.Workspaces[name])
+					}
+					return 0, tw.Flush()
+				},
+			},
+		},
+	}
+}
+
+func (a *app) doctorCommand() *cli.Command {
+	return &cli.Command{
+		Name:        "doctor",
+		Summary:     "Check the environment fresh depends on",
+		Description: "Check git, the GitHub CLI and its authentication, the SSH agent, notifications, the configuration file and the scan root, and print how to fix any problems.\n\nExit status is 1 when any check failed; warnings leave it at 0.",
+		Examples: []string{
+			"fresh doctor",
+			"fresh doctor --workspace work --format json",
+		},
+		Flags: func(fs *cli.FlagSet) {
+			a.scanFlags(fs)
+			fs.String(&a.doctor.Format, "format", "f", string(report.FormatTable), "Output format").OneOf(formats...)
+		},
+		Run: func(ctx *cli.Context, args []string) (int, error) {
+			// A broken configuration file is one of the things doctor
+			// reports, so carry on with the defaults Load falls back to.
+			cfg, _ := config.Load()
+			dir, err := a.scan.dir(cfg)
+			if err != nil {
+				return 1, err
+			}
+			configPath, _ := config.FilePath()
+
+			results := doctor.Run(doctor.SystemEnvironment(), doctor.Options{
+				ConfigPath: configPath,
+				ScanDir:    dir,
+			})
+			if err := doctor.Write(ctx.Stdout, report.Format(a.doctor.Format), results); err != nil {
+				return 1, err
+			}
+			return doctor.ExitCode(results), nil
+		},
+	}
+}
+
+func (a *app) scanFlags(fs *cli.FlagSet) {
+	fs.String(&a.scan.Dir, "dir", "d", "", "Directory to scan for git repositories (default current directory)").Placeholder("path").Dirs()
+	fs.String(&a.scan.Workspace, "workspace", "w", "", "Scan a workspace named in the configuration file").Placeholder("name").CompleteWith(completeWorkspaces)
+}
+
+func (a *app) loadScanDir() (*config.Config, string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, "", err
+	}
+	dir, err := a.scan.resolve(cfg)
+	return cfg, dir, err
+}
+
+// resolve picks the directory to scan and checks it can be scanned.
+func (s scanOptions) resolve(cfg *config.Config) (string, error) {
+	dir, err := s.dir(cfg)
+	if err != nil {
+		return "", err
+	}
+	if err := validateScanDir(dir); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// dir picks the directory to scan: the named workspace, --dir, or the
+// working directory.
+func (s scanOptions) dir(cfg *config.Config) (string, error) {
+	dir := s.Dir
+	if s.Workspace != "" {
+		if s.Dir != "" {
+			return "", cli.Usagef("--dir and --workspace cannot be used together")
+		}
+		var ok bool
+		if dir, ok = cfg.Workspaces[s.Workspace]; !ok {
+			return "", fmt.Errorf("unknown workspace %q (see 'fresh config workspaces')", s.Workspace)
+		}
+	}
+	if dir == "" {
+		return os.Getwd()
+	}
+	return dir, nil
+}
+
+func completeWorkspaces(map[string]string) []cli.Candidate {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	var candidates []cli.Candidate
+	for _, name := range sortedKeys(cfg.Workspaces) {
+		candidates = append(candidates, cli.Candidate{Value: name, Description: cfg.Workspaces[name]})
+	}
+	return candidates
+}
+
+// completeRepositories scans the directory the typed flags point at and
+// offers the names of the repositories found there.
+func (a *app) completeRepositories(values map[string]string) []cli.Candidate {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil
+	}
+	scan := scanOptions{Dir: config.ExpandHome(values["dir"]), Workspace: values["workspace"]}
+	dir, err := scan.resolve(cfg)
+	if err != nil {
+		return nil
+	}
+
+	s := scanner.New(dir)
+	go s.Scan()
+
+	var candidates []cli.Candidate
+	for path := range s.GetRepoChannel() {
+		candidates = append(candidates, cli.Candidate{Value: filepath.Base(path), Description: path})
+	}
+	return candidates
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
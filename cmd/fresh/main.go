@@ -1,11 +1,16 @@
 package main
 
 import (
-	"flag"
 	"fmt"
+	"fresh/internal/bulk"
+	"fresh/internal/cli"
+	"fresh/internal/config"
 	"fresh/internal/git"
 	"fresh/internal/notifications"
+	"fresh/internal/repofilter"
+	"fresh/internal/report"
 	"fresh/internal/ui"
+	"io"
 	"os"
 
 	tea "charm.land/bubbletea/v2"
@@ -18,101 +23,73 @@ var (
 	builtBy = "unknown"
 )
 
-type Config struct {
-	ScanDir string
-}
-
-type Action int
-
-const (
-	ActionRun Action = iota
-	ActionVersion
-	ActionHelp
-)
-
 func main() {
-	formatUsageOutput()
-	action, cfg, err := parseCliFlags()
-
-	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
-	}
-
-	switch action {
-	case ActionVersion:
-		printVersion()
-		os.Exit(0)
-	case ActionRun:
-		runApp(cfg)
-		os.Exit(0)
-	case ActionHelp:
-		flag.Usage()
-		os.Exit(0)
-	default:
-		flag.Usage()
-		os.Exit(1)
-	}
+	app := newApp()
+	os.Exit(cli.Execute(app.rootCommand(), os.Args[1:], &cli.Context{
+		Stdout: os.Stdout,
+		Stderr: os.Stderr,
+	}))
 }
 
-func parseCliFlags() (Action, *Config, error) {
-	var showVersion bool
-	var showHelp bool
-	var dirPath string
-
-	var defaultDir, _ = os.Getwd()
-
-	flag.BoolVar(&showVersion, "version", false, "Print version information")
-	flag.BoolVar(&showVersion, "v", false, "Print version information (shorthand)")
-	flag.BoolVar(&showHelp, "help", false, "Show help message")
-	flag.BoolVar(&showHelp, "h", false, "Show help message (shorthand)")
-	flag.StringVar(&dirPath, "dir", defaultDir, "Specify the directory to scan (shorthand: -d)")
-	flag.StringVar(&dirPath, "d", defaultDir, "Specify the directory to scan (shorthand for --dir)")
+func runApp(cfg *config.Config, scanDir string) (int, error) {
+	if !git.IsGitInstalled() {
+		return 1, fmt.Errorf("git is not installed or not found in PATH")
+	}
 
-	flag.Parse()
+	notifier := notifications.NewNotifier()
+	notifier.Start()
+	defer notifier.Stop()
 
-	if len(flag.Args()) > 0 {
-		return ActionRun, nil, fmt.Errorf("unexpected arguments: %v\nUse --dir to specify a directory", flag.Args())
-	}
+	m := ui.New(scanDir, cfg, notifier)
 
-	switch {
-	case showVersion:
-		return ActionVersion, nil, nil
-	case showHelp:
-		return ActionHelp, nil, nil
-	default:
-		cfg, err := buildConfig(dirPath)
-		return ActionRun, cfg, err
+	if _, err := tea.NewProgram(m).Run(); err != nil {
+		return 1, fmt.Errorf("running program: %w", err)
 	}
+	return 0, nil
 }
 
-func buildConfig(dirPath string) (*Config, error) {
-	if err := validateScanDir(dirPath); err != nil {
-		return nil, err
+// runStatus prints the state of every selected repository and returns
+// report.ExitCode's flags.
+func runStatus(w io.Writer, cfg *config.Config, scanDir string, opts statusOptions, selection repofilter.Selection) (int, error) {
+	if !git.IsGitInstalled() {
+		return 1, fmt.Errorf("git is not installed or not found in PATH")
 	}
 
-	cfg := &Config{
-		ScanDir: dirPath,
+	repos := report.Collect(scanDir, cfg, report.CollectOptions{
+		Fetch:        opts.Fetch,
+		PullRequests: opts.PullRequests,
+		Selection:    selection,
+	})
+	statuses := report.NewRepositoryStatuses(repos)
+	if err := report.Write(w, report.Format(opts.Format), statuses); err != nil {
+		return 1, fmt.Errorf("writing status: %w", err)
 	}
-	return cfg, nil
+	return report.ExitCode(statuses), nil
 }
 
-func runApp(cfg *Config) {
-	if git.IsGitInstalled() == false {
-		fmt.Println("Git is not installed or not found in PATH.")
-		os.Exit(1)
+// runBulk pulls or prunes every selected repository and returns 0 when all
+// of them succeeded or bulk.ExitFailed when any failed.
+func runBulk(w io.Writer, action bulk.Action, cfg *config.Config, scanDir string, opts bulkOptions, selection repofilter.Selection) (int, error) {
+	if !git.IsGitInstalled() {
+		return 1, fmt.Errorf("git is not installed or not found in PATH")
 	}
 
-	notifier := notifications.NewNotifier()
-	notifier.Start()
-	defer notifier.Stop()
-
-	m := ui.New(cfg.ScanDir, notifier)
-
-	if _, err := tea.NewProgram(m).Run(); err != nil {
-		fmt.Println("Error running program:", err)
-		os.Exit(1)
+	// Whether a repository is behind is only known after fetching.
+	repos := report.Collect(scanDir, cfg, report.CollectOptions{
+		Fetch:       action == bulk.ActionPull,
+		Selection:   selection,
+		Concurrency: opts.Concurrency,
+	})
+
+	results := bulk.Run(action, repos, bulk.Options{
+		DryRun:      opts.DryRun,
+		Concurrency: opts.Concurrency,
+	})
+	summary := bulk.Summarize(action, opts.DryRun, results)
+	if err := bulk.Write(w, report.Format(opts.Format), summary); err != nil {
+		return 1, fmt.Errorf("writing summary: %w", err)
 	}
+	return summary.ExitCode(), nil
 }
 
 func validateScanDir(dir string) error {
@@ -129,22 +106,9 @@ func validateScanDir(dir string) error {
 	return nil
 }
 
-func formatUsageOutput() {
-	flag.Usage = func() {
-		fmt.Println("")
-		fmt.Println("Usage: fresh [options]")
-		fmt.Println("\nOptions:")
-		fmt.Println("  --help -h           	Show this help message")
-		fmt.Println("  --dir -d <path>     	Specify the directory to scan for git repositories")
-		fmt.Println("  --version -v   	Print version information")
-		fmt.Println("\nExample:")
-		fmt.Printf("  fresh --dir ~/projects \n\n")
-	}
-}
-
-func printVersion() {
-	fmt.Printf("fresh %s\n", version)
-	fmt.Printf("  commit: %s\n", commit)
-	fmt.Printf("  built:  %s\n", date)
-	fmt.Printf("  by:     %s\n", builtBy)
+func printVersion(w io.Writer) {
+	fmt.Fprintf(w, "fresh %s\n", version)
+	fmt.Fprintf(w, "  commit: %s\n", commit)
+	fmt.Fprintf(w, "  built:  %s\n", date)
+	fmt.Fprintf(w, "  by:     %s\n", builtBy)
 }
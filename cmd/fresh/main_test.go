@@ -1,32 +1,160 @@
 package main
 
 import (
-	"flag"
+	"bytes"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"fresh/internal/cli"
+	"fresh/internal/config"
 )
 
-func TestParseCLIConfigWithDirFlag(t *testing.T) {
+func TestParseRootWithDirFlag(t *testing.T) {
+	t.Parallel()
+
 	tmpDir := t.TempDir()
+	a := newApp()
+
+	cmd, args, err := cli.Parse(a.rootCommand(), []string{"--dir", tmpDir})
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if cmd.Name != "fresh" || len(args) != 0 {
+		t.Errorf("Parse() = %q %v, want root command without args", cmd.Name, args)
+	}
+	if a.scan.Dir != tmpDir {
+		t.Errorf("Parse() Dir = %v, want %v", a.scan.Dir, tmpDir)
+	}
+}
 
-	flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ExitOnError)
-	originalArgs := os.Args
-	t.Cleanup(func() {
-		os.Args = originalArgs
-	})
+func TestParseStatusSubcommand(t *testing.T) {
+	t.Parallel()
 
-	os.Args = []string{"fresh", "--dir", tmpDir}
+	tmpDir := t.TempDir()
+	a := newApp()
 
-	action, cfg, err := parseCliFlags()
+	cmd, args, err := cli.Parse(a.rootCommand(), []string{"status", "--dir", tmpDir, "--format", "ndjson", "--prs=false", "api"})
 	if err != nil {
-		t.Fatalf("parseCliFlags() unexpected error: %v", err)
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if cmd.Name != "status" {
+		t.Fatalf("Parse() command = %q, want status", cmd.Name)
+	}
+	if a.scan.Dir != tmpDir || a.status.Format != "ndjson" || a.status.PullRequests {
+		t.Fatalf("Parse() status = %+v, scan = %+v", a.status, a.scan)
+	}
+	if len(args) != 1 || args[0] != "api" {
+		t.Fatalf("Parse() args = %v, want [api]", args)
 	}
+}
+
+func TestParseStatusRejectsUnknownFormat(t *testing.T) {
+	t.Parallel()
 
-	if action != ActionRun {
-		t.Errorf("parseCliFlags() action = %v, want ActionRun", action)
+	a := newApp()
+	if _, _, err := cli.Parse(a.rootCommand(), []string{"status", "--format", "xml"}); err == nil {
+		t.Fatal("Parse() expected error for unknown format")
 	}
+}
+
+func TestParsePruneSubcommand(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	a := newApp()
 
-	if cfg.ScanDir != tmpDir {
-		t.Errorf("parseCliFlags() ScanDir = %v, want %v", cfg.ScanDir, tmpDir)
+	cmd, _, err := cli.Parse(a.rootCommand(), []string{"prune", "-d", tmpDir, "--dry-run", "-j", "2", "--filter", "is:clean", "--exclude", "old-*, tmp"})
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if cmd.Name != "prune" {
+		t.Fatalf("Parse() command = %q, want prune", cmd.Name)
+	}
+	if !a.bulk.DryRun || a.bulk.Concurrency != 2 || a.bulk.Filter != "is:clean" {
+		t.Fatalf("Parse() bulk = %+v", a.bulk)
+	}
+	if exclude := splitList(a.bulk.Exclude); len(exclude) != 2 || exclude[1] != "tmp" {
+		t.Fatalf("splitList() = %v", exclude)
+	}
+}
+
+func TestParsePruneFlagsAfterRepos(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	a := newApp()
+
+	cmd, args, err := cli.Parse(a.rootCommand(), []string{"prune", "--dir", tmpDir, "r1", "--dry-run"})
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if cmd.Name != "prune" || !a.bulk.DryRun {
+		t.Fatalf("Parse() = %q, bulk = %+v, want a dry-run prune", cmd.Name, a.bulk)
+	}
+	if len(args) != 1 || args[0] != "r1" {
+		t.Fatalf("Parse() args = %v, want [r1]", args)
+	}
+}
+
+func TestScanOptionsResolve(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	cfg := &config.Config{Workspaces: map[string]string{"work": tmpDir}}
+
+	tests := []struct {
+		name    string
+		opts    scanOptions
+		want    string
+		wantErr string
+	}{
+		{name: "dir", opts: scanOptions{Dir: tmpDir}, want: tmpDir},
+		{name: "workspace", opts: scanOptions{Workspace: "work"}, want: tmpDir},
+		{name: "unknown workspace", opts: scanOptions{Workspace: "home"}, wantErr: "unknown workspace"},
+		{name: "both", opts: scanOptions{Dir: tmpDir, Workspace: "work"}, wantErr: "cannot be used together"},
+		{name: "missing dir", opts: scanOptions{Dir: tmpDir + "/missing"}, wantErr: "does not exist"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tt.opts.resolve(cfg)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolve() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("resolve() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigShowRedactsTokens(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	const file = `{
+  "github": {"token": "ghp_secret", "instances": [{"host": "ghe.acme.corp", "token": "ghe-secret"}]},
+  "gitea": {"instances": [{"host": "git.example.com", "token": "gitea-secret"}]}
+}`
+	if err := os.WriteFile(path, []byte(file), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(config.EnvConfigPath, path)
+
+	var stdout, stderr bytes.Buffer
+	code := cli.Execute(newApp().rootCommand(), []string{"config", "show"}, &cli.Context{Stdout: &stdout, Stderr: &stderr})
+	if code != 0 {
+		t.Fatalf("config show exit = %d, stderr = %s", code, stderr.String())
+	}
+	if strings.Contains(stdout.String(), "secret") {
+		t.Fatalf("config show printed a token:\n%s", stdout.String())
+	}
+	if !strings.Contains(stdout.String(), config.RedactedToken) {
+		t.Fatalf("config show = %s, want the token redacted", stdout.String())
 	}
 }
@@ -0,0 +1,61 @@
+// Package browser opens web pages in the user's browser.
+package browser
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Command returns the program and arguments that open url on goos: the
+// configured command split on spaces with url appended, or else open on
+// macOS, the URL protocol handler on Windows and xdg-open elsewhere.
+func Command(goos string, configured string, url string) (string, []string) {
+	if fields := strings.Fields(configured); len(fields) > 0 {
+		return fields[0], append(fields[1:], url)
+	}
+	switch goos {
+	case "darwin":
+		return "open", []string{url}
+	case "windows":
+		return "rundll32", []string{"url.dll,FileProtocolHandler", url}
+	default:
+		return "xdg-open", []string{url}
+	}
+}
+
+// Open starts the browser on url without waiting for it to exit. configured
+// is the browser command from the configuration, empty for the platform's
+// default opener.
+func Open(configured string, url string) error {
+	if err := CheckURL(url); err != nil {
+		return err
+	}
+	name, args := Command(runtime.GOOS, configured, url)
+	cmd := exec.Command(name, args...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// CheckURL accepts only http and https URLs with a host. Check links come
+// from CI systems, and the platform openers would just as readily open a
+// file:// URL or hand one to any other registered scheme handler.
+func CheckURL(raw string) error {
+	if raw == "" {
+		return errors.New("no web page to open")
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("not a web page: %q", raw)
+	}
+	if scheme := strings.ToLower(parsed.Scheme); scheme != "http" && scheme != "https" {
+		return fmt.Errorf("not a web page: %q", raw)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package browser
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCommand(t *testing.T) {
+	t.Parallel()
+
+	const url = "https://github.com/octo/demo"
+	tests := []struct {
+		name       string
+		goos       string
+		configured string
+		wantName   string
+		wantArgs   []string
+	}{
+		{name: "linux", goos: "linux", wantName: "xdg-open", wantArgs: []string{url}},
+		{name: "macOS", goos: "darwin", wantName: "open", wantArgs: []string{url}},
+		{name: "windows", goos: "windows", wantName: "rundll32", wantArgs: []string{"url.dll,FileProtocolHandler", url}},
+		{name: "configured", goos: "linux", configured: "firefox", wantName: "firefox", wantArgs: []string{url}},
+		{name: "configured with arguments", goos: "darwin", configured: " open -a Safari ", wantName: "open", wantArgs: []string{"-a", "Safari", url}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			name, args := Command(tt.goos, tt.configured, url)
+			if name != tt.wantName || !slices.Equal(args, tt.wantArgs) {
+				t.Errorf("Command() = %s %v, want %s %v", name, args, tt.wantName, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestCheckURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{url: "https://github.com/octo/demo/actions/runs/1"},
+		{url: "HTTP://ci.example.com/job/7"},
+		{url: "", wantErr: true},
+		{url: "file:///etc/passwd", wantErr: true},
+		{url: "javascript:alert(1)", wantErr: true},
+		{url: "smb://fileserver/share", wantErr: true},
+		{url: "ms-settings:", wantErr: true},
+		{url: "/tmp/build.log", wantErr: true},
+		{url: "https:///no-host", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			t.Parallel()
+			if err := CheckURL(tt.url); (err != nil) != tt.wantErr {
+				t.Errorf("CheckURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOpenRejectsOtherSchemes(t *testing.T) {
+	t.Parallel()
+
+	if err := Open("", "file:///etc/passwd"); err == nil {
+		t.Fatal("Open(file://) error = nil, want the URL rejected before a command starts")
+	}
+}
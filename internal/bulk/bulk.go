@@ -0,0 +1,127 @@
+package bulk
+
+import (
+	"sync"
+
+	"fresh/internal/domain"
+	"fresh/internal/git"
+)
+
+type Action string
+
+const (
+	ActionPull  Action = "pull"
+	ActionPrune Action = "prune"
+)
+
+type Status string
+
+const (
+	StatusOK      Status = "ok"
+	StatusFailed  Status = "failed"
+	StatusSkipped Status = "skipped"
+	// StatusPlanned marks work a dry run would have done.
+	StatusPlanned Status = "planned"
+)
+
+type Result struct {
+	Name     string   `json:"name"`
+	Path     string   `json:"path"`
+	Status   Status   `json:"status"`
+	Reason   string   `json:"reason,omitempty"`
+	Branches []string `json:"branches,omitempty"`
+	Deleted  int      `json:"deleted,omitempty"`
+	Failed   int      `json:"failed,omitempty"`
+}
+
+type Options struct {
+	DryRun      bool
+	Concurrency int
+}
+
+// Run applies action to repos with at most Concurrency repositories being
+// worked on at once. Repositories the action does not apply to are skipped
+// by the same rules the TUI uses: pull only repositories that are behind,
+// prune only branches already merged. Results keep the order of repos.
+func Run(action Action, repos []domain.Repository, opts Options) []Result {
+	results := make([]Result, len(repos))
+	concurrency := max(opts.Concurrency, 1)
+
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+	for i, repo := range repos {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			results[i] = run(action, repo, opts.DryRun)
+		}()
+	}
+	wg.Wait()
+
+	return results
+}
+
+func run(action Action, repo domain.Repository, dryRun bool) Result {
+	result := Result{Name: repo.Name, Path: repo.Path}
+
+	switch action {
+	case ActionPull:
+		if !repo.CanPull() {
+			result.Status = StatusSkipped
+			result.Reason = pullSkipReason(repo.RemoteState)
+			return result
+		}
+		if dryRun {
+			result.Status = StatusPlanned
+			return result
+		}
+		outcome := git.Pull(repo.Path, nil)
+		if !outcome.IsSuccess() {
+			result.Status = StatusFailed
+			result.Reason = outcome.FailureReason
+			return result
+		}
+		result.Status = StatusOK
+
+	case ActionPrune:
+		if len(repo.Branches.Merged) == 0 {
+			result.Status = StatusSkipped
+			result.Reason = "no merged branches"
+			return result
+		}
+		result.Branches = append([]string{}, repo.Branches.Merged...)
+		if dryRun {
+			result.Status = StatusPlanned
+			return result
+		}
+		outcome := git.DeleteBranches(repo.Path, repo.Branches.Merged, nil)
+		result.Deleted = outcome.DeletedCount
+		result.Failed = outcome.FailedCount
+		if !outcome.IsSuccess() {
+			result.Status = StatusFailed
+			result.Reason = outcome.FailureReason
+			return result
+		}
+		result.Status = StatusOK
+	}
+
+	return result
+}
+
+func pullSkipReason(state domain.RemoteState) string {
+	switch s := state.(type) {
+	case domain.NoUpstream:
+		return "no upstream"
+	case domain.DetachedRemote:
+		return "detached HEAD"
+	case domain.RemoteError:
+		return s.Message
+	case domain.Ahead:
+		return "ahead of upstream"
+	default:
+		return "up to date"
+	}
+}
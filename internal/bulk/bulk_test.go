@@ -0,0 +1,59 @@
+package bulk
+
+import (
+	"testing"
+
+	"fresh/internal/domain"
+	"fresh/internal/testhelpers"
+)
+
+func TestRunDryRunPlansOnlyEligibleRepositories(t *testing.T) {
+	t.Parallel()
+
+	behind := testhelpers.NewTestRepository("behind").RemoteState(domain.Behind{Count: 2}).Build()
+	synced := testhelpers.NewTestRepository("synced").RemoteState(domain.Synced{}).Build()
+	noUpstream := testhelpers.NewTestRepository("local").RemoteState(domain.NoUpstream{}).Build()
+
+	tests := []struct {
+		name   string
+		action Action
+		repo   domain.Repository
+		status Status
+		reason string
+	}{
+		{name: "pull behind", action: ActionPull, repo: behind, status: StatusPlanned},
+		{name: "pull synced", action: ActionPull, repo: synced, status: StatusSkipped, reason: "up to date"},
+		{name: "pull without upstream", action: ActionPull, repo: noUpstream, status: StatusSkipped, reason: "no upstream"},
+		{name: "prune without merged branches", action: ActionPrune, repo: synced, status: StatusSkipped, reason: "no merged branches"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Run(tt.action, []domain.Repository{tt.repo}, Options{DryRun: true, Concurrency: 1})
+			if len(got) != 1 || got[0].Status != tt.status || got[0].Reason != tt.reason {
+				t.Fatalf("Run() = %+v, want status %q reason %q", got, tt.status, tt.reason)
+			}
+		})
+	}
+}
+
+func TestRunPruneDryRunListsBranchesAndKeepsOrder(t *testing.T) {
+	t.Parallel()
+
+	first := testhelpers.NewTestRepository("first").Build()
+	first.Branches.Merged = []string{"done", "old"}
+	second := testhelpers.NewTestRepository("second").Build()
+
+	got := Run(ActionPrune, []domain.Repository{first, second}, Options{
+		DryRun:      true,
+		Concurrency: 2,
+	})
+
+	if len(got) != 2 || got[0].Name != "first" || got[1].Name != "second" {
+		t.Fatalf("Run() order = %+v", got)
+	}
+	if got[0].Status != StatusPlanned || len(got[0].Branches) != 2 {
+		t.Fatalf("first = %+v, want planned with 2 branches", got[0])
+	}
+}
@@ -0,0 +1,83 @@
+package bulk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"fresh/internal/report"
+)
+
+// Write prints summary in format. Table output leaves out skipped
+// repositories to keep scheduled runs quiet; JSON and NDJSON include them.
+// NDJSON writes one line per repository followed by a summary line without
+// the results.
+func Write(w io.Writer, format report.Format, summary Summary) error {
+	switch format {
+	case report.FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(summary)
+	case report.FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		for _, result := range summary.Results {
+			if err := encoder.Encode(result); err != nil {
+				return err
+			}
+		}
+		return encoder.Encode(struct {
+			Summary Totals `json:"summary"`
+		}{summary.Totals})
+	default:
+		return writeTable(w, summary)
+	}
+}
+
+func writeTable(w io.Writer, summary Summary) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, result := range summary.Results {
+		if result.Status == StatusSkipped {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Name, result.Status, tableDetail(summary.Action, result))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintln(w, summaryLine(summary))
+	return err
+}
+
+func tableDetail(action Action, result Result) string {
+	switch {
+	case result.Reason != "":
+		return result.Reason
+	case action == ActionPrune && result.Status == StatusPlanned:
+		return "would delete " + strings.Join(result.Branches, ", ")
+	case action == ActionPrune:
+		return fmt.Sprintf("deleted %d", result.Deleted)
+	case result.Status == StatusPlanned:
+		return "would pull"
+	default:
+		return "pulled"
+	}
+}
+
+func summaryLine(summary Summary) string {
+	var parts []string
+	if summary.DryRun {
+		parts = append(parts, fmt.Sprintf("%d planned", summary.Planned))
+	} else {
+		parts = append(parts, fmt.Sprintf("%d ok", summary.OK), fmt.Sprintf("%d failed", summary.Failed))
+	}
+	parts = append(parts, fmt.Sprintf("%d skipped", summary.Skipped))
+
+	line := fmt.Sprintf("%s: %s", summary.Action, strings.Join(parts, ", "))
+	if summary.DryRun {
+		line += " (dry run)"
+	}
+	return line
+}
@@ -0,0 +1,77 @@
+package bulk
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"fresh/internal/report"
+)
+
+func testSummary() Summary {
+	return Summarize(ActionPrune, false, []Result{
+		{Name: "api", Status: StatusOK, Branches: []string{"done"}, Deleted: 1},
+		{Name: "web", Status: StatusFailed, Reason: "not fully merged"},
+		{Name: "docs", Status: StatusSkipped, Reason: "no merged branches"},
+	})
+}
+
+func TestWriteTableHidesSkipped(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := Write(&buf, report.FormatTable, testSummary()); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"deleted 1", "not fully merged", "prune: 1 ok, 1 failed, 1 skipped"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("table missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "docs") {
+		t.Fatalf("table lists skipped repository:\n%s", out)
+	}
+}
+
+func TestWriteNDJSONEndsWithSummary(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := Write(&buf, report.FormatNDJSON, testSummary()); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("lines = %d, want 4:\n%s", len(lines), buf.String())
+	}
+	var last struct {
+		Summary Totals `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(lines[3]), &last); err != nil {
+		t.Fatalf("last line is not a summary: %v", err)
+	}
+	if last.Summary.Failed != 1 || last.Summary.Action != ActionPrune {
+		t.Fatalf("summary = %+v", last.Summary)
+	}
+}
+
+func TestWriteJSONIncludesResults(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := Write(&buf, report.FormatJSON, testSummary()); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	var got Summary
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if got.Total != 3 || len(got.Results) != 3 {
+		t.Fatalf("decoded = %+v", got)
+	}
+}
@@ -0,0 +1,59 @@
+package bulk
+
+// Summary is the machine-readable outcome of a bulk run.
+type Summary struct {
+	Totals
+	Results []Result `json:"results"`
+}
+
+type Totals struct {
+	Action  Action `json:"action"`
+	DryRun  bool   `json:"dry_run"`
+	Total   int    `json:"total"`
+	OK      int    `json:"ok"`
+	Failed  int    `json:"failed"`
+	Skipped int    `json:"skipped"`
+	Planned int    `json:"planned"`
+}
+
+// Exit codes for the headless pull and prune commands; 1 is left for usage
+// and runtime errors.
+const (
+	ExitOK     = 0
+	ExitFailed = 2
+)
+
+func Summarize(action Action, dryRun bool, results []Result) Summary {
+	summary := Summary{
+		Totals: Totals{
+			Action: action,
+			DryRun: dryRun,
+			Total:  len(results),
+		},
+		Results: results,
+	}
+	if summary.Results == nil {
+		summary.Results = []Result{}
+	}
+
+	for _, result := range results {
+		switch result.Status {
+		case StatusOK:
+			summary.OK++
+		case StatusFailed:
+			summary.Failed++
+		case StatusSkipped:
+			summary.Skipped++
+		case StatusPlanned:
+			summary.Planned++
+		}
+	}
+	return summary
+}
+
+func (s Summary) ExitCode() int {
+	if s.Failed > 0 {
+		return ExitFailed
+	}
+	return ExitOK
+}
@@ -0,0 +1,24 @@
+package bulk
+
+import "testing"
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	summary := Summarize(ActionPull, false, []Result{
+		{Status: StatusOK},
+		{Status: StatusOK},
+		{Status: StatusFailed},
+		{Status: StatusSkipped},
+	})
+
+	if summary.Total != 4 || summary.OK != 2 || summary.Failed != 1 || summary.Skipped != 1 {
+		t.Fatalf("Summarize() = %+v", summary.Totals)
+	}
+	if summary.ExitCode() != ExitFailed {
+		t.Fatalf("ExitCode() = %d, want %d", summary.ExitCode(), ExitFailed)
+	}
+	if empty := Summarize(ActionPrune, true, nil); empty.Results == nil || empty.ExitCode() != ExitOK {
+		t.Fatalf("empty summary = %+v", empty)
+	}
+}
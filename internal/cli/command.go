@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Command is one node of the command tree. The root command is the program
+// itself; every other command is reached by naming it after its parent.
+type Command struct {
+	Name        string
+	Summary     string
+	Description string
+	// Args describes the positional arguments for help, e.g. "[repo...]".
+	Args     string
+	Examples []string
+	Hidden   bool
+
+	// Flags declares the command's flags, binding them to the caller's
+	// variables.
+	Flags func(fs *FlagSet)
+	// Run executes the command and returns the process exit code. A nil
+	// Run shows the command's help, which suits pure command groups.
+	Run func(ctx *Context, args []string) (int, error)
+	// CompleteArgs suggests positional arguments. values holds the flags
+	// typed so far on the command line, by long name.
+	CompleteArgs func(values map[string]string) []Candidate
+
+	Subcommands []*Command
+
+	parent *Command
+	flags  *FlagSet
+}
+
+// Context carries what a running command writes to.
+type Context struct {
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Candidate is one completion suggestion.
+type Candidate struct {
+	Value       string
+	Description string
+}
+
+// ErrUsage marks an error caused by how the command was invoked; Execute
+// points the user at the command's help after printing it.
+var ErrUsage = errors.New("usage error")
+
+func Usagef(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrUsage, fmt.Sprintf(format, args...))
+}
+
+// Path is the command's full invocation, such as "fresh config path".
+func (c *Command) Path() string {
+	if c.parent == nil {
+		return c.Name
+	}
+	return c.parent.Path() + " " + c.Name
+}
+
+func (c *Command) FlagSet() *FlagSet {
+	if c.flags == nil {
+		c.flags = newFlagSet(c.Name)
+		if c.Flags != nil {
+			c.Flags(c.flags)
+		}
+	}
+	return c.flags
+}
+
+func (c *Command) subcommand(name string) *Command {
+	for _, sub := range c.Subcommands {
+		if sub.Name == name {
+			sub.parent = c
+			return sub
+		}
+	}
+	return nil
+}
+
+func (c *Command) visibleSubcommands() []*Command {
+	var visible []*Command
+	for _, sub := range c.Subcommands {
+		if !sub.Hidden {
+			visible = append(visible, sub)
+		}
+	}
+	return visible
+}
+
+// Parse finds the command args name and parses its flags. Subcommand names
+// are only recognised before the first flag or positional argument of
+// their parent, so `fresh status pull` passes "pull" to status. Flags may
+// follow positional arguments.
+func Parse(root *Command, args []string) (*Command, []string, error) {
+	cmd := root
+	for len(args) > 0 {
+		sub := cmd.subcommand(args[0])
+		if sub == nil {
+			break
+		}
+		cmd = sub
+		args = args[1:]
+	}
+
+	positional, err := cmd.FlagSet().parse(args)
+	if err != nil {
+		return cmd, nil, err
+	}
+	return cmd, positional, nil
+}
+
+// Execute parses args against root and runs the command they name. Help is
+// printed for --help and for commands without a Run function. Errors are
+// printed to ctx.Stderr and exit with status 1.
+func Execute(root *Command, args []string, ctx *Context) int {
+	if root.subcommand(completeCommand) == nil {
+		root.Subcommands = append(root.Subcommands, completionCommands(root)...)
+	}
+
+	cmd, rest, err := Parse(root, args)
+	switch {
+	case errors.Is(err, flag.ErrHelp):
+		WriteHelp(ctx.Stdout, cmd)
+		return 0
+	case err != nil:
+		return reportError(ctx, cmd, err)
+	case cmd.Run == nil:
+		if len(rest) > 0 {
+			return reportError(ctx, cmd, Usagef("unknown command %q", rest[0]))
+		}
+		WriteHelp(ctx.Stdout, cmd)
+		return 0
+	}
+
+	code, err := cmd.Run(ctx, rest)
+	if err != nil {
+		return reportError(ctx, cmd, err)
+	}
+	return code
+}
+
+func reportError(ctx *Context, cmd *Command, err error) int {
+	fmt.Fprintf(ctx.Stderr, "Error: %s\n", strings.TrimPrefix(err.Error(), ErrUsage.Error()+": "))
+	if errors.Is(err, ErrUsage) {
+		fmt.Fprintf(ctx.Stderr, "Run '%s --help' for usage.\n", cmd.Path())
+	}
+	return 1
+}
@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type testOptions struct {
+	dir    string
+	format string
+	dryRun bool
+	jobs   int
+}
+
+func testCommand(opts *testOptions) *Command {
+	return &Command{
+		Name:    "tool",
+		Summary: "A test tool",
+		Flags: func(fs *FlagSet) {
+			fs.String(&opts.dir, "dir", "d", "", "Directory to scan").Placeholder("path").Dirs()
+		},
+		Run: func(ctx *Context, args []string) (int, error) { return 0, nil },
+		Subcommands: []*Command{
+			{
+				Name:    "status",
+				Summary: "Print status",
+				Args:    "[repo...]",
+				Flags: func(fs *FlagSet) {
+					fs.String(&opts.format, "format", "f", "table", "Output format").OneOf("table", "json")
+					fs.Bool(&opts.dryRun, "dry-run", "n", false, "Change nothing")
+					fs.Int(&opts.jobs, "jobs", "j", 4, "Parallel jobs")
+				},
+				Run: func(ctx *Context, args []string) (int, error) {
+					return len(args), nil
+				},
+				CompleteArgs: func(values map[string]string) []Candidate {
+					return []Candidate{{Value: "api", Description: values["format"]}, {Value: "web"}}
+				},
+			},
+			{
+				Name:    "config",
+				Summary: "Inspect configuration",
+				Subcommands: []*Command{
+					{Name: "path", Summary: "Print the path", Run: func(ctx *Context, args []string) (int, error) {
+						return 0, errors.New("no config")
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []string
+		wantCmd  string
+		wantArgs []string
+		want     testOptions
+		wantErr  bool
+	}{
+		{name: "root flags", args: []string{"-d", "/src"}, wantCmd: "tool", want: testOptions{dir: "/src"}},
+		{name: "subcommand defaults", args: []string{"status"}, wantCmd: "status", want: testOptions{format: "table", jobs: 4}},
+		{
+			name:     "subcommand flags and args",
+			args:     []string{"status", "--format", "JSON", "-n", "-j", "2", "api", "web"},
+			wantCmd:  "status",
+			wantArgs: []string{"api", "web"},
+			want:     testOptions{format: "json", dryRun: true, jobs: 2},
+		},
+		{
+			name:     "flags after args",
+			args:     []string{"status", "api", "--format", "json", "web", "-n"},
+			wantCmd:  "status",
+			wantArgs: []string{"api", "web"},
+			want:     testOptions{format: "json", dryRun: true, jobs: 4},
+		},
+		{
+			name:     "args after terminator",
+			args:     []string{"status", "api", "--", "-n", "web"},
+			wantCmd:  "status",
+			wantArgs: []string{"api", "-n", "web"},
+			want:     testOptions{format: "table", jobs: 4},
+		},
+		{name: "unknown flag after args", args: []string{"status", "api", "--nope"}, wantCmd: "status", wantErr: true},
+		{name: "nested subcommand", args: []string{"config", "path"}, wantCmd: "path"},
+		{name: "invalid value", args: []string{"status", "--format", "xml"}, wantCmd: "status", wantErr: true},
+		{name: "unknown flag", args: []string{"status", "--nope"}, wantCmd: "status", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var opts testOptions
+			cmd, args, err := Parse(testCommand(&opts), tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if cmd.Name != tt.wantCmd {
+				t.Errorf("Parse() command = %q, want %q", cmd.Name, tt.wantCmd)
+			}
+			if tt.wantErr {
+				return
+			}
+			if strings.Join(args, " ") != strings.Join(tt.wantArgs, " ") {
+				t.Errorf("Parse() args = %v, want %v", args, tt.wantArgs)
+			}
+			if opts != tt.want {
+				t.Errorf("Parse() options = %+v, want %+v", opts, tt.want)
+			}
+		})
+	}
+}
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{name: "runs command", args: []string{"status", "a", "b"}, wantCode: 2},
+		{name: "help flag", args: []string{"status", "--help"}, wantStdout: "Usage: tool status [options] [repo...]"},
+		{name: "group shows help", args: []string{"config"}, wantStdout: "Usage: tool config <command> [options]"},
+		{name: "usage error", args: []string{"status", "-f", "xml"}, wantCode: 1, wantStderr: "Run 'tool status --help' for usage."},
+		{name: "unknown group command", args: []string{"config", "nope"}, wantCode: 1, wantStderr: `unknown command "nope"`},
+		{name: "run error", args: []string{"config", "path"}, wantCode: 1, wantStderr: "Error: no config\n"},
+		{name: "completion script", args: []string{"completion", "fish"}, wantStdout: "complete -c tool"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var opts testOptions
+			var stdout, stderr bytes.Buffer
+			code := Execute(testCommand(&opts), tt.args, &Context{Stdout: &stdout, Stderr: &stderr})
+			if code != tt.wantCode {
+				t.Errorf("Execute() = %d, want %d (stderr %q)", code, tt.wantCode, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.wantStdout) {
+				t.Errorf("Execute() stdout = %q, want it to contain %q", stdout.String(), tt.wantStdout)
+			}
+			if !strings.Contains(stderr.String(), tt.wantStderr) {
+				t.Errorf("Execute() stderr = %q, want it to contain %q", stderr.String(), tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestWriteHelp(t *testing.T) {
+	t.Parallel()
+
+	var opts testOptions
+	root := testCommand(&opts)
+	cmd, _, _ := Parse(root, []string{"status"})
+
+	var out bytes.Buffer
+	WriteHelp(&out, cmd)
+
+	for _, want := range []string{
+		"Usage: tool status [options] [repo...]",
+		"-f, --format <value>   Output format: table or json (default table)",
+		"-n, --dry-run",
+		"-j, --jobs <n>         Parallel jobs (default 4)",
+		"-h, --help",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("WriteHelp() missing %q in:\n%s", want, out.String())
+		}
+	}
+}
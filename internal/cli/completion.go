@@ -0,0 +1,268 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// The shell scripts call back into the program with the words typed so far
+// and print the candidates it returns, so completions always follow the
+// current command tree. The first line of the reply is a directive: the
+// scripts fall back to directory completion on directiveDirs.
+const (
+	completeCommand = "__complete"
+	directiveNone   = ":none"
+	directiveDirs   = ":dirs"
+)
+
+var shells = []string{"bash", "zsh", "fish"}
+
+func completionCommands(root *Command) []*Command {
+	return []*Command{
+		{
+			Name:    "completion",
+			Summary: "Print a shell completion script",
+			Description: "Print a completion script for bash, zsh or fish. Load it from your shell's startup file, for example:\n" +
+				"  source <(" + root.Name + " completion bash)\n" +
+				"  " + root.Name + " completion fish > ~/.config/fish/completions/" + root.Name + ".fish",
+			Args: "<bash|zsh|fish>",
+			Run: func(ctx *Context, args []string) (int, error) {
+				if len(args) != 1 {
+					return 1, Usagef("expected one shell: %s", joinAlternatives(shells))
+				}
+				return 0, WriteCompletionScript(ctx.Stdout, root.Name, args[0])
+			},
+			CompleteArgs: func(map[string]string) []Candidate {
+				candidates := make([]Candidate, len(shells))
+				for i, shell := range shells {
+					candidates[i] = Candidate{Value: shell}
+				}
+				return candidates
+			},
+		},
+		{
+			Name:   completeCommand,
+			Hidden: true,
+			Run: func(ctx *Context, args []string) (int, error) {
+				directive, candidates := Complete(root, args)
+				fmt.Fprintln(ctx.Stdout, directive)
+				for _, candidate := range candidates {
+					fmt.Fprintf(ctx.Stdout, "%s\t%s\n", candidate.Value, candidate.Description)
+				}
+				return 0, nil
+			},
+		},
+	}
+}
+
+// Complete returns the completion directive and candidates for words, the
+// arguments typed after the program name. The last word is the one being
+// completed and may be empty.
+func Complete(root *Command, words []string) (string, []Candidate) {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	partial := words[len(words)-1]
+	typed := words[:len(words)-1]
+
+	cmd := root
+	values := make(map[string]string)
+	var pending *Flag
+	positional := false
+	flagsDone := false
+	for _, word := range typed {
+		switch {
+		case pending != nil && word == "=":
+			// bash splits --flag=value into three words.
+		case pending != nil:
+			values[pending.Name] = word
+			pending = nil
+		case !flagsDone && word == "--":
+			flagsDone = true
+		case !flagsDone && strings.HasPrefix(word, "-") && word != "-":
+			name, value, hasValue := strings.Cut(strings.TrimLeft(word, "-"), "=")
+			spec := cmd.FlagSet().lookup(name)
+			if spec == nil {
+				continue
+			}
+			switch {
+			case hasValue:
+				values[spec.Name] = value
+			case spec.IsBool:
+				values[spec.Name] = "true"
+			default:
+				pending = spec
+			}
+		case !positional:
+			if sub := cmd.subcommand(word); sub != nil {
+				cmd = sub
+				continue
+			}
+			positional = true
+		}
+	}
+
+	if pending != nil {
+		if partial == "=" {
+			partial = ""
+		}
+		return completeFlagValue(pending, values, "", partial)
+	}
+	if !flagsDone && strings.HasPrefix(partial, "--") && strings.Contains(partial, "=") {
+		name, value, _ := strings.Cut(strings.TrimPrefix(partial, "--"), "=")
+		if spec := cmd.FlagSet().lookup(name); spec != nil && !spec.IsBool {
+			return completeFlagValue(spec, values, "--"+name+"=", value)
+		}
+		return directiveNone, nil
+	}
+	if !flagsDone && strings.HasPrefix(partial, "-") {
+		return directiveNone, filterCandidates(flagCandidates(cmd), partial)
+	}
+
+	var candidates []Candidate
+	if !positional {
+		for _, sub := range cmd.visibleSubcommands() {
+			candidates = append(candidates, Candidate{Value: sub.Name, Description: sub.Summary})
+		}
+	}
+	if cmd.CompleteArgs != nil {
+		candidates = append(candidates, cmd.CompleteArgs(values)...)
+	}
+	return directiveNone, filterCandidates(candidates, partial)
+}
+
+func completeFlagValue(spec *Flag, values map[string]string, prefix string, partial string) (string, []Candidate) {
+	if spec.dirs {
+		return directiveDirs, nil
+	}
+
+	var candidates []Candidate
+	for _, value := range spec.values {
+		candidates = append(candidates, Candidate{Value: value})
+	}
+	if spec.complete != nil {
+		candidates = append(candidates, spec.complete(values)...)
+	}
+
+	candidates = filterCandidates(candidates, partial)
+	for i := range candidates {
+		candidates[i].Value = prefix + candidates[i].Value
+	}
+	return directiveNone, candidates
+}
+
+func flagCandidates(cmd *Command) []Candidate {
+	candidates := []Candidate{{Value: "--help", Description: "Show this help message"}}
+	for _, spec := range cmd.FlagSet().specs {
+		candidates = append(candidates, Candidate{Value: "--" + spec.Name, Description: spec.Usage})
+		if spec.Short != "" {
+			candidates = append(candidates, Candidate{Value: "-" + spec.Short, Description: spec.Usage})
+		}
+	}
+	return candidates
+}
+
+func filterCandidates(candidates []Candidate, partial string) []Candidate {
+	var matched []Candidate
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		if !strings.HasPrefix(candidate.Value, partial) || seen[candidate.Value] {
+			continue
+		}
+		seen[candidate.Value] = true
+		matched = append(matched, candidate)
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].Value < matched[j].Value })
+	return matched
+}
+
+func WriteCompletionScript(w io.Writer, program string, shell string) error {
+	var script string
+	switch shell {
+	case "bash":
+		script = bashCompletion
+	case "zsh":
+		script = zshCompletion
+	case "fish":
+		script = fishCompletion
+	default:
+		return Usagef("unsupported shell %q (want %s)", shell, joinAlternatives(shells))
+	}
+
+	script = strings.ReplaceAll(script, "{{program}}", program)
+	script = strings.ReplaceAll(script, "{{complete}}", completeCommand)
+	_, err := io.WriteString(w, script)
+	return err
+}
+
+// bashCompletion avoids mapfile so it also works with the bash 3.2 that
+// ships with macOS.
+const bashCompletion = `# bash completion for {{program}}
+_{{program}}_completions() {
+    local cur="${COMP_WORDS[COMP_CWORD]}"
+    local directive="" line first=1
+    COMPREPLY=()
+    while IFS= read -r line; do
+        if [[ $first -eq 1 ]]; then
+            directive="$line"
+            first=0
+            continue
+        fi
+        COMPREPLY+=("${line%%$'\t'*}")
+    done < <({{program}} {{complete}} -- "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null)
+
+    if [[ "$directive" == ":dirs" ]]; then
+        COMPREPLY=($(compgen -d -- "$cur"))
+    fi
+}
+complete -o default -F _{{program}}_completions {{program}}
+`
+
+const zshCompletion = `#compdef {{program}}
+# zsh completion for {{program}}
+_{{program}}() {
+    local -a lines candidates
+    local line
+    lines=("${(@f)$({{program}} {{complete}} -- "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+    (( ${#lines} == 0 )) && return 1
+
+    if [[ "${lines[1]}" == ":dirs" ]]; then
+        _files -/
+        return
+    fi
+
+    for line in "${(@)lines[2,-1]}"; do
+        [[ -z "$line" ]] && continue
+        candidates+=("${${line%%$'\t'*}//:/\\:}:${line#*$'\t'}")
+    done
+    _describe '{{program}}' candidates
+}
+
+if [[ "${funcstack[1]}" == "_{{program}}" ]]; then
+    _{{program}} "$@"
+else
+    compdef _{{program}} {{program}}
+fi
+`
+
+const fishCompletion = `# fish completion for {{program}}
+function __{{program}}_complete
+    set -l tokens (commandline -opc)
+    set -e tokens[1]
+    set -l current (commandline -ct)
+    set -l lines ({{program}} {{complete}} -- $tokens $current 2>/dev/null)
+    test (count $lines) -eq 0; and return
+
+    if test "$lines[1]" = ":dirs"
+        __fish_complete_directories $current
+        return
+    end
+    if test (count $lines) -gt 1
+        printf '%s\n' $lines[2..-1]
+    end
+end
+
+complete -c {{program}} -f -a '(__{{program}}_complete)'
+`
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		words         []string
+		wantDirective string
+		want          []string
+	}{
+		{name: "subcommands", words: []string{""}, wantDirective: directiveNone, want: []string{"completion", "config", "status"}},
+		{name: "subcommand prefix", words: []string{"st"}, wantDirective: directiveNone, want: []string{"status"}},
+		{name: "nested subcommands", words: []string{"config", ""}, wantDirective: directiveNone, want: []string{"path"}},
+		{name: "flag names", words: []string{"status", "--f"}, wantDirective: directiveNone, want: []string{"--format"}},
+		{name: "short flag names", words: []string{"status", "-"}, wantDirective: directiveNone, want: []string{"--dry-run", "--format", "--help", "--jobs", "-f", "-j", "-n"}},
+		{name: "flag values", words: []string{"status", "--format", ""}, wantDirective: directiveNone, want: []string{"json", "table"}},
+		{name: "bash split flag values", words: []string{"status", "--format", "=", "j"}, wantDirective: directiveNone, want: []string{"json"}},
+		{name: "inline flag values", words: []string{"status", "--format=t"}, wantDirective: directiveNone, want: []string{"--format=table"}},
+		{name: "directory flag", words: []string{"-d", ""}, wantDirective: directiveDirs},
+		{name: "positional args", words: []string{"status", "-n", "a"}, wantDirective: directiveNone, want: []string{"api"}},
+		{name: "args after flag value", words: []string{"status", "-f", "json", "api", ""}, wantDirective: directiveNone, want: []string{"api", "web"}},
+		{name: "no flags after --", words: []string{"status", "--", "-"}, wantDirective: directiveNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var opts testOptions
+			root := testCommand(&opts)
+			root.Subcommands = append(root.Subcommands, completionCommands(root)...)
+
+			directive, candidates := Complete(root, tt.words)
+			if directive != tt.wantDirective {
+				t.Errorf("Complete() directive = %q, want %q", directive, tt.wantDirective)
+			}
+			var got []string
+			for _, candidate := range candidates {
+				got = append(got, candidate.Value)
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("Complete() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompletePassesFlagValues(t *testing.T) {
+	t.Parallel()
+
+	var opts testOptions
+	_, candidates := Complete(testCommand(&opts), []string{"status", "--format=json", "a"})
+	if len(candidates) != 1 || candidates[0].Description != "json" {
+		t.Fatalf("Complete() = %+v, want api described by the typed format", candidates)
+	}
+}
+
+func TestWriteCompletionScript(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		shell   string
+		want    string
+		wantErr bool
+	}{
+		{shell: "bash", want: "complete -o default -F _tool_completions tool"},
+		{shell: "zsh", want: "#compdef tool"},
+		{shell: "fish", want: "tool __complete -- $tokens $current"},
+		{shell: "powershell", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			err := WriteCompletionScript(&out, "tool", tt.shell)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("WriteCompletionScript() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !strings.Contains(out.String(), tt.want) {
+				t.Errorf("WriteCompletionScript() = %q, want it to contain %q", out.String(), tt.want)
+			}
+			if strings.Contains(out.String(), "{{") {
+				t.Errorf("WriteCompletionScript() left a template placeholder:\n%s", out.String())
+			}
+		})
+	}
+}
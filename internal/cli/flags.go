@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// FlagSet wraps flag.FlagSet so every flag can have a long and a short name
+// and carry what help and shell completion need to know about it.
+type FlagSet struct {
+	fs    *flag.FlagSet
+	specs []*Flag
+}
+
+// Flag describes one flag. Short is the single-letter alias, if any.
+type Flag struct {
+	Name    string
+	Short   string
+	Usage   string
+	Default string
+	IsBool  bool
+
+	placeholder string
+	values      []string
+	dirs        bool
+	complete    func(values map[string]string) []Candidate
+}
+
+func newFlagSet(name string) *FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return &FlagSet{fs: fs}
+}
+
+func (f *FlagSet) String(p *string, name, short, value, usage string) *Flag {
+	f.fs.StringVar(p, name, value, usage)
+	if short != "" {
+		f.fs.StringVar(p, short, value, usage)
+	}
+	return f.add(&Flag{Name: name, Short: short, Usage: usage, Default: value, placeholder: "value"})
+}
+
+func (f *FlagSet) Bool(p *bool, name, short string, value bool, usage string) *Flag {
+	f.fs.BoolVar(p, name, value, usage)
+	if short != "" {
+		f.fs.BoolVar(p, short, value, usage)
+	}
+	spec := &Flag{Name: name, Short: short, Usage: usage, IsBool: true}
+	if value {
+		spec.Default = "true"
+	}
+	return f.add(spec)
+}
+
+func (f *FlagSet) Int(p *int, name, short string, value int, usage string) *Flag {
+	f.fs.IntVar(p, name, value, usage)
+	if short != "" {
+		f.fs.IntVar(p, short, value, usage)
+	}
+	return f.add(&Flag{Name: name, Short: short, Usage: usage, Default: strconv.Itoa(value), placeholder: "n"})
+}
+
+func (f *FlagSet) add(spec *Flag) *Flag {
+	f.specs = append(f.specs, spec)
+	return spec
+}
+
+// Placeholder names the flag's value in help, as in "--dir <path>".
+func (s *Flag) Placeholder(name string) *Flag {
+	s.placeholder = name
+	return s
+}
+
+// OneOf restricts the flag to values, which are also offered as completions.
+func (s *Flag) OneOf(values ...string) *Flag {
+	s.values = values
+	return s
+}
+
+// Dirs completes the flag's value with directory names.
+func (s *Flag) Dirs() *Flag {
+	s.dirs = true
+	return s
+}
+
+// CompleteWith completes the flag's value by calling fn with the flags typed
+// so far.
+func (s *Flag) CompleteWith(fn func(values map[string]string) []Candidate) *Flag {
+	s.complete = fn
+	return s
+}
+
+func (f *FlagSet) lookup(name string) *Flag {
+	for _, spec := range f.specs {
+		if spec.Name == name || (spec.Short != "" && spec.Short == name) {
+			return spec
+		}
+	}
+	return nil
+}
+
+// parse parses flags wherever they appear among args, as completion offers
+// them, and returns the positional arguments. Everything after "--" is
+// positional.
+func (f *FlagSet) parse(args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := f.fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, Usagef("%s", err.Error())
+		}
+		rest := f.fs.Args()
+		if len(rest) == 0 {
+			break
+		}
+		// flag stops at the first positional argument, or consumes "--"
+		// and stops after it.
+		if len(rest) < len(args) && args[len(args)-len(rest)-1] == "--" {
+			positional = append(positional, rest...)
+			break
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+
+	for _, spec := range f.specs {
+		if len(spec.values) == 0 {
+			continue
+		}
+		value := f.fs.Lookup(spec.Name).Value.String()
+		canonical, ok := lookupFold(spec.values, value)
+		if !ok {
+			return nil, Usagef("invalid value %q for --%s (want %s)", value, spec.Name, joinAlternatives(spec.values))
+		}
+		// Both names share the variable, so setting one normalises it.
+		f.fs.Set(spec.Name, canonical)
+	}
+	return positional, nil
+}
+
+// lookupFold finds value in values ignoring case and returns its canonical
+// spelling.
+func lookupFold(values []string, value string) (string, bool) {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// joinAlternatives renders values as "a, b or c".
+func joinAlternatives(values []string) string {
+	switch len(values) {
+	case 0:
+		return ""
+	case 1:
+		return values[0]
+	default:
+		return strings.Join(values[:len(values)-1], ", ") + " or " + values[len(values)-1]
+	}
+}
+
+// usageName renders the flag's names for help, such as "-d, --dir <path>".
+func (s *Flag) usageName() string {
+	name := "--" + s.Name
+	if s.Short != "" {
+		name = "-" + s.Short + ", " + name
+	} else {
+		name = "    " + name
+	}
+	if !s.IsBool {
+		name += fmt.Sprintf(" <%s>", s.placeholder)
+	}
+	return name
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// WriteHelp prints help for cmd generated from its description, subcommands
+// and flags.
+func WriteHelp(w io.Writer, cmd *Command) {
+	fmt.Fprintln(w, "")
+
+	usage := "Usage: " + cmd.Path()
+	if len(cmd.visibleSubcommands()) > 0 {
+		if cmd.Run != nil {
+			fmt.Fprintf(w, "%s [options] %s\n", usage, cmd.Args)
+			usage = strings.Repeat(" ", len("Usage:")) + " " + cmd.Path()
+		}
+		fmt.Fprintf(w, "%s <command> [options]\n", usage)
+	} else {
+		fmt.Fprintln(w, strings.TrimSpace(usage+" [options] "+cmd.Args))
+	}
+
+	if description := firstNonEmpty(cmd.Description, cmd.Summary); description != "" {
+		fmt.Fprintf(w, "\n%s\n", description)
+	}
+
+	if subs := cmd.visibleSubcommands(); len(subs) > 0 {
+		fmt.Fprintln(w, "\nCommands:")
+		tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		for _, sub := range subs {
+			fmt.Fprintf(tw, "  %s\t%s\n", sub.Name, sub.Summary)
+		}
+		tw.Flush()
+	}
+
+	fmt.Fprintln(w, "\nOptions:")
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	for _, spec := range cmd.FlagSet().specs {
+		fmt.Fprintf(tw, "  %s\t%s\n", spec.usageName(), spec.usageText())
+	}
+	fmt.Fprintf(tw, "  -h, --help\tShow this help message\n")
+	tw.Flush()
+
+	if len(cmd.Examples) > 0 {
+		fmt.Fprintln(w, "\nExamples:")
+		for _, example := range cmd.Examples {
+			fmt.Fprintf(w, "  %s\n", example)
+		}
+	}
+	fmt.Fprintln(w, "")
+}
+
+func (s *Flag) usageText() string {
+	text := s.Usage
+	switch {
+	case len(s.values) > 0:
+		text += fmt.Sprintf(": %s", joinAlternatives(s.values))
+		if s.Default != "" {
+			text += fmt.Sprintf(" (default %s)", s.Default)
+		}
+	case s.Default != "" && s.Default != "0":
+		text += fmt.Sprintf(" (default %s)", s.Default)
+	}
+	return text
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
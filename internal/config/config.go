@@ -8,9 +8,81 @@ type TimeoutConfig struct {
 	Fetch   time.Duration
 }
 
+// FetchConfig controls the git fetch run when repositories are refreshed.
+// By default it is a plain git fetch of the default remote.
+type FetchConfig struct {
+	// AllRemotes fetches every configured remote instead of only the
+	// default one.
+	AllRemotes bool
+	// Prune removes remote-tracking branches deleted on the remote.
+	Prune bool
+	// Tags fetches every tag, not only those pointing at fetched commits.
+	// Together with Prune it also deletes local tags the remote does not
+	// have, since git fetch --prune --tags prunes refs/tags as well.
+	Tags bool
+}
+
+// GitHub clients that can fetch pull requests.
+const (
+	// GitHubClientCLI runs the gh CLI for every query.
+	GitHubClientCLI = "gh"
+	// GitHubClientAPI calls the GitHub API directly.
+	GitHubClientAPI = "api"
+)
+
+// GitHubConfig says which remotes are GitHub, including GitHub Enterprise
+// Server instances, and how their pull requests are fetched.
+type GitHubConfig struct {
+	// Hosts lists GitHub hostnames; only these are treated as GitHub.
+	Hosts []string
+	// Client is GitHubClientCLI or GitHubClientAPI.
+	Client string
+	// Token authenticates GitHubClientAPI against github.com. When empty
+	// the token comes from the environment or from gh's stored credentials.
+	Token string
+	// Instances holds the tokens of GitHub Enterprise Server hosts. Each
+	// token is only sent to its own host.
+	Instances []Instance
+}
+
+// GitLabConfig says which remotes are GitLab instances whose merge requests
+// should be shown.
+type GitLabConfig struct {
+	// Hosts lists GitLab hostnames; only these are treated as GitLab.
+	Hosts []string
+	// Instances holds the tokens of self-managed hosts. Each token is only
+	// sent to its own host.
+	Instances []Instance
+}
+
+// GiteaConfig lists the Gitea and Forgejo instances whose pull requests
+// should be shown. Unlike GitHub and GitLab these have no public default
+// host, so every instance is configured with its token.
+type GiteaConfig struct {
+	Instances []Instance
+}
+
+// Instance is a self-hosted forge and the token sent to it.
+type Instance struct {
+	Host  string
+	Token string
+}
+
 type Config struct {
 	ProtectedBranches []string
 	Timeout           TimeoutConfig
+	Fetch             FetchConfig
+	// StaleStashAge is how old a stash must be before the stash view
+	// highlights it as forgotten work.
+	StaleStashAge time.Duration
+	// Workspaces maps workspace names to the directory each one scans.
+	Workspaces map[string]string
+	GitHub     GitHubConfig
+	GitLab     GitLabConfig
+	Gitea      GiteaConfig
+	// Browser is the command web pages are opened with, given the URL as
+	// its last argument. When empty the platform's opener is used.
+	Browser string
 }
 
 func DefaultConfig() *Config {
@@ -29,5 +101,13 @@ func DefaultConfig() *Config {
 			Pull:    2 * time.Minute,
 			Fetch:   1 * time.Minute,
 		},
+		StaleStashAge: 30 * 24 * time.Hour,
+		GitHub: GitHubConfig{
+			Hosts:  []string{"github.com"},
+			Client: GitHubClientCLI,
+		},
+		GitLab: GitLabConfig{
+			Hosts: []string{"gitlab.com"},
+		},
 	}
 }
@@ -0,0 +1,292 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvConfigPath overrides where the configuration file is read from.
+const EnvConfigPath = "FRESH_CONFIG"
+
+// File is the user's configuration file. Settings left out of the file keep
+// their defaults.
+type File struct {
+	// Workspaces names directories to scan, so `fresh -w work` can stand in
+	// for `fresh --dir ~/src/work`.
+	Workspaces map[string]string `json:"workspaces,omitempty"`
+	GitHub     *GitHubFile       `json:"github,omitempty"`
+	GitLab     *GitLabFile       `json:"gitlab,omitempty"`
+	Gitea      *GiteaFile        `json:"gitea,omitempty"`
+	// Browser replaces xdg-open or open, e.g. "firefox --new-tab".
+	Browser string     `json:"browser,omitempty"`
+	Fetch   *FetchFile `json:"fetch,omitempty"`
+	// StaleStashAge is how old a stash gets before it is highlighted, such
+	// as "14d" or "36h".
+	StaleStashAge Duration `json:"stale_stash_age,omitempty"`
+}
+
+// Duration is a time.Duration written as a string, which may also count
+// whole days, as in "30d".
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("duration must be a string such as \"30d\" or \"12h\"")
+	}
+	parsed, err := parseDuration(value)
+	if err != nil {
+		return err
+	}
+	*d = Duration(parsed)
+	return nil
+}
+
+// MarshalJSON writes d the way UnmarshalJSON reads it, in days when it is
+// a whole number of them.
+func (d Duration) MarshalJSON() ([]byte, error) {
+	value := time.Duration(d)
+	if day := 24 * time.Hour; value > 0 && value%day == 0 {
+		return json.Marshal(strconv.FormatInt(int64(value/day), 10) + "d")
+	}
+	return json.Marshal(value.String())
+}
+
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed < 0 {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return parsed, nil
+}
+
+// FetchFile turns on fetching every remote, pruning deleted branches and
+// fetching all tags when repositories are refreshed. Prune and Tags together
+// also delete local tags the remote does not have.
+type FetchFile struct {
+	AllRemotes bool `json:"all_remotes,omitempty"`
+	Prune      bool `json:"prune,omitempty"`
+	Tags       bool `json:"tags,omitempty"`
+}
+
+// GitHubFile configures GitHub hosts and, with Client set to "api", the
+// built-in API client instead of gh. Token and TokenEnv, which works as
+// for an instance, are for github.com; Instances adds Enterprise hosts
+// with their own tokens.
+type GitHubFile struct {
+	Hosts     []string       `json:"hosts,omitempty"`
+	Client    string         `json:"client,omitempty"`
+	Token     string         `json:"token,omitempty"`
+	TokenEnv  string         `json:"token_env,omitempty"`
+	Instances []InstanceFile `json:"instances,omitempty"`
+}
+
+type GiteaFile struct {
+	Instances []InstanceFile `json:"instances,omitempty"`
+}
+
+// InstanceFile configures one self-hosted forge. TokenEnv names an
+// environment variable holding the token, which keeps it out of the file;
+// it takes precedence over Token.
+type InstanceFile struct {
+	Host     string `json:"host"`
+	Token    string `json:"token,omitempty"`
+	TokenEnv string `json:"token_env,omitempty"`
+}
+
+// GitLabFile lists self-managed GitLab hosts in addition to gitlab.com.
+// Instances adds hosts with their own tokens.
+type GitLabFile struct {
+	Hosts     []string       `json:"hosts,omitempty"`
+	Instances []InstanceFile `json:"instances,omitempty"`
+}
+
+// FilePath returns $FRESH_CONFIG, or config.json under the user's
+// configuration directory.
+func FilePath() (string, error) {
+	if path := os.Getenv(EnvConfigPath); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "fresh", "config.json"), nil
+}
+
+// LoadFile reads the configuration file at path. A missing file is not an
+// error and yields an empty File.
+func LoadFile(path string) (File, error) {
+	var file File
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return file, err
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return file, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return file, nil
+}
+
+// Load returns the defaults with the user's configuration file applied.
+func Load() (*Config, error) {
+	cfg := DefaultConfig()
+
+	path, err := FilePath()
+	if err != nil {
+		return cfg, nil
+	}
+	file, err := LoadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Apply(file)
+	return cfg, nil
+}
+
+// Apply overrides the settings file sets.
+func (c *Config) Apply(file File) {
+	if len(file.Workspaces) > 0 {
+		c.Workspaces = make(map[string]string, len(file.Workspaces))
+		for name, dir := range file.Workspaces {
+			c.Workspaces[name] = ExpandHome(dir)
+		}
+	}
+	if file.GitHub != nil {
+		c.GitHub.Hosts = appendHosts(c.GitHub.Hosts, file.GitHub.Hosts)
+		if client := strings.ToLower(strings.TrimSpace(file.GitHub.Client)); client != "" {
+			c.GitHub.Client = client
+		}
+		if token := resolveToken(file.GitHub.Token, file.GitHub.TokenEnv); token != "" {
+			c.GitHub.Token = token
+		}
+		c.GitHub.Instances = appendInstances(c.GitHub.Instances, file.GitHub.Instances)
+		for _, instance := range c.GitHub.Instances {
+			c.GitHub.Hosts = appendHosts(c.GitHub.Hosts, []string{instance.Host})
+		}
+	}
+	if browser := strings.TrimSpace(file.Browser); browser != "" {
+		c.Browser = browser
+	}
+	if file.StaleStashAge > 0 {
+		c.StaleStashAge = time.Duration(file.StaleStashAge)
+	}
+	if file.Fetch != nil {
+		c.Fetch = FetchConfig{AllRemotes: file.Fetch.AllRemotes, Prune: file.Fetch.Prune, Tags: file.Fetch.Tags}
+	}
+	if file.GitLab != nil {
+		c.GitLab.Hosts = appendHosts(c.GitLab.Hosts, file.GitLab.Hosts)
+		c.GitLab.Instances = appendInstances(c.GitLab.Instances, file.GitLab.Instances)
+		for _, instance := range c.GitLab.Instances {
+			c.GitLab.Hosts = appendHosts(c.GitLab.Hosts, []string{instance.Host})
+		}
+	}
+	if file.Gitea != nil {
+		c.Gitea.Instances = appendInstances(c.Gitea.Instances, file.Gitea.Instances)
+	}
+}
+
+func appendInstances(instances []Instance, added []InstanceFile) []Instance {
+	for _, instance := range added {
+		host := strings.ToLower(strings.TrimSpace(instance.Host))
+		if host == "" {
+			continue
+		}
+		token := resolveToken(instance.Token, instance.TokenEnv)
+		instances = append(instances, Instance{Host: host, Token: token})
+	}
+	return instances
+}
+
+// RedactedToken stands in for inline tokens when the file is printed.
+const RedactedToken = "***"
+
+// Redacted returns a copy of f with every inline token replaced by
+// RedactedToken, so the file can be printed without leaking them.
+func (f File) Redacted() File {
+	if f.GitHub != nil {
+		github := *f.GitHub
+		github.Token = redactToken(github.Token)
+		github.Instances = redactInstances(github.Instances)
+		f.GitHub = &github
+	}
+	if f.GitLab != nil {
+		gitlab := *f.GitLab
+		gitlab.Instances = redactInstances(gitlab.Instances)
+		f.GitLab = &gitlab
+	}
+	if f.Gitea != nil {
+		gitea := *f.Gitea
+		gitea.Instances = redactInstances(gitea.Instances)
+		f.Gitea = &gitea
+	}
+	return f
+}
+
+func redactInstances(instances []InstanceFile) []InstanceFile {
+	if instances == nil {
+		return nil
+	}
+	redacted := make([]InstanceFile, len(instances))
+	for i, instance := range instances {
+		instance.Token = redactToken(instance.Token)
+		redacted[i] = instance
+	}
+	return redacted
+}
+
+func redactToken(token string) string {
+	if token == "" {
+		return ""
+	}
+	return RedactedToken
+}
+
+// resolveToken prefers the environment variable tokenEnv names over the
+// inline token.
+func resolveToken(token string, tokenEnv string) string {
+	if tokenEnv != "" {
+		token = os.Getenv(tokenEnv)
+	}
+	return strings.TrimSpace(token)
+}
+
+func appendHosts(hosts []string, added []string) []string {
+	for _, host := range added {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" && !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts
+}
+
+// ExpandHome replaces a leading ~ with the user's home directory.
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
@@ -0,0 +1,271 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadFileMissingIsEmpty(t *testing.T) {
+	t.Parallel()
+
+	file, err := LoadFile(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("LoadFile() unexpected error: %v", err)
+	}
+	if len(file.Workspaces) != 0 {
+		t.Fatalf("LoadFile() = %+v, want empty", file)
+	}
+}
+
+func TestLoadFileAppliesWorkspaces(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	content := `{"workspaces": {"work": "~/src/work", "oss": "/opt/oss"}}`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() unexpected error: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.Apply(file)
+	if cfg.Workspaces["oss"] != "/opt/oss" {
+		t.Fatalf("oss = %q, want /opt/oss", cfg.Workspaces["oss"])
+	}
+	if home, err := os.UserHomeDir(); err == nil && cfg.Workspaces["work"] != filepath.Join(home, "src", "work") {
+		t.Fatalf("work = %q, want expanded home", cfg.Workspaces["work"])
+	}
+}
+
+func TestLoadFileRejectsInvalidJSON(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"workspaces": [}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(path); err == nil {
+		t.Fatal("LoadFile() expected error for invalid JSON")
+	}
+}
+
+func TestApplyAddsForgeHosts(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.Apply(File{
+		GitHub: &GitHubFile{Hosts: []string{"github.acme.corp"}},
+		GitLab: &GitLabFile{
+			Hosts:     []string{"Git.Example.com", "gitlab.com", " "},
+			Instances: []InstanceFile{{Host: "gitlab.acme.corp", Token: "acme"}},
+		},
+	})
+
+	if want := []string{"github.com", "github.acme.corp"}; !slices.Equal(cfg.GitHub.Hosts, want) {
+		t.Fatalf("GitHub.Hosts = %v, want %v", cfg.GitHub.Hosts, want)
+	}
+	if want := []string{"gitlab.com", "git.example.com", "gitlab.acme.corp"}; !slices.Equal(cfg.GitLab.Hosts, want) {
+		t.Fatalf("GitLab.Hosts = %v, want %v", cfg.GitLab.Hosts, want)
+	}
+	if want := []Instance{{Host: "gitlab.acme.corp", Token: "acme"}}; !slices.Equal(cfg.GitLab.Instances, want) {
+		t.Fatalf("GitLab.Instances = %+v, want %+v", cfg.GitLab.Instances, want)
+	}
+}
+
+func TestApplyResolvesGiteaTokens(t *testing.T) {
+	t.Setenv("FRESH_TEST_FORGEJO_TOKEN", "from-env")
+
+	cfg := DefaultConfig()
+	cfg.Apply(File{Gitea: &GiteaFile{Instances: []InstanceFile{
+		{Host: "Forgejo.Acme.Corp", TokenEnv: "FRESH_TEST_FORGEJO_TOKEN", Token: "ignored"},
+		{Host: "gitea.example.com", Token: "inline"},
+		{Token: "no host"},
+	}}})
+
+	want := []Instance{
+		{Host: "forgejo.acme.corp", Token: "from-env"},
+		{Host: "gitea.example.com", Token: "inline"},
+	}
+	if !slices.Equal(cfg.Gitea.Instances, want) {
+		t.Fatalf("Gitea.Instances = %+v, want %+v", cfg.Gitea.Instances, want)
+	}
+}
+
+func TestApplyAddsGitHubInstances(t *testing.T) {
+	t.Setenv("FRESH_TEST_GHE_TOKEN", "from-env")
+
+	cfg := DefaultConfig()
+	cfg.Apply(File{GitHub: &GitHubFile{
+		Token: "dotcom",
+		Instances: []InstanceFile{
+			{Host: "GHE.Acme.Corp", TokenEnv: "FRESH_TEST_GHE_TOKEN"},
+			{Host: " "},
+		},
+	}})
+
+	if cfg.GitHub.Token != "dotcom" {
+		t.Fatalf("GitHub.Token = %q, want dotcom", cfg.GitHub.Token)
+	}
+	if want := []Instance{{Host: "ghe.acme.corp", Token: "from-env"}}; !slices.Equal(cfg.GitHub.Instances, want) {
+		t.Fatalf("GitHub.Instances = %+v, want %+v", cfg.GitHub.Instances, want)
+	}
+	if want := []string{"github.com", "ghe.acme.corp"}; !slices.Equal(cfg.GitHub.Hosts, want) {
+		t.Fatalf("GitHub.Hosts = %v, want %v", cfg.GitHub.Hosts, want)
+	}
+}
+
+func TestApplySelectsGitHubClient(t *testing.T) {
+	t.Setenv("FRESH_TEST_GITHUB_TOKEN", " from-env ")
+
+	cfg := DefaultConfig()
+	if cfg.GitHub.Client != GitHubClientCLI {
+		t.Fatalf("default GitHub.Client = %q, want %q", cfg.GitHub.Client, GitHubClientCLI)
+	}
+
+	cfg.Apply(File{GitHub: &GitHubFile{Client: "API", TokenEnv: "FRESH_TEST_GITHUB_TOKEN"}})
+	if cfg.GitHub.Client != GitHubClientAPI || cfg.GitHub.Token != "from-env" {
+		t.Fatalf("GitHub = %+v, want the api client with the token from the environment", cfg.GitHub)
+	}
+	if want := []string{"github.com"}; !slices.Equal(cfg.GitHub.Hosts, want) {
+		t.Fatalf("GitHub.Hosts = %v, want %v", cfg.GitHub.Hosts, want)
+	}
+}
+
+func TestApplySetsBrowser(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.Apply(File{Browser: "  "})
+	if cfg.Browser != "" {
+		t.Fatalf("Browser = %q, want the platform default", cfg.Browser)
+	}
+
+	cfg.Apply(File{Browser: " firefox --new-tab "})
+	if cfg.Browser != "firefox --new-tab" {
+		t.Fatalf("Browser = %q, want firefox --new-tab", cfg.Browser)
+	}
+}
+
+func TestApplySetsFetch(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	if cfg.Fetch != (FetchConfig{}) {
+		t.Fatalf("default Fetch = %+v, want a plain git fetch", cfg.Fetch)
+	}
+
+	cfg.Apply(File{Fetch: &FetchFile{AllRemotes: true, Prune: true}})
+	if want := (FetchConfig{AllRemotes: true, Prune: true}); cfg.Fetch != want {
+		t.Fatalf("Fetch = %+v, want %+v", cfg.Fetch, want)
+	}
+}
+
+func TestLoadFileReadsStaleStashAge(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: `"14d"`, want: 14 * 24 * time.Hour},
+		{value: `"36h"`, want: 36 * time.Hour},
+		{value: `"soon"`, wantErr: true},
+		{value: `"-2d"`, wantErr: true},
+		{value: `30`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "config.json")
+			if err := os.WriteFile(path, []byte(`{"stale_stash_age": `+tt.value+`}`), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			file, err := LoadFile(path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			cfg := DefaultConfig()
+			cfg.Apply(file)
+			if cfg.StaleStashAge != tt.want {
+				t.Errorf("StaleStashAge = %v, want %v", cfg.StaleStashAge, tt.want)
+			}
+		})
+	}
+}
+
+func TestDurationRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value Duration
+		want  string
+	}{
+		{value: Duration(14 * 24 * time.Hour), want: `"14d"`},
+		{value: Duration(36 * time.Hour), want: `"36h0m0s"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := json.Marshal(tt.value)
+			if err != nil || string(data) != tt.want {
+				t.Fatalf("Marshal() = %s, %v, want %s", data, err, tt.want)
+			}
+			var got Duration
+			if err := json.Unmarshal(data, &got); err != nil || got != tt.value {
+				t.Fatalf("Unmarshal(%s) = %v, %v, want %v", data, time.Duration(got), err, time.Duration(tt.value))
+			}
+		})
+	}
+}
+
+func TestRedactedHidesTokens(t *testing.T) {
+	t.Parallel()
+
+	file := File{
+		GitHub: &GitHubFile{
+			Token:     "ghp_secret",
+			Instances: []InstanceFile{{Host: "ghe.acme.corp", Token: "ghe-secret"}},
+		},
+		GitLab: &GitLabFile{Instances: []InstanceFile{{Host: "gitlab.acme.corp", Token: "glpat-secret"}}},
+		Gitea: &GiteaFile{Instances: []InstanceFile{
+			{Host: "git.example.com", Token: "gitea-secret"},
+			{Host: "forgejo.example.com", TokenEnv: "FORGEJO_TOKEN"},
+		}},
+	}
+
+	redacted := file.Redacted()
+	data, err := json.Marshal(redacted)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Fatalf("redacted file = %s, want no token", data)
+	}
+	if got := redacted.Gitea.Instances; got[0].Token != RedactedToken || got[1].Token != "" || got[1].TokenEnv != "FORGEJO_TOKEN" {
+		t.Fatalf("redacted instances = %+v", got)
+	}
+	if redacted.GitHub.Token != RedactedToken || redacted.GitHub.Instances[0].Token != RedactedToken {
+		t.Fatalf("redacted GitHub = %+v", redacted.GitHub)
+	}
+	if file.GitHub.Token != "ghp_secret" || file.Gitea.Instances[0].Token != "gitea-secret" {
+		t.Fatal("Redacted() modified the original file")
+	}
+}
@@ -0,0 +1,380 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fresh/internal/config"
+	"fresh/internal/notifications"
+)
+
+// minGitVersion is the first release whose `git status --porcelain=v2`
+// reports the stash count requested with --show-stash.
+var minGitVersion = [3]int{2, 35, 0}
+
+// requiredScopes are the classic token scopes pull request queries need to
+// see private repositories.
+var requiredScopes = []string{"repo"}
+
+func checkGit(env Environment) Result {
+	result := Result{Name: "git"}
+	if _, err := env.LookPath("git"); err != nil {
+		result.Status = StatusFail
+		result.Detail = "git is not installed or not found in PATH"
+		result.Fix = "Install git from https://git-scm.com/downloads"
+		return result
+	}
+
+	output, err := env.Run("git", "--version")
+	if err != nil {
+		result.Status = StatusFail
+		result.Detail = "git --version failed: " + firstLine(output, err)
+		result.Fix = "Reinstall git"
+		return result
+	}
+
+	version, ok := parseGitVersion(output)
+	if !ok {
+		result.Status = StatusWarn
+		result.Detail = "could not read the version from " + strconv.Quote(strings.TrimSpace(output))
+		return result
+	}
+
+	result.Detail = "git " + formatVersion(version)
+	if compareVersions(version, minGitVersion) < 0 {
+		result.Status = StatusFail
+		result.Detail += fmt.Sprintf(" is older than %s, which stash counts need", formatVersion(minGitVersion))
+		result.Fix = "Upgrade git to " + formatVersion(minGitVersion) + " or newer"
+		return result
+	}
+	result.Status = StatusOK
+	return result
+}
+
+func checkGh(env Environment) []Result {
+	gh := Result{Name: "gh"}
+	if _, err := env.LookPath("gh"); err != nil {
+		gh.Status = StatusWarn
+		gh.Detail = "gh is not installed; pull request columns are unavailable"
+		gh.Fix = "Install the GitHub CLI from https://cli.github.com"
+		return []Result{gh}
+	}
+	gh.Status = StatusOK
+	gh.Detail = "gh is installed"
+	if output, err := env.Run("gh", "--version"); err == nil {
+		gh.Detail = firstLine(output, nil)
+	}
+
+	auth := Result{Name: "gh auth"}
+	output, err := env.Run("gh", "auth", "status")
+	hosts := parseAuthStatus(output)
+	if err != nil {
+		auth.Status = StatusFail
+		auth.Detail = authFailure(output, err)
+		auth.Fix = "Run `gh auth login`"
+		return []Result{gh, auth}
+	}
+	auth.Status = StatusOK
+	auth.Detail = "logged in to " + strings.Join(hostNames(hosts), ", ")
+	if len(hosts) == 0 {
+		auth.Detail = "logged in"
+	}
+
+	return []Result{gh, auth, checkScopes(hosts)}
+}
+
+func checkScopes(hosts []authHost) Result {
+	result := Result{Name: "gh scopes", Status: StatusOK}
+
+	var missing, reported []string
+	for _, host := range hosts {
+		if !host.scopesReported {
+			continue
+		}
+		reported = append(reported, host.name)
+		for _, scope := range requiredScopes {
+			if !containsScope(host.scopes, scope) {
+				missing = append(missing, fmt.Sprintf("%s on %s", scope, host.name))
+			}
+		}
+	}
+
+	switch {
+	case len(missing) > 0:
+		result.Status = StatusWarn
+		result.Detail = "token is missing " + strings.Join(missing, ", ") + "; private repositories show no pull requests"
+		result.Fix = "Run `gh auth refresh --scopes " + strings.Join(requiredScopes, ",") + "`"
+	case len(reported) == 0:
+		result.Detail = "scopes not reported (fine-grained or environment token)"
+	default:
+		result.Detail = "token has " + strings.Join(requiredScopes, ", ")
+	}
+	return result
+}
+
+func checkSSHAgent(env Environment) Result {
+	result := Result{Name: "ssh agent"}
+	if env.Getenv("SSH_AUTH_SOCK") == "" {
+		result.Status = StatusWarn
+		result.Detail = "no agent is running; fetching over SSH may prompt for passphrases"
+		result.Fix = "Start one with `eval \"$(ssh-agent)\"` and run `ssh-add`"
+		return result
+	}
+	if _, err := env.LookPath("ssh-add"); err != nil {
+		result.Status = StatusOK
+		result.Detail = "SSH_AUTH_SOCK is set"
+		return result
+	}
+
+	output, err := env.Run("ssh-add", "-l")
+	switch {
+	case err == nil:
+		result.Status = StatusOK
+		result.Detail = fmt.Sprintf("agent holds %s", pluralize(len(nonEmptyLines(output)), "key"))
+	case strings.Contains(output, "no identities"):
+		result.Status = StatusWarn
+		result.Detail = "agent holds no keys; fetching over SSH may prompt for passphrases"
+		result.Fix = "Run `ssh-add` to load your key"
+	default:
+		result.Status = StatusWarn
+		result.Detail = "cannot reach the agent: " + firstLine(output, err)
+		result.Fix = "Check SSH_AUTH_SOCK points at a running agent"
+	}
+	return result
+}
+
+func checkNotifications(env Environment) Result {
+	result := Result{Name: "notifications"}
+	if env.GOOS != "darwin" {
+		result.Status = StatusWarn
+		result.Detail = "desktop notifications are only supported on macOS; the terminal bell rings instead"
+		return result
+	}
+
+	var missing []string
+	for _, backend := range notifications.Backends() {
+		if _, err := env.LookPath(backend); err != nil {
+			missing = append(missing, backend)
+		}
+	}
+	if len(missing) > 0 {
+		result.Status = StatusWarn
+		result.Detail = strings.Join(missing, ", ") + " not found; the terminal bell rings instead"
+		result.Fix = "Make sure /usr/bin is on PATH"
+		return result
+	}
+	result.Status = StatusOK
+	result.Detail = "delivered through " + strings.Join(notifications.Backends(), " and ")
+	return result
+}
+
+func checkConfig(path string) []Result {
+	result := Result{Name: "config"}
+	if path == "" {
+		result.Status = StatusWarn
+		result.Detail = "cannot locate the configuration directory"
+		result.Fix = "Set " + config.EnvConfigPath + " to the configuration file's path"
+		return []Result{result}
+	}
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		result.Status = StatusOK
+		result.Detail = "no file at " + path + ", using defaults"
+		return []Result{result}
+	}
+	file, err := config.LoadFile(path)
+	if err != nil {
+		result.Status = StatusFail
+		result.Detail = err.Error()
+		result.Fix = "Fix or remove " + path
+		return []Result{result}
+	}
+	result.Status = StatusOK
+	result.Detail = path
+
+	results := []Result{result}
+	names := make([]string, 0, len(file.Workspaces))
+	for name := range file.Workspaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		dir := config.ExpandHome(file.Workspaces[name])
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			results = append(results, Result{
+				Name:   "workspace " + name,
+				Status: StatusWarn,
+				Detail: dir + " is not a directory",
+				Fix:    "Update or remove the workspace in " + path,
+			})
+		}
+	}
+	return results
+}
+
+func checkScanDir(dir string) Result {
+	result := Result{Name: "scan root", Detail: dir}
+	info, err := os.Stat(dir)
+	switch {
+	case err != nil:
+		result.Status = StatusFail
+		result.Detail = err.Error()
+		result.Fix = "Pass an existing directory with --dir or --workspace"
+	case !info.IsDir():
+		result.Status = StatusFail
+		result.Detail = dir + " is not a directory"
+		result.Fix = "Pass a directory with --dir or --workspace"
+	default:
+		if _, err := os.ReadDir(dir); err != nil {
+			result.Status = StatusFail
+			result.Detail = err.Error()
+			result.Fix = "Grant read access to " + dir
+			return result
+		}
+		result.Status = StatusOK
+	}
+	return result
+}
+
+type authHost struct {
+	name           string
+	scopes         []string
+	scopesReported bool
+}
+
+// parseAuthStatus reads the hosts and token scopes from `gh auth status`.
+// Hosts are the unindented lines; older gh releases list scopes unquoted.
+func parseAuthStatus(output string) []authHost {
+	var hosts []authHost
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if line == trimmed {
+			if !strings.Contains(trimmed, " ") {
+				hosts = append(hosts, authHost{name: trimmed})
+			}
+			continue
+		}
+		_, scopes, ok := strings.Cut(trimmed, "Token scopes:")
+		if !ok || len(hosts) == 0 {
+			continue
+		}
+		host := &hosts[len(hosts)-1]
+		host.scopesReported = true
+		for _, scope := range strings.Split(scopes, ",") {
+			if scope = strings.Trim(strings.TrimSpace(scope), `'"`); scope != "" && scope != "none" {
+				host.scopes = append(host.scopes, scope)
+			}
+		}
+	}
+	return hosts
+}
+
+func hostNames(hosts []authHost) []string {
+	names := make([]string, len(hosts))
+	for i, host := range hosts {
+		names[i] = host.name
+	}
+	return names
+}
+
+// containsScope reports whether scopes grants scope. admin:org implies
+// write:org and read:org, and write:org implies read:org.
+func containsScope(scopes []string, scope string) bool {
+	for _, granted := range scopes {
+		if granted == scope {
+			return true
+		}
+		if strings.HasSuffix(scope, ":org") && (granted == "admin:org" || (granted == "write:org" && scope == "read:org")) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseGitVersion(output string) ([3]int, bool) {
+	var version [3]int
+	fields := strings.Fields(output)
+	if len(fields) < 3 || fields[0] != "git" || fields[1] != "version" {
+		return version, false
+	}
+
+	parts := strings.Split(fields[2], ".")
+	for i := 0; i < len(version) && i < len(parts); i++ {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return version, i > 0
+		}
+		version[i] = n
+	}
+	return version, true
+}
+
+func compareVersions(a, b [3]int) int {
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] - b[i]
+		}
+	}
+	return 0
+}
+
+func formatVersion(version [3]int) string {
+	return fmt.Sprintf("%d.%d.%d", version[0], version[1], version[2])
+}
+
+// authFailure picks the line of a failed `gh auth status` that explains
+// it, which follows the host it belongs to.
+func authFailure(output string, err error) string {
+	for _, line := range nonEmptyLines(output) {
+		if strings.HasPrefix(line, "X ") || strings.HasPrefix(line, "✗ ") || strings.Contains(line, "not logged") {
+			return stripMarker(line)
+		}
+	}
+	return firstLine(output, err)
+}
+
+// firstLine returns the first non-empty line of a command's output, or the
+// error when the command printed nothing.
+func firstLine(output string, err error) string {
+	if lines := nonEmptyLines(output); len(lines) > 0 {
+		return stripMarker(lines[0])
+	}
+	if err != nil {
+		return err.Error()
+	}
+	return ""
+}
+
+// stripMarker removes the status symbol gh prefixes its lines with.
+func stripMarker(line string) string {
+	for _, marker := range []string{"✓ ", "✗ ", "X ", "! ", "- "} {
+		if strings.HasPrefix(line, marker) {
+			return strings.TrimPrefix(line, marker)
+		}
+	}
+	return line
+}
+
+func nonEmptyLines(output string) []string {
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
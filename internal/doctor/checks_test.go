@@ -0,0 +1,366 @@
+package doctor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeEnvironment answers LookPath from installed and Run from outputs,
+// keyed by the full command line. Commands listed in failing exit non-zero.
+type fakeEnvironment struct {
+	installed map[string]bool
+	outputs   map[string]string
+	failing   map[string]bool
+	env       map[string]string
+	goos      string
+}
+
+func (f fakeEnvironment) environment() Environment {
+	return Environment{
+		LookPath: func(file string) (string, error) {
+			if f.installed[file] {
+				return "/usr/bin/" + file, nil
+			}
+			return "", errors.New("not found")
+		},
+		Run: func(name string, args ...string) (string, error) {
+			line := strings.Join(append([]string{name}, args...), " ")
+			if f.failing[line] {
+				return f.outputs[line], errors.New("exit status 1")
+			}
+			return f.outputs[line], nil
+		},
+		Getenv: func(key string) string { return f.env[key] },
+		GOOS:   f.goos,
+	}
+}
+
+func TestCheckGit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		env        fakeEnvironment
+		wantStatus Status
+		wantDetail string
+	}{
+		{
+			name:       "missing",
+			env:        fakeEnvironment{},
+			wantStatus: StatusFail,
+			wantDetail: "not installed",
+		},
+		{
+			name: "supported",
+			env: fakeEnvironment{
+				installed: map[string]bool{"git": true},
+				outputs:   map[string]string{"git --version": "git version 2.39.3 (Apple Git-146)\n"},
+			},
+			wantStatus: StatusOK,
+			wantDetail: "git 2.39.3",
+		},
+		{
+			name: "windows build",
+			env: fakeEnvironment{
+				installed: map[string]bool{"git": true},
+				outputs:   map[string]string{"git --version": "git version 2.45.1.windows.1\n"},
+			},
+			wantStatus: StatusOK,
+			wantDetail: "git 2.45.1",
+		},
+		{
+			name: "too old for stash counts",
+			env: fakeEnvironment{
+				installed: map[string]bool{"git": true},
+				outputs:   map[string]string{"git --version": "git version 2.34.1\n"},
+			},
+			wantStatus: StatusFail,
+			wantDetail: "older than 2.35.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := checkGit(tt.env.environment())
+			if got.Status != tt.wantStatus || !strings.Contains(got.Detail, tt.wantDetail) {
+				t.Fatalf("checkGit() = %+v, want %s containing %q", got, tt.wantStatus, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestCheckGh(t *testing.T) {
+	t.Parallel()
+
+	const loggedIn = `github.com
+  ✓ Logged in to github.com account octocat (keyring)
+  - Active account: true
+  - Git operations protocol: ssh
+  - Token: gho_************************************
+  - Token scopes: 'gist', 'read:org', 'repo', 'workflow'
+`
+	const missingRepo = `github.com
+  ✓ Logged in to github.com as octocat (oauth_token)
+  ✓ Token scopes: gist, read:org
+`
+	const fineGrained = `github.com
+  ✓ Logged in to github.com account octocat (GH_TOKEN)
+  - Token: github_pat_***
+`
+	const expired = `github.com
+  X Failed to log in to github.com account octocat (default)
+  - The token in default is invalid.
+`
+
+	tests := []struct {
+		name   string
+		auth   string
+		failed bool
+		want   map[string]Status
+		detail string
+	}{
+		{
+			name:   "logged in with scopes",
+			auth:   loggedIn,
+			want:   map[string]Status{"gh": StatusOK, "gh auth": StatusOK, "gh scopes": StatusOK},
+			detail: "token has repo",
+		},
+		{
+			name:   "missing repo scope",
+			auth:   missingRepo,
+			want:   map[string]Status{"gh": StatusOK, "gh auth": StatusOK, "gh scopes": StatusWarn},
+			detail: "missing repo on github.com",
+		},
+		{
+			name:   "scopes not reported",
+			auth:   fineGrained,
+			want:   map[string]Status{"gh": StatusOK, "gh auth": StatusOK, "gh scopes": StatusOK},
+			detail: "scopes not reported",
+		},
+		{
+			name:   "not logged in",
+			auth:   "You are not logged into any GitHub hosts. To log in, run: gh auth login\n",
+			failed: true,
+			want:   map[string]Status{"gh": StatusOK, "gh auth": StatusFail},
+			detail: "not logged into any GitHub hosts",
+		},
+		{
+			name:   "invalid token",
+			auth:   expired,
+			failed: true,
+			want:   map[string]Status{"gh": StatusOK, "gh auth": StatusFail},
+			detail: "Failed to log in to github.com",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			env := fakeEnvironment{
+				installed: map[string]bool{"gh": true},
+				outputs: map[string]string{
+					"gh --version":   "gh version 2.62.0 (2024-11-14)\n",
+					"gh auth status": tt.auth,
+				},
+				failing: map[string]bool{"gh auth status": tt.failed},
+			}
+
+			results := checkGh(env.environment())
+			if len(results) != len(tt.want) {
+				t.Fatalf("checkGh() = %+v, want %d results", results, len(tt.want))
+			}
+			for _, result := range results {
+				if result.Status != tt.want[result.Name] {
+					t.Errorf("checkGh() %s = %s, want %s", result.Name, result.Status, tt.want[result.Name])
+				}
+			}
+			if last := results[len(results)-1]; !strings.Contains(last.Detail, tt.detail) {
+				t.Errorf("checkGh() %s detail = %q, want it to contain %q", last.Name, last.Detail, tt.detail)
+			}
+		})
+	}
+}
+
+func TestCheckGhMissing(t *testing.T) {
+	t.Parallel()
+
+	results := checkGh(fakeEnvironment{}.environment())
+	if len(results) != 1 || results[0].Status != StatusWarn || results[0].Fix == "" {
+		t.Fatalf("checkGh() = %+v, want a single warning with a fix", results)
+	}
+}
+
+func TestCheckSSHAgent(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		env        fakeEnvironment
+		wantStatus Status
+		wantDetail string
+	}{
+		{
+			name:       "no agent",
+			env:        fakeEnvironment{},
+			wantStatus: StatusWarn,
+			wantDetail: "no agent",
+		},
+		{
+			name: "keys loaded",
+			env: fakeEnvironment{
+				installed: map[string]bool{"ssh-add": true},
+				env:       map[string]string{"SSH_AUTH_SOCK": "/tmp/agent.sock"},
+				outputs:   map[string]string{"ssh-add -l": "256 SHA256:abc me@host (ED25519)\n"},
+			},
+			wantStatus: StatusOK,
+			wantDetail: "1 key",
+		},
+		{
+			name: "no keys",
+			env: fakeEnvironment{
+				installed: map[string]bool{"ssh-add": true},
+				env:       map[string]string{"SSH_AUTH_SOCK": "/tmp/agent.sock"},
+				outputs:   map[string]string{"ssh-add -l": "The agent has no identities.\n"},
+				failing:   map[string]bool{"ssh-add -l": true},
+			},
+			wantStatus: StatusWarn,
+			wantDetail: "no keys",
+		},
+		{
+			name: "unreachable",
+			env: fakeEnvironment{
+				installed: map[string]bool{"ssh-add": true},
+				env:       map[string]string{"SSH_AUTH_SOCK": "/tmp/agent.sock"},
+				outputs:   map[string]string{"ssh-add -l": "Error connecting to agent: No such file or directory\n"},
+				failing:   map[string]bool{"ssh-add -l": true},
+			},
+			wantStatus: StatusWarn,
+			wantDetail: "cannot reach",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := checkSSHAgent(tt.env.environment())
+			if got.Status != tt.wantStatus || !strings.Contains(got.Detail, tt.wantDetail) {
+				t.Fatalf("checkSSHAgent() = %+v, want %s containing %q", got, tt.wantStatus, tt.wantDetail)
+			}
+		})
+	}
+}
+
+func TestCheckNotifications(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		env        fakeEnvironment
+		wantStatus Status
+	}{
+		{name: "macOS", env: fakeEnvironment{goos: "darwin", installed: map[string]bool{"osascript": true, "afplay": true}}, wantStatus: StatusOK},
+		{name: "macOS without afplay", env: fakeEnvironment{goos: "darwin", installed: map[string]bool{"osascript": true}}, wantStatus: StatusWarn},
+		{name: "linux", env: fakeEnvironment{goos: "linux"}, wantStatus: StatusWarn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := checkNotifications(tt.env.environment()); got.Status != tt.wantStatus {
+				t.Fatalf("checkNotifications() = %+v, want %s", got, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want []Status
+	}{
+		{name: "missing file", path: filepath.Join(dir, "missing.json"), want: []Status{StatusOK}},
+		{name: "invalid json", path: write("invalid.json", "{"), want: []Status{StatusFail}},
+		{
+			name: "missing workspace",
+			path: write("config.json", `{"workspaces": {"ok": "`+dir+`", "gone": "`+filepath.Join(dir, "gone")+`"}}`),
+			want: []Status{StatusOK, StatusWarn},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			results := checkConfig(tt.path)
+			if len(results) != len(tt.want) {
+				t.Fatalf("checkConfig() = %+v, want %v", results, tt.want)
+			}
+			for i, result := range results {
+				if result.Status != tt.want[i] {
+					t.Errorf("checkConfig()[%d] = %+v, want %s", i, result, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckScanDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	if err := os.WriteFile(file, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		dir  string
+		want Status
+	}{
+		{name: "directory", dir: dir, want: StatusOK},
+		{name: "missing", dir: filepath.Join(dir, "missing"), want: StatusFail},
+		{name: "file", dir: file, want: StatusFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := checkScanDir(tt.dir); got.Status != tt.want {
+				t.Fatalf("checkScanDir() = %+v, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	t.Parallel()
+
+	if got := ExitCode([]Result{{Status: StatusOK}, {Status: StatusWarn}}); got != 0 {
+		t.Errorf("ExitCode() with warnings = %d, want 0", got)
+	}
+	if got := ExitCode([]Result{{Status: StatusOK}, {Status: StatusFail}}); got != 1 {
+		t.Errorf("ExitCode() with a failure = %d, want 1", got)
+	}
+}
@@ -0,0 +1,84 @@
+package doctor
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusOK   Status = "ok"
+	StatusWarn Status = "warn"
+	StatusFail Status = "fail"
+)
+
+// Result is the outcome of one check. Fix tells the user what to do about a
+// warning or failure.
+type Result struct {
+	Name   string `json:"name"`
+	Status Status `json:"status"`
+	Detail string `json:"detail"`
+	Fix    string `json:"fix,omitempty"`
+}
+
+// Environment is what the checks inspect, so tests can stand in for the
+// machine fresh runs on.
+type Environment struct {
+	LookPath func(file string) (string, error)
+	// Run returns the combined output of a command. The error is non-nil
+	// when the command could not start or exited with a non-zero status.
+	Run    func(name string, args ...string) (string, error)
+	Getenv func(key string) string
+	GOOS   string
+}
+
+const commandTimeout = 10 * time.Second
+
+// SystemEnvironment inspects the current machine.
+func SystemEnvironment() Environment {
+	return Environment{
+		LookPath: exec.LookPath,
+		Run: func(name string, args ...string) (string, error) {
+			ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+			defer cancel()
+			output, err := exec.CommandContext(ctx, name, args...).CombinedOutput()
+			return string(output), err
+		},
+		Getenv: os.Getenv,
+		GOOS:   runtime.GOOS,
+	}
+}
+
+type Options struct {
+	ConfigPath string
+	ScanDir    string
+}
+
+// Run performs every check in the order they are reported. Checks that
+// depend on an earlier one, such as gh's authentication on gh being
+// installed, are left out when it failed.
+func Run(env Environment, opts Options) []Result {
+	var results []Result
+	results = append(results, checkGit(env))
+	results = append(results, checkGh(env)...)
+	results = append(results, checkSSHAgent(env))
+	results = append(results, checkNotifications(env))
+	results = append(results, checkConfig(opts.ConfigPath)...)
+	results = append(results, checkScanDir(opts.ScanDir))
+	return results
+}
+
+// ExitCode is 1 when any check failed. Warnings leave fresh usable and do
+// not change the exit code.
+func ExitCode(results []Result) int {
+	for _, result := range results {
+		if result.Status == StatusFail {
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,58 @@
+package doctor
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"fresh/internal/report"
+)
+
+var statusSymbols = map[Status]string{
+	StatusOK:   "✓",
+	StatusWarn: "!",
+	StatusFail: "✗",
+}
+
+// Write prints results in format. The table puts each fix on its own line
+// under the check it belongs to.
+func Write(w io.Writer, format report.Format, results []Result) error {
+	switch format {
+	case report.FormatJSON:
+		if results == nil {
+			results = []Result{}
+		}
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	case report.FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		for _, result := range results {
+			if err := encoder.Encode(result); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return writeTable(w, results)
+	}
+}
+
+func writeTable(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	counts := make(map[Status]int)
+	for _, result := range results {
+		counts[result.Status]++
+		fmt.Fprintf(tw, "%s %s\t%s\n", statusSymbols[result.Status], result.Name, result.Detail)
+		if result.Fix != "" {
+			fmt.Fprintf(tw, "\t→ %s\n", result.Fix)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(w, "\n%d ok, %d warnings, %d failed\n", counts[StatusOK], counts[StatusWarn], counts[StatusFail])
+	return err
+}
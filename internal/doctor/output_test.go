@@ -0,0 +1,61 @@
+package doctor
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"fresh/internal/report"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	results := []Result{
+		{Name: "git", Status: StatusOK, Detail: "git 2.45.0"},
+		{Name: "gh auth", Status: StatusFail, Detail: "not logged in", Fix: "Run `gh auth login`"},
+	}
+
+	t.Run("table", func(t *testing.T) {
+		t.Parallel()
+
+		var out bytes.Buffer
+		if err := Write(&out, report.FormatTable, results); err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{"✓ git", "✗ gh auth", "→ Run `gh auth login`", "1 ok, 0 warnings, 1 failed"} {
+			if !strings.Contains(out.String(), want) {
+				t.Errorf("Write() missing %q in:\n%s", want, out.String())
+			}
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var out bytes.Buffer
+		if err := Write(&out, report.FormatJSON, results); err != nil {
+			t.Fatal(err)
+		}
+		var decoded []Result
+		if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+			t.Fatalf("Write() produced invalid JSON: %v", err)
+		}
+		if len(decoded) != 2 || decoded[1].Fix != results[1].Fix {
+			t.Errorf("Write() = %+v", decoded)
+		}
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		t.Parallel()
+
+		var out bytes.Buffer
+		if err := Write(&out, report.FormatNDJSON, results); err != nil {
+			t.Fatal(err)
+		}
+		if lines := strings.Count(out.String(), "\n"); lines != 2 {
+			t.Errorf("Write() wrote %d lines, want 2", lines)
+		}
+	})
+}
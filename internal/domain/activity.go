@@ -84,3 +84,102 @@ func (p *PruningActivity) MarkComplete(outcome PruneOutcome) {
 }
 
 func (p *PruningActivity) IsInProgress() bool { return p.CommandCompletion.IsInProgress() }
+
+type SwitchingActivity struct {
+	LineBuffer
+	Spinner spinner.Model
+	CommandCompletion
+	Branch     string
+	AlreadyOn  bool
+	Skipped    bool
+	SkipReason string
+}
+
+func (*SwitchingActivity) isActivity() {}
+
+func (s *SwitchingActivity) MarkComplete(outcome CheckoutOutcome) {
+	s.CommandCompletion.MarkComplete(outcome.CommandOutcome)
+	if outcome.Branch != "" {
+		s.Branch = outcome.Branch
+	}
+	s.AlreadyOn = outcome.AlreadyOn
+	s.Skipped = outcome.Skipped
+	s.SkipReason = outcome.SkipReason
+}
+
+func (s *SwitchingActivity) IsInProgress() bool { return s.CommandCompletion.IsInProgress() }
+
+type CreatingBranchActivity struct {
+	LineBuffer
+	Spinner spinner.Model
+	CommandCompletion
+	Branch     string
+	StartPoint string
+	Pushed     bool
+	Skipped    bool
+	SkipReason string
+}
+
+func (*CreatingBranchActivity) isActivity() {}
+
+func (c *CreatingBranchActivity) MarkComplete(outcome BranchCreateOutcome) {
+	c.CommandCompletion.MarkComplete(outcome.CommandOutcome)
+	c.StartPoint = outcome.StartPoint
+	c.Pushed = outcome.Pushed
+	c.Skipped = outcome.Skipped
+	c.SkipReason = outcome.SkipReason
+}
+
+func (c *CreatingBranchActivity) IsInProgress() bool { return c.CommandCompletion.IsInProgress() }
+
+type RebasingActivity struct {
+	LineBuffer
+	Spinner spinner.Model
+	CommandCompletion
+	Branch          string
+	Onto            string
+	UpToDate        bool
+	ConflictedFiles []string
+	Skipped         bool
+	SkipReason      string
+}
+
+func (*RebasingActivity) isActivity() {}
+
+func (r *RebasingActivity) MarkComplete(outcome RebaseOutcome) {
+	r.CommandCompletion.MarkComplete(outcome.CommandOutcome)
+	r.Branch = outcome.Branch
+	r.Onto = outcome.Onto
+	r.UpToDate = outcome.UpToDate
+	r.ConflictedFiles = outcome.ConflictedFiles
+	r.Skipped = outcome.Skipped
+	r.SkipReason = outcome.SkipReason
+}
+
+func (r *RebasingActivity) IsInProgress() bool { return r.CommandCompletion.IsInProgress() }
+
+type SyncingForkActivity struct {
+	LineBuffer
+	Spinner spinner.Model
+	CommandCompletion
+	Branch     string
+	Behind     int
+	UpToDate   bool
+	Pushed     bool
+	Skipped    bool
+	SkipReason string
+}
+
+func (*SyncingForkActivity) isActivity() {}
+
+func (s *SyncingForkActivity) MarkComplete(outcome ForkSyncOutcome) {
+	s.CommandCompletion.MarkComplete(outcome.CommandOutcome)
+	s.Branch = outcome.Branch
+	s.Behind = outcome.Behind
+	s.UpToDate = outcome.UpToDate
+	s.Pushed = outcome.Pushed
+	s.Skipped = outcome.Skipped
+	s.SkipReason = outcome.SkipReason
+}
+
+func (s *SyncingForkActivity) IsInProgress() bool { return s.CommandCompletion.IsInProgress() }
@@ -0,0 +1,24 @@
+package domain
+
+// ForkState describes how a fork's default branch on origin compares with
+// the same branch on the canonical repository, configured as the upstream
+// remote.
+type ForkState interface {
+	isForkState()
+}
+
+type NotFork struct{}
+
+type ForkStatus struct {
+	Branch string
+	Ahead  int
+	Behind int
+}
+
+type ForkError struct {
+	Message string
+}
+
+func (NotFork) isForkState()    {}
+func (ForkStatus) isForkState() {}
+func (ForkError) isForkState()  {}
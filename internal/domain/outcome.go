@@ -14,3 +14,50 @@ type PruneOutcome struct {
 	DeletedCount int
 	FailedCount  int
 }
+
+// CheckoutOutcome describes a branch switch. Skipped is set when the switch
+// was not attempted, for example because the working tree had changes or
+// the branch does not exist in the repository.
+type CheckoutOutcome struct {
+	CommandOutcome
+	Branch     string
+	AlreadyOn  bool
+	Skipped    bool
+	SkipReason string
+}
+
+// BranchCreateOutcome describes creating a branch from the default branch.
+// StartPoint is the ref the branch was created from, such as origin/main.
+type BranchCreateOutcome struct {
+	CommandOutcome
+	Branch     string
+	StartPoint string
+	Pushed     bool
+	Skipped    bool
+	SkipReason string
+}
+
+// RebaseOutcome describes rebasing the current branch onto the default
+// branch. When the rebase stops on conflicts it is aborted, and the files
+// that conflicted are listed in ConflictedFiles.
+type RebaseOutcome struct {
+	CommandOutcome
+	Branch          string
+	Onto            string
+	UpToDate        bool
+	ConflictedFiles []string
+	Skipped         bool
+	SkipReason      string
+}
+
+// ForkSyncOutcome describes bringing a fork's default branch up to date with
+// the upstream remote. Behind counts the upstream commits origin was missing.
+type ForkSyncOutcome struct {
+	CommandOutcome
+	Branch     string
+	Behind     int
+	UpToDate   bool
+	Pushed     bool
+	Skipped    bool
+	SkipReason string
+}
@@ -0,0 +1,80 @@
+package domain
+
+import "time"
+
+// PullRequest is everything shown about one pull request when it is opened
+// from the pull requests view.
+type PullRequest struct {
+	Number     int
+	Title      string
+	URL        string
+	Author     string
+	HeadBranch string
+	BaseBranch string
+	Draft      bool
+	Mergeable  MergeableState
+	Additions  int
+	Deletions  int
+	Labels     []string
+	Checks     []CheckRun
+	Reviewers  []Reviewer
+}
+
+type MergeableState string
+
+const (
+	MergeableUnknown     MergeableState = ""
+	MergeableClean       MergeableState = "mergeable"
+	MergeableConflicting MergeableState = "conflicting"
+)
+
+type CheckStatus string
+
+const (
+	CheckPassed  CheckStatus = "passed"
+	CheckRunning CheckStatus = "running"
+	CheckFailed  CheckStatus = "failed"
+	CheckSkipped CheckStatus = "skipped"
+	CheckWaiting CheckStatus = "waiting"
+)
+
+// CheckRun is one check or commit status reported for the head commit.
+type CheckRun struct {
+	Name        string
+	Status      CheckStatus
+	StartedAt   time.Time
+	CompletedAt time.Time
+	URL         string
+}
+
+// Duration returns how long the check ran, or has been running by now, or
+// 0 when it has not started.
+func (c CheckRun) Duration(now time.Time) time.Duration {
+	if c.StartedAt.IsZero() {
+		return 0
+	}
+	end := c.CompletedAt
+	if end.IsZero() {
+		end = now
+	}
+	if end.Before(c.StartedAt) {
+		return 0
+	}
+	return end.Sub(c.StartedAt)
+}
+
+type ReviewState string
+
+const (
+	ReviewApproved         ReviewState = "approved"
+	ReviewChangesRequested ReviewState = "changes_requested"
+	ReviewCommented        ReviewState = "commented"
+	ReviewDismissed        ReviewState = "dismissed"
+	// ReviewPending is a requested review that has not been given yet.
+	ReviewPending ReviewState = "pending"
+)
+
+type Reviewer struct {
+	Login string
+	State ReviewState
+}
@@ -3,11 +3,13 @@ package domain
 import "time"
 
 type PullRequestDetails struct {
-	Number    int
-	Title     string
-	IsMine    bool
-	UpdatedAt time.Time
-	Checks    PullRequestChecks
+	Number int
+	Title  string
+	IsMine bool
+	// ReviewRequested is set when the user is asked to review it.
+	ReviewRequested bool
+	UpdatedAt       time.Time
+	Checks          PullRequestChecks
 }
 
 type PullRequestChecks struct {
@@ -11,6 +11,12 @@ type PullRequestCount struct {
 	MyBlocked int
 	MyReview  int
 	MyChecks  int
+	// ReviewRequested counts other people's pull requests waiting on the
+	// user's review.
+	ReviewRequested int
+	// Partial is set when a search limit was reached, so the counts may be
+	// too low.
+	Partial bool
 }
 
 type PullRequestUnavailable struct{}
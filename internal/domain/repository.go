@@ -1,13 +1,18 @@
 package domain
 
+import "time"
+
 type Repository struct {
 	Name         string
 	Path         string
 	RemoteURL    string
+	Remotes      []Remote
 	Branches     Branches
 	StashCount   int
+	LastCommitAt time.Time
 	LocalState   LocalState
 	RemoteState  RemoteState
+	Fork         ForkState
 	PullRequests PullRequestState
 	Activity     Activity
 }
@@ -19,3 +24,10 @@ func (r Repository) IsBusy() bool {
 func (r Repository) CanPull() bool {
 	return r.RemoteState.CanPull()
 }
+
+// PullRequestCount returns the synced pull request counts, or zero counts
+// while they are unknown or failed to load.
+func (r Repository) PullRequestCount() PullRequestCount {
+	count, _ := r.PullRequests.(PullRequestCount)
+	return count
+}
@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+type RepositoryDetails struct {
+	Remotes       []Remote
+	Upstream      string
+	LocalBranches []BranchStatus
+	Stashes       []StashEntry
+	RecentCommits []Commit
+	ChangedFiles  []ChangedFile
+}
+
+type Remote struct {
+	Name string
+	URL  string
+	// Host is the lowercased host parsed from URL, with SSH aliases
+	// resolved through ~/.ssh/config. It is empty for local paths
+	// and URLs that cannot be parsed.
+	Host string
+}
+
+type BranchStatus struct {
+	Name         string
+	Upstream     string
+	Ahead        int
+	Behind       int
+	UpstreamGone bool
+	IsCurrent    bool
+}
+
+type StashEntry struct {
+	Ref       string
+	Branch    string
+	Message   string
+	CreatedAt time.Time
+}
+
+type Commit struct {
+	Hash      string
+	ShortHash string
+	Author    string
+	Date      time.Time
+	Subject   string
+}
+
+type ChangeKind string
+
+const (
+	ChangeStaged     ChangeKind = "staged"
+	ChangeUnstaged   ChangeKind = "unstaged"
+	ChangeUntracked  ChangeKind = "untracked"
+	ChangeConflicted ChangeKind = "conflicted"
+)
+
+type ChangedFile struct {
+	Path      string
+	OrigPath  string
+	IndexCode byte
+	WorkCode  byte
+	Kind      ChangeKind
+}
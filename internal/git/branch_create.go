@@ -0,0 +1,96 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"fresh/internal/domain"
+)
+
+// CreateBranchFromDefault fetches the default branch from origin and creates
+// branch from it, so every repository starts the work from the latest
+// upstream state. With push set, the new branch is published to origin with
+// upstream tracking. Repositories with uncommitted changes, or that already
+// have the branch, are skipped.
+func CreateBranchFromDefault(repoPath string, branch string, push bool, lineCallback func(string)) domain.BranchCreateOutcome {
+	emit := func(line string) {
+		if lineCallback != nil {
+			lineCallback(line)
+		}
+	}
+	skip := func(reason string) domain.BranchCreateOutcome {
+		emit("Skipped: " + reason)
+		return domain.BranchCreateOutcome{Branch: branch, Skipped: true, SkipReason: reason}
+	}
+	fail := func(outcome domain.CommandOutcome) domain.BranchCreateOutcome {
+		return domain.BranchCreateOutcome{CommandOutcome: outcome, Branch: branch}
+	}
+
+	if !IsValidBranchName(repoPath, branch) {
+		return skip(fmt.Sprintf("%q is not a valid branch name", branch))
+	}
+	if refExists(repoPath, "refs/heads/"+branch) {
+		return skip(fmt.Sprintf("branch %s already exists", branch))
+	}
+
+	dirty, err := HasTrackedChanges(repoPath)
+	if err != nil {
+		return fail(domain.CommandOutcome{ExitCode: commandExitCode(err), FailureReason: err.Error()})
+	}
+	if dirty {
+		return skip("uncommitted changes")
+	}
+
+	defaultBranch := DefaultBranch(repoPath)
+	if defaultBranch == "" {
+		return skip("no default branch found")
+	}
+
+	startPoint := defaultBranch
+	if hasRemote(repoPath, "origin") {
+		emit(fmt.Sprintf("Fetching origin/%s", defaultBranch))
+		if outcome := runStreamedCommand(repoPath, defaultConfig.Timeout.Fetch, lineCallback, "fetch", "--progress", "origin", defaultBranch); !outcome.IsSuccess() {
+			return fail(outcome)
+		}
+		if refExists(repoPath, "refs/remotes/origin/"+defaultBranch) {
+			startPoint = "origin/" + defaultBranch
+		}
+	}
+
+	emit(fmt.Sprintf("Creating %s from %s", branch, startPoint))
+	if outcome := runStreamedCommand(repoPath, defaultConfig.Timeout.Default, lineCallback, "switch", "--no-track", "-c", branch, startPoint); !outcome.IsSuccess() {
+		return fail(outcome)
+	}
+
+	created := domain.BranchCreateOutcome{Branch: branch, StartPoint: startPoint}
+	if !push {
+		return created
+	}
+
+	emit(fmt.Sprintf("Pushing %s to origin", branch))
+	if outcome := runStreamedCommand(repoPath, defaultConfig.Timeout.Pull, lineCallback, "push", "--progress", "--set-upstream", "origin", branch); !outcome.IsSuccess() {
+		created.CommandOutcome = outcome
+		return created
+	}
+	created.Pushed = true
+	return created
+}
+
+// IsValidBranchName reports whether git accepts name as a new branch name.
+func IsValidBranchName(repoPath string, name string) bool {
+	if strings.TrimSpace(name) == "" || strings.HasPrefix(name, "-") {
+		return false
+	}
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "check-ref-format", "--branch", name)
+	cmd.Dir = repoPath
+	return cmd.Run() == nil
+}
+
+func hasRemote(repoPath string, name string) bool {
+	for _, remote := range remoteNames(repoPath) {
+		if remote == name {
+			return true
+		}
+	}
+	return false
+}
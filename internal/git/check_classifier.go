@@ -1,6 +1,9 @@
 package git
 
-import "strings"
+import (
+	"fresh/internal/domain"
+	"strings"
+)
 
 type ghCheckConclusion string
 
@@ -87,6 +90,21 @@ func classifyCheckSummary(conclusionRaw, statusRaw, stateRaw string) checkSummar
 	return checkSummaryWaiting
 }
 
+func checkStatusOf(class checkSummaryClass) domain.CheckStatus {
+	switch class {
+	case checkSummaryPassed:
+		return domain.CheckPassed
+	case checkSummaryRunning:
+		return domain.CheckRunning
+	case checkSummaryFailed:
+		return domain.CheckFailed
+	case checkSummarySkipped:
+		return domain.CheckSkipped
+	default:
+		return domain.CheckWaiting
+	}
+}
+
 func classifyCheckGate(conclusionRaw, statusRaw, stateRaw string) pullRequestCheckGate {
 	conclusion := normalizeCheckConclusion(conclusionRaw)
 	switch conclusion {
@@ -0,0 +1,183 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"fresh/internal/domain"
+	"fresh/internal/textutil"
+)
+
+// DefaultBranch returns the branch the origin remote's HEAD points at, falling
+// back to main or master when origin/HEAD has not been recorded. It returns an
+// empty string when none of these exist.
+func DefaultBranch(repoPath string) string {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD")
+	cmd.Dir = repoPath
+	if output, err := cmd.Output(); err == nil {
+		if branch := strings.TrimPrefix(strings.TrimSpace(string(output)), "origin/"); branch != "" {
+			return branch
+		}
+	}
+
+	for _, candidate := range []string{"main", "master"} {
+		if refExists(repoPath, "refs/heads/"+candidate) || refExists(repoPath, "refs/remotes/origin/"+candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// ListRemoteBranches returns remote-tracking branches such as origin/feature,
+// leaving out the symbolic origin/HEAD.
+func ListRemoteBranches(repoPath string) []string {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "for-each-ref", "--format=%(refname:short)%00%(symref)", "refs/remotes")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	return parseRemoteBranches(string(output))
+}
+
+func parseRemoteBranches(output string) []string {
+	var branches []string
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), fieldSeparator, 2)
+		if fields[0] == "" || (len(fields) == 2 && fields[1] != "") {
+			continue
+		}
+		if !strings.Contains(fields[0], "/") {
+			continue
+		}
+		branches = append(branches, fields[0])
+	}
+	return branches
+}
+
+// HasTrackedChanges reports whether tracked files have staged or unstaged
+// changes. Untracked files are ignored; git refuses a switch on its own if one
+// would be overwritten.
+func HasTrackedChanges(repoPath string) (bool, error) {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "status", "--porcelain", "--untracked-files=no")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(string(output)) != "", nil
+}
+
+// CheckoutBranch switches to branch if it exists locally, or creates a
+// tracking branch when only a remote has it. Repositories with uncommitted
+// changes are skipped rather than risking a conflicted switch.
+func CheckoutBranch(repoPath string, branch string, lineCallback func(string)) domain.CheckoutOutcome {
+	emit := func(line string) {
+		if lineCallback != nil {
+			lineCallback(line)
+		}
+	}
+	skip := func(reason string) domain.CheckoutOutcome {
+		emit("Skipped: " + reason)
+		return domain.CheckoutOutcome{Branch: branch, Skipped: true, SkipReason: reason}
+	}
+
+	if branch == "" {
+		return skip("no branch given")
+	}
+
+	if current, ok := GetCurrentBranch(repoPath).(domain.OnBranch); ok && current.Name == branch {
+		emit("Already on " + branch)
+		return domain.CheckoutOutcome{Branch: branch, AlreadyOn: true}
+	}
+
+	dirty, err := HasTrackedChanges(repoPath)
+	if err != nil {
+		return domain.CheckoutOutcome{
+			CommandOutcome: domain.CommandOutcome{ExitCode: commandExitCode(err), FailureReason: err.Error()},
+			Branch:         branch,
+		}
+	}
+	if dirty {
+		return skip("uncommitted changes")
+	}
+
+	args := []string{"switch", branch}
+	if !refExists(repoPath, "refs/heads/"+branch) {
+		remoteBranch := findRemoteBranch(repoPath, branch)
+		if remoteBranch == "" {
+			return skip(fmt.Sprintf("no branch %s", branch))
+		}
+		args = []string{"switch", "--track", remoteBranch}
+	}
+
+	emit("git " + strings.Join(args, " "))
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", args...)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	for _, line := range strings.Split(string(output), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			emit(line)
+		}
+	}
+	if err != nil {
+		return domain.CheckoutOutcome{
+			CommandOutcome: domain.CommandOutcome{
+				ExitCode:      commandExitCode(err),
+				FailureReason: textutil.FirstNonEmptyTrimmed(firstErrorLine(string(output)), string(output), err.Error()),
+			},
+			Branch: branch,
+		}
+	}
+	return domain.CheckoutOutcome{Branch: branch}
+}
+
+func SwitchToDefaultBranch(repoPath string, lineCallback func(string)) domain.CheckoutOutcome {
+	branch := DefaultBranch(repoPath)
+	if branch == "" {
+		if lineCallback != nil {
+			lineCallback("Skipped: no default branch found")
+		}
+		return domain.CheckoutOutcome{Skipped: true, SkipReason: "no default branch found"}
+	}
+	return CheckoutBranch(repoPath, branch, lineCallback)
+}
+
+// findRemoteBranch returns the remote-tracking ref for branch, preferring
+// origin when several remotes have it.
+func findRemoteBranch(repoPath string, branch string) string {
+	return matchRemoteBranch(ListRemoteBranches(repoPath), remoteNames(repoPath), branch)
+}
+
+func matchRemoteBranch(remoteBranches []string, remotes []string, branch string) string {
+	available := make(map[string]bool, len(remoteBranches))
+	for _, ref := range remoteBranches {
+		available[ref] = true
+	}
+
+	ordered := append([]string{"origin"}, remotes...)
+	for _, remote := range ordered {
+		if ref := remote + "/" + branch; available[ref] {
+			return ref
+		}
+	}
+	return ""
+}
+
+func remoteNames(repoPath string) []string {
+	remotes := ListRemotes(repoPath)
+	names := make([]string, len(remotes))
+	for i, remote := range remotes {
+		names[i] = remote.Name
+	}
+	return names
+}
+
+func refExists(repoPath string, ref string) bool {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "rev-parse", "--verify", "--quiet", ref)
+	cmd.Dir = repoPath
+	return cmd.Run() == nil
+}
@@ -0,0 +1,49 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRemoteBranchesSkipsSymbolicRefs(t *testing.T) {
+	t.Parallel()
+
+	output := "origin\x00refs/remotes/origin/main\n" +
+		"origin/HEAD\x00refs/remotes/origin/main\n" +
+		"origin/main\x00\n" +
+		"origin/feature/x\x00\n" +
+		"upstream/main\x00\n"
+
+	got := parseRemoteBranches(output)
+	want := []string{"origin/main", "origin/feature/x", "upstream/main"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseRemoteBranches() = %v, want %v", got, want)
+	}
+}
+
+func TestMatchRemoteBranch(t *testing.T) {
+	t.Parallel()
+
+	refs := []string{"upstream/release", "fork/release", "origin/release", "upstream/only-upstream", "origin/team/feature"}
+	remotes := []string{"fork", "origin", "upstream"}
+
+	tests := []struct {
+		branch string
+		want   string
+	}{
+		{branch: "release", want: "origin/release"},
+		{branch: "only-upstream", want: "upstream/only-upstream"},
+		{branch: "team/feature", want: "origin/team/feature"},
+		{branch: "feature", want: ""},
+		{branch: "missing", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.branch, func(t *testing.T) {
+			t.Parallel()
+			if got := matchRemoteBranch(refs, remotes, tt.branch); got != tt.want {
+				t.Fatalf("matchRemoteBranch(%q) = %q, want %q", tt.branch, got, tt.want)
+			}
+		})
+	}
+}
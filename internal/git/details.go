@@ -0,0 +1,271 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"fresh/internal/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	recentCommitLimit = 15
+	fieldSeparator    = "\x00"
+)
+
+func GetRepositoryDetails(repoPath string) domain.RepositoryDetails {
+	var details domain.RepositoryDetails
+
+	Parallel(
+		func() { details.Remotes = ListRemotes(repoPath) },
+		func() { details.Upstream = GetUpstream(repoPath) },
+		func() { details.LocalBranches = ListBranchStatuses(repoPath) },
+		func() { details.Stashes = ListStashes(repoPath) },
+		func() { details.RecentCommits = ListCommits(repoPath, "HEAD", recentCommitLimit) },
+		func() { details.ChangedFiles = ListChangedFiles(repoPath) },
+	)
+
+	return details
+}
+
+func ListRemotes(repoPath string) []domain.Remote {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "remote", "-v")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	return parseRemotes(string(output))
+}
+
+func parseRemotes(output string) []domain.Remote {
+	var remotes []domain.Remote
+	seen := make(map[string]bool)
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || seen[fields[0]] {
+			continue
+		}
+		if len(fields) >= 3 && fields[2] != "(fetch)" {
+			continue
+		}
+		seen[fields[0]] = true
+		remote := domain.Remote{Name: fields[0], URL: fields[1]}
+		if location, ok := ResolveRemoteURL(nil, remote.URL); ok {
+			remote.Host = location.Host
+		}
+		remotes = append(remotes, remote)
+	}
+	return remotes
+}
+
+func GetUpstream(repoPath string) string {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{u}")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func ListBranchStatuses(repoPath string) []domain.BranchStatus {
+	format := strings.Join([]string{"%(HEAD)", "%(refname:short)", "%(upstream:short)", "%(upstream:track,nobracket)"}, "%00")
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "for-each-ref", "--format="+format, "refs/heads")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	return parseBranchStatuses(string(output))
+}
+
+func parseBranchStatuses(output string) []domain.BranchStatus {
+	var branches []domain.BranchStatus
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), fieldSeparator)
+		if len(fields) < 4 || fields[1] == "" {
+			continue
+		}
+
+		branch := domain.BranchStatus{
+			Name:      fields[1],
+			Upstream:  fields[2],
+			IsCurrent: strings.TrimSpace(fields[0]) == "*",
+		}
+		branch.Ahead, branch.Behind, branch.UpstreamGone = parseTrack(fields[3])
+		branches = append(branches, branch)
+	}
+	return branches
+}
+
+// parseTrack reads the %(upstream:track,nobracket) form: "ahead 1, behind 2" or "gone".
+func parseTrack(track string) (ahead, behind int, gone bool) {
+	track = strings.TrimSpace(track)
+	if track == "gone" {
+		return 0, 0, true
+	}
+
+	for _, part := range strings.Split(track, ",") {
+		var count int
+		part = strings.TrimSpace(part)
+		if _, err := fmt.Sscanf(part, "ahead %d", &count); err == nil {
+			ahead = count
+		} else if _, err := fmt.Sscanf(part, "behind %d", &count); err == nil {
+			behind = count
+		}
+	}
+	return ahead, behind, false
+}
+
+func ListStashes(repoPath string) []domain.StashEntry {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "stash", "list", "--format=%gd%x00%ct%x00%gs")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	return parseStashList(string(output))
+}
+
+func parseStashList(output string) []domain.StashEntry {
+	var stashes []domain.StashEntry
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), fieldSeparator, 3)
+		if len(fields) < 3 || fields[0] == "" {
+			continue
+		}
+
+		branch, message := parseStashSubject(fields[2])
+		stashes = append(stashes, domain.StashEntry{
+			Ref:       fields[0],
+			Branch:    branch,
+			Message:   message,
+			CreatedAt: parseUnixSeconds(fields[1]),
+		})
+	}
+	return stashes
+}
+
+// parseStashSubject splits a reflog subject such as "WIP on main: abc1234 msg"
+// or "On feature/x: msg" into the branch and the message.
+func parseStashSubject(subject string) (branch, message string) {
+	rest := subject
+	switch {
+	case strings.HasPrefix(rest, "WIP on "):
+		rest = strings.TrimPrefix(rest, "WIP on ")
+	case strings.HasPrefix(rest, "On "):
+		rest = strings.TrimPrefix(rest, "On ")
+	default:
+		return "", strings.TrimSpace(subject)
+	}
+
+	colon := strings.Index(rest, ": ")
+	if colon < 0 {
+		return "", strings.TrimSpace(subject)
+	}
+	return rest[:colon], strings.TrimSpace(rest[colon+2:])
+}
+
+// ListCommits returns up to limit commits reachable from revision, newest first.
+// revision may be a range such as HEAD..@{u}.
+func ListCommits(repoPath string, revision string, limit int) []domain.Commit {
+	args := []string{"log", "--format=%H%x00%h%x00%an%x00%ct%x00%s"}
+	if limit > 0 {
+		args = append(args, "-n", strconv.Itoa(limit))
+	}
+	args = append(args, revision, "--")
+
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", args...)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	return parseCommitLog(string(output))
+}
+
+func parseCommitLog(output string) []domain.Commit {
+	var commits []domain.Commit
+
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), fieldSeparator, 5)
+		if len(fields) < 5 || fields[0] == "" {
+			continue
+		}
+		commits = append(commits, domain.Commit{
+			Hash:      fields[0],
+			ShortHash: fields[1],
+			Author:    fields[2],
+			Date:      parseUnixSeconds(fields[3]),
+			Subject:   fields[4],
+		})
+	}
+	return commits
+}
+
+func ListChangedFiles(repoPath string) []domain.ChangedFile {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "status", "--porcelain=v1", "-z")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	return parseChangedFiles(string(output))
+}
+
+func parseChangedFiles(output string) []domain.ChangedFile {
+	var files []domain.ChangedFile
+
+	entries := strings.Split(output, fieldSeparator)
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+
+		x, y := entry[0], entry[1]
+		file := domain.ChangedFile{Path: entry[3:], IndexCode: x, WorkCode: y}
+		if (x == 'R' || x == 'C') && i+1 < len(entries) {
+			i++
+			file.OrigPath = entries[i]
+		}
+
+		switch {
+		case x == '?' && y == '?':
+			file.Kind = domain.ChangeUntracked
+			files = append(files, file)
+		case x == 'U' || y == 'U' || (x == 'A' && y == 'A') || (x == 'D' && y == 'D'):
+			file.Kind = domain.ChangeConflicted
+			files = append(files, file)
+		default:
+			if x != ' ' && x != '!' {
+				staged := file
+				staged.Kind = domain.ChangeStaged
+				files = append(files, staged)
+			}
+			if y != ' ' && y != '!' {
+				unstaged := file
+				unstaged.Kind = domain.ChangeUnstaged
+				files = append(files, unstaged)
+			}
+		}
+	}
+	return files
+}
+
+func parseUnixSeconds(value string) time.Time {
+	seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
@@ -0,0 +1,120 @@
+package git
+
+import (
+	"testing"
+	"time"
+
+	"fresh/internal/domain"
+)
+
+func TestParseRemotesUsesFetchURLs(t *testing.T) {
+	t.Parallel()
+
+	output := "origin\tgit@github.com:me/fork.git (fetch)\n" +
+		"origin\tgit@github.com:me/fork.git (push)\n" +
+		"upstream\thttps://github.com/acme/project.git (fetch)\n" +
+		"upstream\tno_push (push)\n"
+
+	got := parseRemotes(output)
+	want := []domain.Remote{
+		{Name: "origin", URL: "git@github.com:me/fork.git", Host: "github.com"},
+		{Name: "upstream", URL: "https://github.com/acme/project.git", Host: "github.com"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseRemotes() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("parseRemotes()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParseBranchStatuses(t *testing.T) {
+	t.Parallel()
+
+	output := "*\x00main\x00origin/main\x00behind 3\n" +
+		" \x00feature\x00origin/feature\x00ahead 2, behind 1\n" +
+		" \x00old\x00origin/old\x00gone\n" +
+		" \x00local\x00\x00\n"
+
+	got := parseBranchStatuses(output)
+	if len(got) != 4 {
+		t.Fatalf("branches = %d, want 4", len(got))
+	}
+	if !got[0].IsCurrent || got[0].Behind != 3 || got[0].Ahead != 0 {
+		t.Fatalf("main = %+v, want current and behind 3", got[0])
+	}
+	if got[1].Ahead != 2 || got[1].Behind != 1 {
+		t.Fatalf("feature = %+v, want ahead 2 behind 1", got[1])
+	}
+	if !got[2].UpstreamGone {
+		t.Fatalf("old = %+v, want upstream gone", got[2])
+	}
+	if got[3].Upstream != "" {
+		t.Fatalf("local = %+v, want no upstream", got[3])
+	}
+}
+
+func TestParseStashList(t *testing.T) {
+	t.Parallel()
+
+	output := "stash@{0}\x001700000000\x00WIP on main: abc1234 Fix parser\n" +
+		"stash@{1}\x001600000000\x00On feature/x: experiment: keep\n"
+
+	got := parseStashList(output)
+	if len(got) != 2 {
+		t.Fatalf("stashes = %d, want 2", len(got))
+	}
+	if got[0].Ref != "stash@{0}" || got[0].Branch != "main" || got[0].Message != "abc1234 Fix parser" {
+		t.Fatalf("stash 0 = %+v", got[0])
+	}
+	if got[1].Branch != "feature/x" || got[1].Message != "experiment: keep" {
+		t.Fatalf("stash 1 = %+v", got[1])
+	}
+	if !got[1].CreatedAt.Equal(time.Unix(1600000000, 0)) {
+		t.Fatalf("stash 1 created = %v", got[1].CreatedAt)
+	}
+}
+
+func TestParseCommitLog(t *testing.T) {
+	t.Parallel()
+
+	output := "0123456789abcdef\x000123456\x00Ada\x001700000000\x00Add feature\n"
+
+	got := parseCommitLog(output)
+	if len(got) != 1 {
+		t.Fatalf("commits = %d, want 1", len(got))
+	}
+	if got[0].ShortHash != "0123456" || got[0].Author != "Ada" || got[0].Subject != "Add feature" {
+		t.Fatalf("commit = %+v", got[0])
+	}
+}
+
+func TestParseChangedFiles(t *testing.T) {
+	t.Parallel()
+
+	output := "MM both.go\x00A  added.go\x00 D removed.go\x00R  new.go\x00old.go\x00UU conflict.go\x00?? scratch.txt\x00"
+
+	got := parseChangedFiles(output)
+	counts := make(map[domain.ChangeKind]int)
+	for _, file := range got {
+		counts[file.Kind]++
+	}
+
+	if counts[domain.ChangeStaged] != 3 {
+		t.Fatalf("staged = %d, want 3 (%+v)", counts[domain.ChangeStaged], got)
+	}
+	if counts[domain.ChangeUnstaged] != 2 {
+		t.Fatalf("unstaged = %d, want 2", counts[domain.ChangeUnstaged])
+	}
+	if counts[domain.ChangeConflicted] != 1 || counts[domain.ChangeUntracked] != 1 {
+		t.Fatalf("conflicted/untracked = %d/%d, want 1/1", counts[domain.ChangeConflicted], counts[domain.ChangeUntracked])
+	}
+
+	for _, file := range got {
+		if file.Path == "new.go" && file.OrigPath != "old.go" {
+			t.Fatalf("rename orig path = %q, want old.go", file.OrigPath)
+		}
+	}
+}
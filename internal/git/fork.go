@@ -0,0 +1,186 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+
+	"fresh/internal/domain"
+)
+
+const (
+	originRemote   = "origin"
+	upstreamRemote = "upstream"
+)
+
+// IsForkLayout reports whether remotes follow the fork convention of origin
+// for the user's fork and upstream for the canonical repository.
+func IsForkLayout(remotes []domain.Remote) bool {
+	var origin, upstream bool
+	for _, remote := range remotes {
+		switch remote.Name {
+		case originRemote:
+			origin = true
+		case upstreamRemote:
+			upstream = true
+		}
+	}
+	return origin && upstream
+}
+
+// GetForkState compares the default branch on origin with the same branch on
+// upstream, using the remote-tracking refs from the last fetch. Fetch and
+// RefreshRemoteStatusWithFetch keep upstream's ref current for forks.
+func GetForkState(repoPath string, remotes []domain.Remote) domain.ForkState {
+	if !IsForkLayout(remotes) {
+		return domain.NotFork{}
+	}
+
+	branch := upstreamDefaultBranch(repoPath)
+	if branch == "" {
+		return domain.ForkError{Message: "upstream has no default branch"}
+	}
+	originRef := "refs/remotes/" + originRemote + "/" + branch
+	if !refExists(repoPath, originRef) {
+		return domain.ForkError{Message: fmt.Sprintf("origin has no %s branch", branch)}
+	}
+
+	ahead, behind, err := leftRightCount(repoPath, originRef, "refs/remotes/"+upstreamRemote+"/"+branch)
+	if err != nil {
+		return domain.ForkError{Message: err.Error()}
+	}
+	return domain.ForkStatus{Branch: branch, Ahead: ahead, Behind: behind}
+}
+
+// fetchUpstreamDefault updates upstream's remote-tracking ref for its default
+// branch, which a plain fetch of origin leaves alone. GetForkState compares
+// against that ref, so refreshing a fork runs this after the main fetch.
+func fetchUpstreamDefault(repoPath string) error {
+	args := []string{"fetch", "--quiet", upstreamRemote}
+	if branch := upstreamDefaultBranch(repoPath); branch != "" {
+		args = append(args, branch)
+	}
+	cmd := createCommand(defaultConfig.Timeout.Fetch, "git", args...)
+	cmd.Dir = repoPath
+	_, err := cmd.CombinedOutput()
+	return err
+}
+
+// SyncFork fetches upstream, fast-forwards the local default branch to
+// upstream's and pushes upstream's branch to origin. Nothing is ever
+// force-updated: a local or origin branch that has diverged from upstream
+// fails the sync instead, and local commits are never pushed.
+func SyncFork(repoPath string, lineCallback func(string)) domain.ForkSyncOutcome {
+	emit := func(line string) {
+		if lineCallback != nil {
+			lineCallback(line)
+		}
+	}
+	skip := func(reason string) domain.ForkSyncOutcome {
+		emit("Skipped: " + reason)
+		return domain.ForkSyncOutcome{Skipped: true, SkipReason: reason}
+	}
+
+	if !IsForkLayout(ListRemotes(repoPath)) {
+		return skip("not a fork (needs origin and upstream remotes)")
+	}
+
+	emit("Fetching upstream")
+	if outcome := runStreamedCommand(repoPath, defaultConfig.Timeout.Fetch, lineCallback, "fetch", "--progress", upstreamRemote); !outcome.IsSuccess() {
+		return domain.ForkSyncOutcome{CommandOutcome: outcome}
+	}
+
+	branch := upstreamDefaultBranch(repoPath)
+	if branch == "" {
+		return skip("upstream has no default branch")
+	}
+	result := domain.ForkSyncOutcome{Branch: branch}
+	fail := func(outcome domain.CommandOutcome) domain.ForkSyncOutcome {
+		result.CommandOutcome = outcome
+		return result
+	}
+
+	upstreamRef := "refs/remotes/" + upstreamRemote + "/" + branch
+	localRef := "refs/heads/" + branch
+	hasLocal := refExists(repoPath, localRef)
+
+	originBehind := -1
+	if originRef := "refs/remotes/" + originRemote + "/" + branch; refExists(repoPath, originRef) {
+		if _, behind, err := leftRightCount(repoPath, originRef, upstreamRef); err == nil {
+			originBehind = behind
+		}
+	}
+	localUpToDate := !hasLocal || isAncestor(repoPath, upstreamRef, localRef)
+	if originBehind == 0 && localUpToDate {
+		emit(fmt.Sprintf("%s already matches upstream", branch))
+		result.UpToDate = true
+		return result
+	}
+	result.Behind = max(originBehind, 0)
+
+	if hasLocal && !localUpToDate {
+		current, onBranch := GetCurrentBranch(repoPath).(domain.OnBranch)
+		if onBranch && current.Name == branch {
+			dirty, err := HasTrackedChanges(repoPath)
+			if err != nil {
+				return fail(domain.CommandOutcome{ExitCode: commandExitCode(err), FailureReason: err.Error()})
+			}
+			if dirty {
+				return skip("uncommitted changes on " + branch)
+			}
+			emit(fmt.Sprintf("Fast-forwarding %s", branch))
+			if outcome := runStreamedCommand(repoPath, defaultConfig.Timeout.Default, lineCallback, "merge", "--ff-only", upstreamRef); !outcome.IsSuccess() {
+				return fail(outcome)
+			}
+		} else {
+			// Fetching from the repository itself updates a branch that is
+			// not checked out, refusing anything but a fast-forward.
+			emit(fmt.Sprintf("Fast-forwarding %s", branch))
+			if outcome := runStreamedCommand(repoPath, defaultConfig.Timeout.Default, lineCallback, "fetch", ".", upstreamRef+":"+localRef); !outcome.IsSuccess() {
+				return fail(outcome)
+			}
+		}
+	}
+
+	emit(fmt.Sprintf("Pushing %s to origin", branch))
+	if outcome := runStreamedCommand(repoPath, defaultConfig.Timeout.Pull, lineCallback, "push", "--progress", originRemote, upstreamRef+":"+localRef); !outcome.IsSuccess() {
+		return fail(outcome)
+	}
+	result.Pushed = true
+	return result
+}
+
+// upstreamDefaultBranch returns the branch upstream's HEAD points at, falling
+// back to main or master when upstream/HEAD has not been recorded.
+func upstreamDefaultBranch(repoPath string) string {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "symbolic-ref", "--quiet", "--short", "refs/remotes/"+upstreamRemote+"/HEAD")
+	cmd.Dir = repoPath
+	if output, err := cmd.Output(); err == nil {
+		if branch := strings.TrimPrefix(strings.TrimSpace(string(output)), upstreamRemote+"/"); branch != "" {
+			return branch
+		}
+	}
+
+	for _, candidate := range []string{"main", "master"} {
+		if refExists(repoPath, "refs/remotes/"+upstreamRemote+"/"+candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// leftRightCount returns how many commits only left has and how many only
+// right has.
+func leftRightCount(repoPath string, left string, right string) (int, int, error) {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "rev-list", "--left-right", "--count", left+"..."+right)
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var leftCount, rightCount int
+	if _, err := fmt.Sscanf(strings.TrimSpace(string(output)), "%d\t%d", &leftCount, &rightCount); err != nil {
+		return 0, 0, fmt.Errorf("failed to parse rev-list output")
+	}
+	return leftCount, rightCount, nil
+}
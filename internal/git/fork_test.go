@@ -0,0 +1,57 @@
+package git
+
+import (
+	"testing"
+
+	"fresh/internal/config"
+	"fresh/internal/domain"
+)
+
+func TestIsForkLayout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		remotes []domain.Remote
+		want    bool
+	}{
+		{name: "no remotes", want: false},
+		{name: "origin only", remotes: []domain.Remote{{Name: "origin"}}, want: false},
+		{name: "upstream only", remotes: []domain.Remote{{Name: "upstream"}}, want: false},
+		{name: "origin and upstream", remotes: []domain.Remote{{Name: "upstream"}, {Name: "origin"}}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsForkLayout(tt.remotes); got != tt.want {
+				t.Fatalf("IsForkLayout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNeedsUpstreamFetch(t *testing.T) {
+	t.Parallel()
+
+	fork := []domain.Remote{{Name: "origin"}, {Name: "upstream"}}
+	tests := []struct {
+		name    string
+		opts    config.FetchConfig
+		remotes []domain.Remote
+		want    bool
+	}{
+		{name: "fork with plain fetch", remotes: fork, want: true},
+		{name: "fork with all remotes", opts: config.FetchConfig{AllRemotes: true}, remotes: fork, want: false},
+		{name: "not a fork", remotes: []domain.Remote{{Name: "origin"}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := needsUpstreamFetch(tt.opts, tt.remotes); got != tt.want {
+				t.Fatalf("needsUpstreamFetch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -14,6 +14,8 @@ import (
 	"time"
 )
 
+// defaultConfig only supplies command timeouts. Forge hosts and tokens come
+// from the configuration callers pass in.
 var defaultConfig = config.DefaultConfig()
 
 type RefreshMode int
@@ -42,11 +44,11 @@ func RefreshRepository(path string, cfg *config.Config, opts RefreshRepositoryOp
 			repo = BuildRepository(path, cfg)
 		}
 
-		_ = RefreshRemoteStatusWithFetch(&repo)
+		_ = RefreshRemoteStatusWithFetch(&repo, cfg.Fetch)
 		return repo
 
 	case RefreshModeFetchAndBuild:
-		_ = Fetch(path)
+		_ = Fetch(path, cfg.Fetch)
 		return BuildRepository(path, cfg)
 
 	default:
@@ -78,9 +80,11 @@ func BuildRepository(path string, cfg *config.Config) domain.Repository {
 	type result struct {
 		localState  domain.LocalState
 		remoteState domain.RemoteState
-		remoteURL   string
+		remotes     []domain.Remote
+		fork        domain.ForkState
 		branches    domain.Branches
 		stashCount  int
+		lastCommit  time.Time
 	}
 
 	var res result
@@ -88,8 +92,12 @@ func BuildRepository(path string, cfg *config.Config) domain.Repository {
 	Parallel(
 		func() { res.localState, res.stashCount = GetLocalState(path) },
 		func() { res.remoteState = GetRemoteState(path) },
-		func() { res.remoteURL = GetRemoteURL(path) },
+		func() {
+			res.remotes = ListRemotes(path)
+			res.fork = GetForkState(path, res.remotes)
+		},
 		func() { res.branches = BuildBranches(path, cfg.ProtectedBranches) },
+		func() { res.lastCommit = GetLastCommitTime(path) },
 	)
 
 	return domain.Repository{
@@ -97,9 +105,12 @@ func BuildRepository(path string, cfg *config.Config) domain.Repository {
 		Path:         path,
 		Branches:     res.branches,
 		StashCount:   res.stashCount,
+		LastCommitAt: res.lastCommit,
 		LocalState:   res.localState,
-		RemoteURL:    res.remoteURL,
+		RemoteURL:    PrimaryRemoteURL(res.remotes),
+		Remotes:      res.remotes,
 		RemoteState:  res.remoteState,
+		Fork:         res.fork,
 		PullRequests: domain.PullRequestUnavailable{},
 	}
 }
@@ -117,15 +128,18 @@ func IsRepository(path string) bool {
 	return err == nil
 }
 
-func GetRemoteURL(repoPath string) string {
-	cmd := createCommand(defaultConfig.Timeout.Default, "git", "remote", "get-url", "origin")
-	cmd.Dir = repoPath
-	output, err := cmd.Output()
-	if err != nil {
-		return ""
+// PrimaryRemoteURL returns origin's URL, falling back to the first remote so
+// repositories whose only remote has another name still resolve a host.
+func PrimaryRemoteURL(remotes []domain.Remote) string {
+	for _, remote := range remotes {
+		if remote.Name == "origin" {
+			return remote.URL
+		}
 	}
-
-	return strings.TrimSpace(string(output))
+	if len(remotes) > 0 {
+		return remotes[0].URL
+	}
+	return ""
 }
 
 func GetCurrentBranch(repoPath string) domain.Branch {
@@ -139,7 +153,7 @@ func GetCurrentBranch(repoPath string) domain.Branch {
 	name := strings.TrimSpace(string(branch))
 	switch name {
 	case "HEAD":
-		return domain.DetachedHead{}
+		return domain.DetachedHead{CommitSHA: shortHeadSHA(repoPath)}
 	case "":
 		return domain.NoBranch{Reason: "no branch"}
 	default:
@@ -147,6 +161,29 @@ func GetCurrentBranch(repoPath string) domain.Branch {
 	}
 }
 
+// shortHeadSHA returns the abbreviated commit HEAD points at, or "" when it
+// cannot be read.
+func shortHeadSHA(repoPath string) string {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "rev-parse", "--short", "HEAD")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func GetLastCommitTime(repoPath string) time.Time {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "log", "-1", "--format=%ct")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return time.Time{}
+	}
+
+	return parseUnixSeconds(string(output))
+}
+
 func GetLocalState(repoPath string) (domain.LocalState, int) {
 	cmd := createCommand(defaultConfig.Timeout.Default, "git", "status", "--porcelain=v2", "--branch", "--show-stash")
 	cmd.Dir = repoPath
@@ -256,15 +293,40 @@ func GetRemoteState(repoPath string) domain.RemoteState {
 	return domain.Synced{}
 }
 
-func Fetch(repoPath string) error {
-	cmd := createCommand(defaultConfig.Timeout.Fetch, "git", "fetch", "--quiet")
+func fetchArgs(opts config.FetchConfig) []string {
+	args := []string{"fetch", "--quiet"}
+	if opts.AllRemotes {
+		args = append(args, "--all")
+	}
+	if opts.Prune {
+		args = append(args, "--prune")
+	}
+	if opts.Tags {
+		args = append(args, "--tags")
+	}
+	return args
+}
+
+func Fetch(repoPath string, opts config.FetchConfig) error {
+	cmd := createCommand(defaultConfig.Timeout.Fetch, "git", fetchArgs(opts)...)
 	cmd.Dir = repoPath
-	_, err := cmd.CombinedOutput()
-	return err
+	if _, err := cmd.CombinedOutput(); err != nil {
+		return err
+	}
+	if needsUpstreamFetch(opts, ListRemotes(repoPath)) {
+		return fetchUpstreamDefault(repoPath)
+	}
+	return nil
 }
 
-func RefreshRemoteStatusWithFetch(repo *domain.Repository) error {
-	cmd := createCommand(defaultConfig.Timeout.Fetch, "git", "fetch", "--quiet")
+// needsUpstreamFetch reports whether a fork's upstream must be fetched
+// separately: fetching all remotes already covers it.
+func needsUpstreamFetch(opts config.FetchConfig, remotes []domain.Remote) bool {
+	return !opts.AllRemotes && IsForkLayout(remotes)
+}
+
+func RefreshRemoteStatusWithFetch(repo *domain.Repository, opts config.FetchConfig) error {
+	cmd := createCommand(defaultConfig.Timeout.Fetch, "git", fetchArgs(opts)...)
 	cmd.Dir = repo.Path
 	output, err := cmd.CombinedOutput()
 	if err != nil {
@@ -277,6 +339,13 @@ func RefreshRemoteStatusWithFetch(repo *domain.Repository) error {
 	}
 
 	repo.RemoteState = GetRemoteState(repo.Path)
+	if needsUpstreamFetch(opts, repo.Remotes) {
+		if err := fetchUpstreamDefault(repo.Path); err != nil {
+			repo.Fork = domain.ForkError{Message: "upstream fetch failed"}
+			return nil
+		}
+	}
+	repo.Fork = GetForkState(repo.Path, repo.Remotes)
 	return nil
 }
 
@@ -314,7 +383,7 @@ func BuildBranches(repoPath string, excludedBranches []string) domain.Branches {
 }
 
 func ListLocalBranches(repoPath string) ([]string, error) {
-	cmd := createCommand(defaultConfig.Timeout.Default, "git", "branch", "--format=%(refname:short)")
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "for-each-ref", "--format=%(refname:short)", "refs/heads")
 	cmd.Dir = repoPath
 	output, err := cmd.Output()
 	if err != nil {
@@ -0,0 +1,70 @@
+package git
+
+import (
+	"strings"
+	"testing"
+
+	"fresh/internal/config"
+	"fresh/internal/domain"
+)
+
+func TestFetchArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts config.FetchConfig
+		want string
+	}{
+		{name: "default remote only", want: "fetch --quiet"},
+		{name: "all remotes", opts: config.FetchConfig{AllRemotes: true}, want: "fetch --quiet --all"},
+		{
+			name: "all remotes with prune and tags",
+			opts: config.FetchConfig{AllRemotes: true, Prune: true, Tags: true},
+			want: "fetch --quiet --all --prune --tags",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := strings.Join(fetchArgs(tt.opts), " "); got != tt.want {
+				t.Fatalf("fetchArgs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrimaryRemoteURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		remotes []domain.Remote
+		want    string
+	}{
+		{name: "no remotes", want: ""},
+		{
+			name: "prefers origin",
+			remotes: []domain.Remote{
+				{Name: "upstream", URL: "https://github.com/acme/project.git"},
+				{Name: "origin", URL: "git@github.com:me/fork.git"},
+			},
+			want: "git@github.com:me/fork.git",
+		},
+		{
+			name:    "falls back to first remote",
+			remotes: []domain.Remote{{Name: "github", URL: "https://github.com/acme/project.git"}},
+			want:    "https://github.com/acme/project.git",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := PrimaryRemoteURL(tt.remotes); got != tt.want {
+				t.Fatalf("PrimaryRemoteURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
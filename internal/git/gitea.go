@@ -0,0 +1,203 @@
+package git
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"fresh/internal/config"
+)
+
+// cachedGiteaUsers caches the authenticated login per host.
+var cachedGiteaUsers hostLoginCaches
+
+// giteaClient talks to the REST API of one Gitea or Forgejo instance; both
+// serve the same /api/v1.
+type giteaClient struct {
+	restClient
+	host string
+}
+
+type giteaPullRequest struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	HTMLURL   string `json:"html_url"`
+	Draft     bool   `json:"draft"`
+	Mergeable bool   `json:"mergeable"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	UpdatedAt string `json:"updated_at"`
+	User      struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Head struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+	RequestedReviewers []struct {
+		Login string `json:"login"`
+	} `json:"requested_reviewers"`
+}
+
+// giteaIssue is an issue search result; with type=pulls every result is a
+// pull request.
+type giteaIssue struct {
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+type giteaCombinedStatus struct {
+	State    string        `json:"state"`
+	Statuses []giteaStatus `json:"statuses"`
+}
+
+type giteaStatus struct {
+	Context   string `json:"context"`
+	Status    string `json:"status"`
+	TargetURL string `json:"target_url"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type giteaReview struct {
+	State     string `json:"state"`
+	Dismissed bool   `json:"dismissed"`
+	Stale     bool   `json:"stale"`
+	User      struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// findInstance returns the instance configured for host.
+func findInstance(instances []config.Instance, host string) (config.Instance, bool) {
+	for _, instance := range instances {
+		if strings.EqualFold(instance.Host, host) {
+			return instance, true
+		}
+	}
+	return config.Instance{}, false
+}
+
+func parseGiteaRemote(cfg *config.Config, remoteURL string) (RemoteLocation, bool) {
+	location, ok := ResolveRemoteURL(cfg, remoteURL)
+	if !ok || strings.Contains(location.Owner, "/") {
+		return RemoteLocation{}, false
+	}
+	if _, ok := findInstance(cfg.Gitea.Instances, location.Host); !ok {
+		return RemoteLocation{}, false
+	}
+	return location, true
+}
+
+func newGiteaClient(cfg config.GiteaConfig, host string) (*giteaClient, error) {
+	instance, _ := findInstance(cfg.Instances, host)
+	if instance.Token == "" {
+		return nil, fmt.Errorf("gitea: no token configured for %s", host)
+	}
+	return newGiteaClientAt(host, "https://"+host+"/api/v1", instance.Token, newAPIHTTPClient(defaultConfig.Timeout.Default)), nil
+}
+
+func newGiteaClientAt(host string, baseURL string, token string, client *http.Client) *giteaClient {
+	return &giteaClient{
+		restClient: restClient{
+			name:       "gitea",
+			baseURL:    baseURL,
+			authHeader: "Authorization",
+			authValue:  "token " + token,
+			tokenHint:  "the token configured for " + host,
+			http:       client,
+		},
+		host: host,
+	}
+}
+
+func giteaRepoPath(owner, repo string) string {
+	return "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+}
+
+// openPullRequestCount reads X-Total-Count so only one pull request has to
+// be transferred.
+func (c *giteaClient) openPullRequestCount(owner, repo string) (int, error) {
+	query := url.Values{"state": {"open"}, "limit": {"1"}}
+	var page []giteaPullRequest
+	header, err := c.get(giteaRepoPath(owner, repo)+"/pulls", query, &page)
+	if err != nil {
+		return 0, err
+	}
+	if total, err := strconv.Atoi(header.Get("X-Total-Count")); err == nil {
+		return total, nil
+	}
+	return len(page), nil
+}
+
+func (c *giteaClient) myPullRequests() ([]giteaIssue, error) {
+	query := url.Values{"type": {"pulls"}, "state": {"open"}, "created": {"true"}, "limit": {"50"}}
+	var issues []giteaIssue
+	if _, err := c.get("/repos/issues/search", query, &issues); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+// reviewRequests finds the open pull requests waiting on the user's
+// review.
+func (c *giteaClient) reviewRequests() ([]giteaIssue, error) {
+	query := url.Values{"type": {"pulls"}, "state": {"open"}, "review_requested": {"true"}, "limit": {"50"}}
+	var issues []giteaIssue
+	if _, err := c.get("/repos/issues/search", query, &issues); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+func (c *giteaClient) pullRequests(owner, repo string) ([]giteaPullRequest, error) {
+	query := url.Values{"state": {"open"}, "sort": {"recentupdate"}, "limit": {"50"}}
+	var pullRequests []giteaPullRequest
+	if _, err := c.get(giteaRepoPath(owner, repo)+"/pulls", query, &pullRequests); err != nil {
+		return nil, err
+	}
+	return pullRequests, nil
+}
+
+func (c *giteaClient) pullRequest(owner, repo string, number int) (giteaPullRequest, error) {
+	var pullRequest giteaPullRequest
+	_, err := c.get(fmt.Sprintf("%s/pulls/%d", giteaRepoPath(owner, repo), number), nil, &pullRequest)
+	return pullRequest, err
+}
+
+func (c *giteaClient) combinedStatus(owner, repo, sha string) (giteaCombinedStatus, error) {
+	var status giteaCombinedStatus
+	if sha == "" {
+		return status, nil
+	}
+	_, err := c.get(giteaRepoPath(owner, repo)+"/commits/"+url.PathEscape(sha)+"/status", nil, &status)
+	return status, err
+}
+
+func (c *giteaClient) reviews(owner, repo string, number int) ([]giteaReview, error) {
+	var reviews []giteaReview
+	_, err := c.get(fmt.Sprintf("%s/pulls/%d/reviews", giteaRepoPath(owner, repo), number), nil, &reviews)
+	return reviews, err
+}
+
+func (c *giteaClient) currentUser() string {
+	return cachedGiteaUsers.forHost(c.host).get(time.Now(), githubLoginCacheTTL, func() (string, error) {
+		var user struct {
+			Login string `json:"login"`
+		}
+		_, err := c.get("/user", nil, &user)
+		return user.Login, err
+	})
+}
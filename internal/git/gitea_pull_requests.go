@@ -0,0 +1,426 @@
+package git
+
+import (
+	"fresh/internal/config"
+	"fresh/internal/domain"
+	"fresh/internal/pullrequests"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GiteaPullRequestService fills the pull request columns for repositories
+// hosted on a configured Gitea or Forgejo instance.
+type GiteaPullRequestService struct {
+	// Config lists the Gitea instances and their tokens.
+	Config *config.Config
+}
+
+func (GiteaPullRequestService) Name() string { return "gitea" }
+
+func (s GiteaPullRequestService) Match(remoteURL string) (RemoteLocation, bool) {
+	return parseGiteaRemote(s.Config, remoteURL)
+}
+
+func (s GiteaPullRequestService) SyncPullRequests(host string, reposByPath map[string]RemoteLocation) (map[string]domain.PullRequestState, []pullrequests.Snapshot, error) {
+	client, err := newGiteaClient(s.Config.Gitea, host)
+	if err != nil {
+		return nil, nil, err
+	}
+	return syncGiteaPullRequests(client, slugsByPath(reposByPath))
+}
+
+func (s GiteaPullRequestService) ListPullRequests(location RemoteLocation) ([]domain.PullRequestDetails, error) {
+	client, err := newGiteaClient(s.Config.Gitea, location.Host)
+	if err != nil {
+		return nil, err
+	}
+	return getGiteaPullRequests(client, location.Owner, location.Name)
+}
+
+func (s GiteaPullRequestService) GetPullRequest(location RemoteLocation, number int) (domain.PullRequest, error) {
+	client, err := newGiteaClient(s.Config.Gitea, location.Host)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	state, err := client.pullRequestState(location.Owner, location.Name, number)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	return giteaPullRequestDetail(state), nil
+}
+
+func (GiteaPullRequestService) PullRequestURL(location RemoteLocation, number int) string {
+	return location.WebURL() + "/pulls/" + strconv.Itoa(number)
+}
+
+func (s GiteaPullRequestService) CurrentUser(host string) string {
+	client, err := newGiteaClient(s.Config.Gitea, host)
+	if err != nil {
+		return ""
+	}
+	return client.currentUser()
+}
+
+// giteaPullRequestState is what classification needs beyond the pull
+// request itself: the head commit's statuses and the reviews so far.
+type giteaPullRequestState struct {
+	pullRequest giteaPullRequest
+	status      giteaCombinedStatus
+	reviews     []giteaReview
+}
+
+// syncGiteaPullRequests counts the open pull requests of every repository,
+// classifies the user's own and finds those waiting on their review.
+func syncGiteaPullRequests(client *giteaClient, reposByPath map[string]string) (map[string]domain.PullRequestState, []pullrequests.Snapshot, error) {
+	repoSet := make(map[string]string)
+	for _, slug := range reposByPath {
+		repoSet[strings.ToLower(slug)] = slug
+	}
+	slugs := make([]string, 0, len(repoSet))
+	for _, slug := range repoSet {
+		slugs = append(slugs, slug)
+	}
+
+	counts := make([]int, len(slugs))
+	if err := forEachConcurrently(len(slugs), func(i int) error {
+		owner, repo, _ := parseNameWithOwner(slugs[i])
+		count, err := client.openPullRequestCount(owner, repo)
+		counts[i] = count
+		return err
+	}); err != nil {
+		return nil, nil, err
+	}
+	openCounts := make(map[string]int, len(slugs))
+	for i, slug := range slugs {
+		openCounts[strings.ToLower(slug)] = counts[i]
+	}
+
+	mine, err := client.myPullRequests()
+	if err != nil {
+		return nil, nil, err
+	}
+	var relevant []giteaIssue
+	for _, issue := range mine {
+		if _, ok := repoSet[strings.ToLower(issue.Repository.FullName)]; ok && issue.Number > 0 {
+			relevant = append(relevant, issue)
+		}
+	}
+	// The search only returns issue fields; mergeability, the head commit
+	// and reviews each need their own request.
+	details := make([]giteaPullRequestState, len(relevant))
+	if err := forEachConcurrently(len(relevant), func(i int) error {
+		owner, repo, _ := parseNameWithOwner(relevant[i].Repository.FullName)
+		state, err := client.pullRequestState(owner, repo, relevant[i].Number)
+		details[i] = state
+		return err
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	summaries := make(map[string]myPullRequestSummary)
+	tracked := make([]pullrequests.Snapshot, 0, len(relevant))
+	for i, issue := range relevant {
+		key := strings.ToLower(issue.Repository.FullName)
+		owner, repo, ok := parseNameWithOwner(repoSet[key])
+		if !ok {
+			continue
+		}
+
+		classification := classifyGiteaPullRequest(details[i])
+		tracked = append(tracked, pullrequests.Snapshot{
+			Key:    pullrequests.Key{Owner: owner, Repo: repo, Number: issue.Number},
+			Status: classification,
+			Title:  strings.TrimSpace(issue.Title),
+		})
+
+		summary := summaries[key]
+		summary.MyOpen++
+		switch classification {
+		case pullrequests.StatusReady:
+			summary.MyReady++
+		case pullrequests.StatusBlocked:
+			summary.MyBlocked++
+		case pullrequests.StatusReview:
+			summary.MyReview++
+		default:
+			summary.MyChecks++
+		}
+		summaries[key] = summary
+	}
+
+	reviews, err := client.reviewRequests()
+	if err != nil {
+		return nil, nil, err
+	}
+	reviewRequests := make(map[string]int)
+	for _, issue := range reviews {
+		key := strings.ToLower(issue.Repository.FullName)
+		owner, repo, ok := parseNameWithOwner(repoSet[key])
+		if !ok || issue.Number <= 0 {
+			continue
+		}
+		reviewRequests[key]++
+		tracked = append(tracked, pullrequests.Snapshot{
+			Key:    pullrequests.Key{Owner: owner, Repo: repo, Number: issue.Number},
+			Status: pullrequests.StatusReviewRequested,
+			Title:  strings.TrimSpace(issue.Title),
+		})
+	}
+
+	states := make(map[string]domain.PullRequestState, len(reposByPath))
+	for path, slug := range reposByPath {
+		key := strings.ToLower(slug)
+		summary := summaries[key]
+		states[path] = domain.PullRequestCount{
+			Open:            openCounts[key],
+			MyOpen:          summary.MyOpen,
+			MyReady:         summary.MyReady,
+			MyBlocked:       summary.MyBlocked,
+			MyReview:        summary.MyReview,
+			MyChecks:        summary.MyChecks,
+			ReviewRequested: reviewRequests[key],
+		}
+	}
+	return states, tracked, nil
+}
+
+func (c *giteaClient) pullRequestState(owner, repo string, number int) (giteaPullRequestState, error) {
+	pullRequest, err := c.pullRequest(owner, repo, number)
+	if err != nil {
+		return giteaPullRequestState{}, err
+	}
+	status, err := c.combinedStatus(owner, repo, pullRequest.Head.SHA)
+	if err != nil {
+		return giteaPullRequestState{}, err
+	}
+	reviews, err := c.reviews(owner, repo, number)
+	if err != nil {
+		return giteaPullRequestState{}, err
+	}
+	return giteaPullRequestState{pullRequest: pullRequest, status: status, reviews: reviews}, nil
+}
+
+// giteaWorkInProgressPrefixes are the title prefixes Gitea treats as drafts
+// by default; older instances have no draft field.
+var giteaWorkInProgressPrefixes = []string{"wip:", "[wip]"}
+
+// classifyGiteaPullRequest maps a pull request onto the statuses used for
+// GitHub: drafts, conflicts, failing statuses and requested changes block,
+// pending statuses wait on checks, requested reviewers wait on review.
+func classifyGiteaPullRequest(state giteaPullRequestState) pullrequests.Status {
+	pr := state.pullRequest
+	if pr.Draft || !pr.Mergeable {
+		return pullrequests.StatusBlocked
+	}
+	title := strings.ToLower(strings.TrimSpace(pr.Title))
+	for _, prefix := range giteaWorkInProgressPrefixes {
+		if strings.HasPrefix(title, prefix) {
+			return pullrequests.StatusBlocked
+		}
+	}
+
+	gate := classifyGiteaStatusGate(state.status.State)
+	for _, status := range state.status.Statuses {
+		switch classifyGiteaStatusGate(status.Status) {
+		case pullRequestCheckGateFailing:
+			gate = pullRequestCheckGateFailing
+		case pullRequestCheckGatePending:
+			if gate == pullRequestCheckGateClear {
+				gate = pullRequestCheckGatePending
+			}
+		}
+	}
+	if gate == pullRequestCheckGateFailing {
+		return pullrequests.StatusBlocked
+	}
+
+	review := giteaReviewDecision(state.reviews)
+	if review == "REQUEST_CHANGES" {
+		return pullrequests.StatusBlocked
+	}
+	if gate == pullRequestCheckGatePending {
+		return pullrequests.StatusChecks
+	}
+	if review == "APPROVED" {
+		return pullrequests.StatusReady
+	}
+	if len(pr.RequestedReviewers) > 0 {
+		return pullrequests.StatusReview
+	}
+	return pullrequests.StatusReady
+}
+
+// giteaReviewDecision folds each reviewer's latest verdict into one, like
+// GitHub's reviewDecision: any requested changes win over approvals.
+// Comments, dismissed and stale reviews carry no verdict.
+func giteaReviewDecision(reviews []giteaReview) string {
+	latest := make(map[string]string)
+	for _, review := range reviews {
+		state := strings.ToUpper(strings.TrimSpace(review.State))
+		if review.Dismissed || review.Stale || (state != "APPROVED" && state != "REQUEST_CHANGES") {
+			continue
+		}
+		latest[strings.ToLower(review.User.Login)] = state
+	}
+
+	decision := ""
+	for _, state := range latest {
+		if state == "REQUEST_CHANGES" {
+			return state
+		}
+		decision = state
+	}
+	return decision
+}
+
+func classifyGiteaStatusGate(state string) pullRequestCheckGate {
+	switch strings.ToLower(strings.TrimSpace(state)) {
+	case "failure", "error":
+		return pullRequestCheckGateFailing
+	case "pending":
+		return pullRequestCheckGatePending
+	default:
+		return pullRequestCheckGateClear
+	}
+}
+
+func classifyGiteaStatus(status giteaStatus) checkSummaryClass {
+	switch strings.ToLower(strings.TrimSpace(status.Status)) {
+	case "success":
+		return checkSummaryPassed
+	case "failure", "error":
+		return checkSummaryFailed
+	case "warning":
+		return checkSummarySkipped
+	default:
+		return checkSummaryWaiting
+	}
+}
+
+func summarizeGiteaStatuses(statuses []giteaStatus) domain.PullRequestChecks {
+	summary := domain.PullRequestChecks{Total: len(statuses)}
+	for _, status := range statuses {
+		switch classifyGiteaStatus(status) {
+		case checkSummaryPassed:
+			summary.Passed++
+		case checkSummaryFailed:
+			summary.Failed++
+		case checkSummarySkipped:
+			summary.Skipped++
+		default:
+			summary.Waiting++
+		}
+	}
+	return summary
+}
+
+// getGiteaPullRequests lists a repository's open pull requests with the
+// commit statuses of each head summarised as checks.
+func getGiteaPullRequests(client *giteaClient, owner, repo string) ([]domain.PullRequestDetails, error) {
+	pullRequests, err := client.pullRequests(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make([]domain.PullRequestChecks, len(pullRequests))
+	if err := forEachConcurrently(len(pullRequests), func(i int) error {
+		status, err := client.combinedStatus(owner, repo, pullRequests[i].Head.SHA)
+		checks[i] = summarizeGiteaStatuses(status.Statuses)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	currentUser := client.currentUser()
+	details := make([]domain.PullRequestDetails, 0, len(pullRequests))
+	for i, pr := range pullRequests {
+		updatedAt, _ := time.Parse(time.RFC3339, pr.UpdatedAt)
+		reviewRequested := false
+		for _, reviewer := range pr.RequestedReviewers {
+			if currentUser != "" && strings.EqualFold(strings.TrimSpace(reviewer.Login), currentUser) {
+				reviewRequested = true
+			}
+		}
+		details = append(details, domain.PullRequestDetails{
+			Number:          pr.Number,
+			Title:           pr.Title,
+			IsMine:          currentUser != "" && strings.EqualFold(strings.TrimSpace(pr.User.Login), currentUser),
+			ReviewRequested: reviewRequested,
+			UpdatedAt:       updatedAt,
+			Checks:          checks[i],
+		})
+	}
+	return details, nil
+}
+
+// giteaPullRequestDetail shows a pull request with each commit status of
+// its head as a check. Statuses only record when they were created and
+// last updated, which is taken as the run time of finished ones.
+func giteaPullRequestDetail(state giteaPullRequestState) domain.PullRequest {
+	pr := state.pullRequest
+	pullRequest := domain.PullRequest{
+		Number:     pr.Number,
+		Title:      pr.Title,
+		URL:        pr.HTMLURL,
+		Author:     pr.User.Login,
+		HeadBranch: pr.Head.Ref,
+		BaseBranch: pr.Base.Ref,
+		Draft:      pr.Draft,
+		Mergeable:  domain.MergeableConflicting,
+		Additions:  pr.Additions,
+		Deletions:  pr.Deletions,
+	}
+	if pr.Mergeable {
+		pullRequest.Mergeable = domain.MergeableClean
+	}
+	for _, label := range pr.Labels {
+		pullRequest.Labels = append(pullRequest.Labels, label.Name)
+	}
+
+	for _, status := range state.status.Statuses {
+		run := domain.CheckRun{
+			Name:      status.Context,
+			Status:    checkStatusOf(classifyGiteaStatus(status)),
+			StartedAt: parseAPITime(status.CreatedAt),
+			URL:       status.TargetURL,
+		}
+		if run.Status != domain.CheckWaiting {
+			run.CompletedAt = parseAPITime(status.UpdatedAt)
+		}
+		pullRequest.Checks = append(pullRequest.Checks, run)
+	}
+
+	reviews := make([]domain.Reviewer, 0, len(state.reviews))
+	var requested []string
+	for _, review := range state.reviews {
+		switch strings.ToUpper(strings.TrimSpace(review.State)) {
+		case "REQUEST_REVIEW":
+			requested = append(requested, review.User.Login)
+			continue
+		case "PENDING":
+			// A review still being written is only visible to its author.
+			continue
+		}
+		reviews = append(reviews, domain.Reviewer{Login: review.User.Login, State: giteaReviewState(review)})
+	}
+	for _, reviewer := range pr.RequestedReviewers {
+		requested = append(requested, reviewer.Login)
+	}
+	pullRequest.Reviewers = foldReviewers(reviews, requested)
+	return pullRequest
+}
+
+func giteaReviewState(review giteaReview) domain.ReviewState {
+	if review.Dismissed {
+		return domain.ReviewDismissed
+	}
+	switch strings.ToUpper(strings.TrimSpace(review.State)) {
+	case "APPROVED":
+		return domain.ReviewApproved
+	case "REQUEST_CHANGES":
+		return domain.ReviewChangesRequested
+	default:
+		return domain.ReviewCommented
+	}
+}
@@ -0,0 +1,271 @@
+package git
+
+import (
+	"fresh/internal/domain"
+	"fresh/internal/pullrequests"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newGiteaStandIn serves routes as JSON and rejects requests without the
+// token, keeping the review_requested parameter in the route key.
+func newGiteaStandIn(t *testing.T, routes map[string]any, headers map[string]map[string]string) *giteaClient {
+	t.Helper()
+
+	standIn := restStandIn{authHeader: "Authorization", authValue: "token secret", pathPrefix: "/api/v1", queryKey: "review_requested"}
+	server, host, baseURL := standIn.serve(t, routes, headers)
+	return newGiteaClientAt(host, baseURL, "secret", server.Client())
+}
+
+func giteaSearchResult(fullName string, number int) giteaIssue {
+	issue := giteaIssue{Number: number, Title: "Change " + fullName}
+	issue.Repository.FullName = fullName
+	return issue
+}
+
+func giteaPR(sha string, opts ...func(*giteaPullRequest)) giteaPullRequest {
+	pr := giteaPullRequest{Mergeable: true}
+	pr.Head.SHA = sha
+	for _, opt := range opts {
+		opt(&pr)
+	}
+	return pr
+}
+
+func TestSyncGiteaPullRequests(t *testing.T) {
+	t.Parallel()
+
+	reviewer := func(pr *giteaPullRequest) {
+		pr.RequestedReviewers = append(pr.RequestedReviewers, struct {
+			Login string `json:"login"`
+		}{Login: "lead"})
+	}
+	routes := map[string]any{
+		"/repos/team/api/pulls": []giteaPullRequest{{Number: 1}},
+		"/repos/Team/Web/pulls": []giteaPullRequest{},
+		"/repos/issues/search": []giteaIssue{
+			giteaSearchResult("team/api", 1),
+			giteaSearchResult("team/api", 2),
+			giteaSearchResult("team/web", 3),
+			giteaSearchResult("other/unscanned", 4),
+		},
+		"/repos/issues/search?review_requested=true": []giteaIssue{
+			giteaSearchResult("team/web", 5),
+			giteaSearchResult("other/unscanned", 6),
+		},
+		"/repos/team/api/pulls/1":            giteaPR("aaa"),
+		"/repos/team/api/pulls/2":            giteaPR("bbb", reviewer),
+		"/repos/team/web/pulls/3":            giteaPR("ccc"),
+		"/repos/team/api/commits/aaa/status": giteaCombinedStatus{State: "success", Statuses: []giteaStatus{{Status: "success"}}},
+		"/repos/team/api/commits/bbb/status": giteaCombinedStatus{},
+		"/repos/team/web/commits/ccc/status": giteaCombinedStatus{State: "failure", Statuses: []giteaStatus{{Status: "failure"}}},
+		"/repos/team/api/pulls/1/reviews":    []giteaReview{},
+		"/repos/team/api/pulls/2/reviews":    []giteaReview{},
+		"/repos/team/web/pulls/3/reviews":    []giteaReview{},
+	}
+	headers := map[string]map[string]string{
+		"/repos/team/api/pulls": {"X-Total-Count": "5"},
+		"/repos/Team/Web/pulls": {"X-Total-Count": "0"},
+	}
+	client := newGiteaStandIn(t, routes, headers)
+
+	states, tracked, err := syncGiteaPullRequests(client, map[string]string{
+		"/src/api": "team/api",
+		"/src/web": "Team/Web",
+	})
+	if err != nil {
+		t.Fatalf("syncGiteaPullRequests() unexpected error: %v", err)
+	}
+
+	wantAPI := domain.PullRequestCount{Open: 5, MyOpen: 2, MyReady: 1, MyReview: 1}
+	if states["/src/api"] != wantAPI {
+		t.Errorf("api state = %+v, want %+v", states["/src/api"], wantAPI)
+	}
+	wantWeb := domain.PullRequestCount{MyOpen: 1, MyBlocked: 1, ReviewRequested: 1}
+	if states["/src/web"] != wantWeb {
+		t.Errorf("web state = %+v, want %+v", states["/src/web"], wantWeb)
+	}
+
+	if len(tracked) != 4 {
+		t.Fatalf("tracked = %+v, want 4 snapshots", tracked)
+	}
+	statuses := make(map[pullrequests.Key]pullrequests.Status)
+	for _, snapshot := range tracked {
+		statuses[snapshot.Key] = snapshot.Status
+	}
+	if got := statuses[pullrequests.Key{Owner: "Team", Repo: "Web", Number: 3}]; got != pullrequests.StatusBlocked {
+		t.Errorf("Team/Web#3 = %q, want blocked", got)
+	}
+	if got := statuses[pullrequests.Key{Owner: "Team", Repo: "Web", Number: 5}]; got != pullrequests.StatusReviewRequested {
+		t.Errorf("Team/Web#5 = %q, want a review request", got)
+	}
+}
+
+func TestSyncGiteaPullRequestsReportsAuthErrors(t *testing.T) {
+	t.Parallel()
+
+	client := newGiteaStandIn(t, nil, nil)
+	client.authValue = "token wrong"
+
+	_, _, err := syncGiteaPullRequests(client, map[string]string{"/src/api": "team/api"})
+	if err == nil || !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), "token configured for") {
+		t.Fatalf("syncGiteaPullRequests() error = %v, want 401 pointing at the configured token", err)
+	}
+}
+
+func TestGetGiteaPullRequests(t *testing.T) {
+	t.Parallel()
+
+	mine := giteaPR("aaa")
+	mine.Number = 1
+	mine.User.Login = "octocat"
+	mine.UpdatedAt = "2026-01-02T10:00:00Z"
+	theirs := giteaPR("")
+	theirs.Number = 2
+	theirs.User.Login = "someone"
+
+	routes := map[string]any{
+		"/repos/team/api/pulls": []giteaPullRequest{mine, theirs},
+		"/repos/team/api/commits/aaa/status": giteaCombinedStatus{
+			State: "failure",
+			Statuses: []giteaStatus{
+				{Status: "success"},
+				{Status: "pending"},
+				{Status: "failure"},
+				{Status: "error"},
+				{Status: "warning"},
+			},
+		},
+		"/user": map[string]string{"login": "octocat"},
+	}
+	client := newGiteaStandIn(t, routes, nil)
+
+	pullRequests, err := getGiteaPullRequests(client, "team", "api")
+	if err != nil {
+		t.Fatalf("getGiteaPullRequests() unexpected error: %v", err)
+	}
+	if len(pullRequests) != 2 {
+		t.Fatalf("getGiteaPullRequests() = %+v, want 2", pullRequests)
+	}
+
+	first := pullRequests[0]
+	if first.Number != 1 || !first.IsMine || first.UpdatedAt.IsZero() {
+		t.Errorf("first = %+v, want my pull request #1", first)
+	}
+	wantChecks := domain.PullRequestChecks{Total: 5, Passed: 1, Failed: 2, Skipped: 1, Waiting: 1}
+	if first.Checks != wantChecks {
+		t.Errorf("checks = %+v, want %+v", first.Checks, wantChecks)
+	}
+	if pullRequests[1].IsMine || pullRequests[1].Checks.Total != 0 {
+		t.Errorf("second = %+v, want someone else's without statuses", pullRequests[1])
+	}
+}
+
+func TestGetGiteaPullRequest(t *testing.T) {
+	t.Parallel()
+
+	pr := giteaPR("aaa")
+	pr.Number = 3
+	pr.Title = "Add retries"
+	pr.User.Login = "octocat"
+	pr.Head.Ref = "retries"
+	pr.Base.Ref = "main"
+	pr.Additions, pr.Deletions = 12, 4
+	pr.Labels = append(pr.Labels, struct {
+		Name string `json:"name"`
+	}{Name: "bug"})
+	pr.RequestedReviewers = append(pr.RequestedReviewers, struct {
+		Login string `json:"login"`
+	}{Login: "carol"})
+
+	approved := giteaReview{State: "APPROVED"}
+	approved.User.Login = "alice"
+	dismissed := giteaReview{State: "REQUEST_CHANGES", Dismissed: true}
+	dismissed.User.Login = "bob"
+	routes := map[string]any{
+		"/repos/team/api/pulls/3": pr,
+		"/repos/team/api/commits/aaa/status": giteaCombinedStatus{Statuses: []giteaStatus{
+			{Context: "ci/test", Status: "success", TargetURL: "https://ci.example.com/1", CreatedAt: "2026-01-02T10:00:00Z", UpdatedAt: "2026-01-02T10:02:00Z"},
+			{Context: "ci/lint", Status: "pending", CreatedAt: "2026-01-02T10:00:00Z", UpdatedAt: "2026-01-02T10:00:00Z"},
+		}},
+		"/repos/team/api/pulls/3/reviews": []giteaReview{approved, dismissed},
+	}
+	client := newGiteaStandIn(t, routes, nil)
+
+	state, err := client.pullRequestState("team", "api", 3)
+	if err != nil {
+		t.Fatalf("pullRequestState() unexpected error: %v", err)
+	}
+	got := giteaPullRequestDetail(state)
+
+	if got.Number != 3 || got.Author != "octocat" || got.HeadBranch != "retries" || got.BaseBranch != "main" {
+		t.Errorf("pull request = %+v, want #3 by octocat from retries into main", got)
+	}
+	if got.Mergeable != domain.MergeableClean || got.Additions != 12 || got.Deletions != 4 || len(got.Labels) != 1 {
+		t.Errorf("pull request = %+v, want mergeable +12 -4 labelled bug", got)
+	}
+	if len(got.Checks) != 2 || got.Checks[0].Status != domain.CheckPassed || got.Checks[0].Duration(time.Now()) != 2*time.Minute {
+		t.Errorf("checks = %+v, want ci/test passed in 2m first", got.Checks)
+	}
+	if got.Checks[1].Status != domain.CheckWaiting || !got.Checks[1].CompletedAt.IsZero() {
+		t.Errorf("checks[1] = %+v, want ci/lint still waiting", got.Checks[1])
+	}
+	wantReviewers := []domain.Reviewer{
+		{Login: "alice", State: domain.ReviewApproved},
+		{Login: "bob", State: domain.ReviewDismissed},
+		{Login: "carol", State: domain.ReviewPending},
+	}
+	if !reflect.DeepEqual(got.Reviewers, wantReviewers) {
+		t.Errorf("reviewers = %+v, want %+v", got.Reviewers, wantReviewers)
+	}
+}
+
+func TestClassifyGiteaPullRequest(t *testing.T) {
+	t.Parallel()
+
+	status := func(state string) giteaCombinedStatus {
+		return giteaCombinedStatus{State: state, Statuses: []giteaStatus{{Status: state}}}
+	}
+	review := func(login, state string) giteaReview {
+		r := giteaReview{State: state}
+		r.User.Login = login
+		return r
+	}
+	requested := giteaPR("aaa", func(pr *giteaPullRequest) {
+		pr.RequestedReviewers = append(pr.RequestedReviewers, struct {
+			Login string `json:"login"`
+		}{Login: "lead"})
+	})
+
+	tests := []struct {
+		name  string
+		state giteaPullRequestState
+		want  pullrequests.Status
+	}{
+		{name: "mergeable", state: giteaPullRequestState{pullRequest: giteaPR("aaa")}, want: pullrequests.StatusReady},
+		{name: "draft", state: giteaPullRequestState{pullRequest: giteaPR("aaa", func(pr *giteaPullRequest) { pr.Draft = true })}, want: pullrequests.StatusBlocked},
+		{name: "wip title", state: giteaPullRequestState{pullRequest: giteaPR("aaa", func(pr *giteaPullRequest) { pr.Title = "WIP: parser" })}, want: pullrequests.StatusBlocked},
+		{name: "conflicts", state: giteaPullRequestState{pullRequest: giteaPR("aaa", func(pr *giteaPullRequest) { pr.Mergeable = false })}, want: pullrequests.StatusBlocked},
+		{name: "failing status", state: giteaPullRequestState{pullRequest: giteaPR("aaa"), status: status("failure")}, want: pullrequests.StatusBlocked},
+		{name: "errored status", state: giteaPullRequestState{pullRequest: giteaPR("aaa"), status: status("error")}, want: pullrequests.StatusBlocked},
+		{name: "pending status", state: giteaPullRequestState{pullRequest: giteaPR("aaa"), status: status("pending")}, want: pullrequests.StatusChecks},
+		{name: "changes requested", state: giteaPullRequestState{pullRequest: giteaPR("aaa"), reviews: []giteaReview{review("lead", "REQUEST_CHANGES")}}, want: pullrequests.StatusBlocked},
+		{name: "changes requested then approved", state: giteaPullRequestState{pullRequest: giteaPR("aaa"), reviews: []giteaReview{review("lead", "REQUEST_CHANGES"), review("lead", "APPROVED")}}, want: pullrequests.StatusReady},
+		{name: "dismissed request for changes", state: giteaPullRequestState{pullRequest: giteaPR("aaa"), reviews: []giteaReview{{State: "REQUEST_CHANGES", Dismissed: true}}}, want: pullrequests.StatusReady},
+		{name: "pending status beats approval", state: giteaPullRequestState{pullRequest: giteaPR("aaa"), status: status("pending"), reviews: []giteaReview{review("lead", "APPROVED")}}, want: pullrequests.StatusChecks},
+		{name: "awaiting reviewer", state: giteaPullRequestState{pullRequest: requested, status: status("success")}, want: pullrequests.StatusReview},
+		{name: "approved with reviewer pending", state: giteaPullRequestState{pullRequest: requested, reviews: []giteaReview{review("lead", "APPROVED")}}, want: pullrequests.StatusReady},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := classifyGiteaPullRequest(tt.state); got != tt.want {
+				t.Errorf("classifyGiteaPullRequest() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package git
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fresh/internal/config"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GitHub token environment variables, read in order, as gh does. The
+// enterprise ones apply to every host but github.com.
+var (
+	gitHubTokenEnvs           = []string{"GH_TOKEN", "GITHUB_TOKEN"}
+	gitHubEnterpriseTokenEnvs = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+)
+
+var errGitHubTokenMissing = errors.New("github: no token (set github.token, or an Enterprise host's token under github.instances, in the configuration file, set GH_TOKEN, or run `gh auth login`)")
+
+// gitHubResponses is shared by every GitHub client so conditional requests
+// keep working across syncs.
+var gitHubResponses etagCache
+
+// gitHubClient calls the GitHub REST and GraphQL APIs of one host without
+// going through gh.
+type gitHubClient struct {
+	restClient
+	// graphqlAPI posts to the GraphQL endpoint, which is not under the
+	// REST base URL on GitHub Enterprise Server.
+	graphqlAPI restClient
+	host       string
+}
+
+type gitHubSearchIssues struct {
+	TotalCount        int  `json:"total_count"`
+	IncompleteResults bool `json:"incomplete_results"`
+	Items             []struct {
+		RepositoryURL string `json:"repository_url"`
+	} `json:"items"`
+}
+
+type gqlRepositoryPullRequestsData struct {
+	Repository *struct {
+		PullRequests struct {
+			Nodes []gqlRepositoryPullRequestNode `json:"nodes"`
+		} `json:"pullRequests"`
+	} `json:"repository"`
+}
+
+type gqlRepositoryPullRequestNode struct {
+	Number    int    `json:"number"`
+	Title     string `json:"title"`
+	UpdatedAt string `json:"updatedAt"`
+	Author    *struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer *ghUser `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *gqlStatusCheckRollup `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+// repositoryPullRequestsQuery fetches the fields gh pr list is asked for
+// when listing a repository's pull requests.
+const repositoryPullRequestsQuery = `
+query($owner: String!, $name: String!) {
+  rateLimit {
+    limit
+    remaining
+    resetAt
+  }
+  repository(owner: $owner, name: $name) {
+    pullRequests(states: OPEN, first: 100, orderBy: {field: UPDATED_AT, direction: DESC}) {
+      nodes {
+        number
+        title
+        updatedAt
+        author {
+          login
+        }
+        reviewRequests(first: 20) {
+          nodes {
+            requestedReviewer {
+              ... on User {
+                login
+              }
+            }
+          }
+        }
+        commits(last: 1) {
+          nodes {
+            commit {
+              statusCheckRollup {
+                state
+                contexts(first: 100) {
+                  nodes {
+                    ... on CheckRun {
+                      conclusion
+                      status
+                    }
+                    ... on StatusContext {
+                      state
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+// gitHubAPIURLs returns the REST base URL and the GraphQL endpoint of host.
+func gitHubAPIURLs(host string) (string, string) {
+	if strings.EqualFold(host, defaultGitHubHost) {
+		return "https://api.github.com", "https://api.github.com/graphql"
+	}
+	return "https://" + host + "/api/v3", "https://" + host + "/api/graphql"
+}
+
+func newGitHubClient(cfg config.GitHubConfig, host string) (*gitHubClient, error) {
+	token := gitHubToken(cfg, host)
+	if token == "" {
+		return nil, errGitHubTokenMissing
+	}
+	baseURL, graphqlURL := gitHubAPIURLs(host)
+	return newGitHubClientAt(host, baseURL, graphqlURL, token, newAPIHTTPClient(defaultConfig.Timeout.Default)), nil
+}
+
+func newGitHubClientAt(host string, baseURL string, graphqlURL string, token string, client *http.Client) *gitHubClient {
+	rest := restClient{
+		name:       "github",
+		baseURL:    baseURL,
+		authHeader: "Authorization",
+		authValue:  "Bearer " + token,
+		tokenHint:  "github.token, GH_TOKEN or `gh auth status`",
+		http:       client,
+		etags:      &gitHubResponses,
+		onResponse: func(header http.Header) {
+			gitHubRateLimits.recordHeaders(host, header)
+		},
+	}
+	// GraphQL queries are POSTs, which GitHub never answers with 304.
+	graphqlAPI := rest
+	graphqlAPI.baseURL = graphqlURL
+	graphqlAPI.etags = nil
+	return &gitHubClient{restClient: rest, graphqlAPI: graphqlAPI, host: host}
+}
+
+// gitHubToken returns the token configured for host, then one from the
+// environment, then the one gh has stored for host.
+func gitHubToken(cfg config.GitHubConfig, host string) string {
+	token, envs, ok := configuredGitHubToken(cfg, host)
+	if !ok {
+		return ""
+	}
+	if token != "" {
+		return token
+	}
+	for _, env := range envs {
+		if token := strings.TrimSpace(os.Getenv(env)); token != "" {
+			return token
+		}
+	}
+
+	cmd := createCommand(defaultConfig.Timeout.Default, "gh", "auth", "token", "--hostname", host)
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// configuredGitHubToken returns the token cfg sets for host and the
+// environment variables to read when it sets none. Hosts cfg does not list
+// get neither, so a credential never reaches a host that only looks like
+// GitHub.
+func configuredGitHubToken(cfg config.GitHubConfig, host string) (string, []string, bool) {
+	if !slices.ContainsFunc(cfg.Hosts, func(configured string) bool { return strings.EqualFold(configured, host) }) {
+		return "", nil, false
+	}
+	if strings.EqualFold(host, defaultGitHubHost) {
+		return strings.TrimSpace(cfg.Token), gitHubTokenEnvs, true
+	}
+	instance, _ := findInstance(cfg.Instances, host)
+	return instance.Token, gitHubEnterpriseTokenEnvs, true
+}
+
+// graphql is the client's graphqlRunner.
+func (c *gitHubClient) graphql(query string, variables map[string]string, out any) error {
+	request := map[string]any{"query": query, "variables": variables}
+	var body json.RawMessage
+	if _, err := c.graphqlAPI.post("", request, &body); err != nil {
+		return err
+	}
+	gitHubRateLimits.recordGraphQL(c.host, body)
+	return decodeGraphQL(body, out)
+}
+
+// openPullRequestCounts counts the open pull requests of ownerRepos through
+// the REST search, whose pages can be answered with 304 Not Modified.
+// Counts are keyed by the slugs as given; partial holds the repositories
+// whose count may be too low.
+func (c *gitHubClient) openPullRequestCounts(ownerRepos []string) (map[string]int, map[string]bool, error) {
+	lookup := slugLookup(ownerRepos)
+	counts := make(map[string]int)
+	partial := make(map[string]bool)
+	for _, batch := range batchSearches("is:pr is:open", ownerRepos) {
+		fetched := 0
+		for page := 1; ; page++ {
+			query := url.Values{
+				"q":        {batch.query},
+				"per_page": {strconv.Itoa(searchPageSize)},
+				"page":     {strconv.Itoa(page)},
+			}
+			var result gitHubSearchIssues
+			if _, err := c.get("/search/issues", query, &result); err != nil {
+				return nil, nil, err
+			}
+			for _, item := range result.Items {
+				parts := strings.Split(strings.TrimSuffix(item.RepositoryURL, "/"), "/")
+				if len(parts) < 2 {
+					continue
+				}
+				if ownerRepo, ok := lookup[strings.ToLower(parts[len(parts)-2]+"/"+parts[len(parts)-1])]; ok {
+					counts[ownerRepo]++
+				}
+			}
+			fetched += len(result.Items)
+
+			if len(result.Items) < searchPageSize || fetched >= result.TotalCount {
+				markPartial(partial, batch.ownerRepos, result.IncompleteResults || fetched < result.TotalCount)
+				break
+			}
+			if fetched >= maxSearchResults {
+				markPartial(partial, batch.ownerRepos, true)
+				break
+			}
+		}
+	}
+	return counts, partial, nil
+}
+
+// repositoryPullRequests returns the open pull requests of owner/repo in
+// the shape gh pr list prints them.
+func (c *gitHubClient) repositoryPullRequests(owner, repo string) ([]ghRepoPullRequestRow, error) {
+	var data gqlRepositoryPullRequestsData
+	variables := map[string]string{"owner": owner, "name": repo}
+	if err := c.graphql(repositoryPullRequestsQuery, variables, &data); err != nil {
+		return nil, err
+	}
+	if data.Repository == nil {
+		return nil, fmt.Errorf("github: repository %s/%s not found", owner, repo)
+	}
+
+	nodes := data.Repository.PullRequests.Nodes
+	rows := make([]ghRepoPullRequestRow, 0, len(nodes))
+	for _, node := range nodes {
+		row := ghRepoPullRequestRow{Number: node.Number, Title: node.Title, UpdatedAt: node.UpdatedAt}
+		if node.Author != nil {
+			row.Author.Login = node.Author.Login
+		}
+		for _, request := range node.ReviewRequests.Nodes {
+			if request.RequestedReviewer != nil {
+				row.ReviewRequests = append(row.ReviewRequests, *request.RequestedReviewer)
+			}
+		}
+		if len(node.Commits.Nodes) > 0 && node.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+			for _, context := range node.Commits.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes {
+				row.StatusCheckRollup = append(row.StatusCheckRollup, ghStatusCheckContext(context))
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func (c *gitHubClient) currentUser() string {
+	return cachedGitHubLogins.forHost(c.host).get(time.Now(), githubLoginCacheTTL, func() (string, error) {
+		var user struct {
+			Login string `json:"login"`
+		}
+		_, err := c.get("/user", nil, &user)
+		return user.Login, err
+	})
+}
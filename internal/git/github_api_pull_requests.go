@@ -0,0 +1,70 @@
+package git
+
+import (
+	"fresh/internal/config"
+	"fresh/internal/domain"
+	"fresh/internal/pullrequests"
+	"strconv"
+	"time"
+)
+
+// GitHubAPIService fills the pull request columns for repositories hosted
+// on GitHub by calling its API directly, for machines without gh or when
+// spawning gh for every query is too slow.
+type GitHubAPIService struct {
+	// Config lists the GitHub hosts and their tokens.
+	Config *config.Config
+}
+
+func (GitHubAPIService) Name() string { return "github" }
+
+func (s GitHubAPIService) Match(remoteURL string) (RemoteLocation, bool) {
+	return parseGitHubRemote(s.Config, remoteURL)
+}
+
+func (s GitHubAPIService) SyncPullRequests(host string, reposByPath map[string]RemoteLocation) (map[string]domain.PullRequestState, []pullrequests.Snapshot, error) {
+	client, err := newGitHubClient(s.Config.GitHub, host)
+	if err != nil {
+		return nil, nil, err
+	}
+	return syncGitHubPullRequests(client, reposByPath)
+}
+
+func (s GitHubAPIService) ListPullRequests(location RemoteLocation) ([]domain.PullRequestDetails, error) {
+	client, err := newGitHubClient(s.Config.GitHub, location.Host)
+	if err != nil {
+		return nil, err
+	}
+	return getGitHubPullRequests(client, location)
+}
+
+func (GitHubAPIService) RateLimit(host string) (RateLimit, bool) {
+	return gitHubRateLimits.current(host, time.Now())
+}
+
+func (GitHubAPIService) PullRequestURL(location RemoteLocation, number int) string {
+	return location.WebURL() + "/pull/" + strconv.Itoa(number)
+}
+
+func (s GitHubAPIService) CurrentUser(host string) string {
+	client, err := newGitHubClient(s.Config.GitHub, host)
+	if err != nil {
+		return ""
+	}
+	return client.currentUser()
+}
+
+// syncGitHubPullRequests makes the same searches as GhPullRequestService,
+// classifying the user's pull requests the same way, but counts open pull
+// requests through REST so unchanged pages cost no rate limit.
+func syncGitHubPullRequests(client *gitHubClient, reposByPath map[string]RemoteLocation) (map[string]domain.PullRequestState, []pullrequests.Snapshot, error) {
+	return syncGitHubHost(client.host, reposByPath, client.openPullRequestCounts, client.graphql)
+}
+
+func getGitHubPullRequests(client *gitHubClient, location RemoteLocation) ([]domain.PullRequestDetails, error) {
+	rows, err := client.repositoryPullRequests(location.Owner, location.Name)
+	if err != nil {
+		return nil, err
+	}
+	return gitHubPullRequestDetails(rows, client.currentUser()), nil
+}
@@ -0,0 +1,342 @@
+package git
+
+import (
+	"encoding/json"
+	"fresh/internal/config"
+	"fresh/internal/domain"
+	"fresh/internal/pullrequests"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// gitHubStandIn answers REST routes by path with an ETag, honouring
+// If-None-Match, and GraphQL queries by a qualifier of the search they
+// make, such as "review-requested:@me", or else by the first root field
+// they select.
+type gitHubStandIn struct {
+	client      *gitHubClient
+	notModified atomic.Int32
+
+	mu       sync.Mutex
+	searches []string
+}
+
+func newGitHubStandIn(t *testing.T, routes map[string]any, graphql map[string]any) *gitHubStandIn {
+	t.Helper()
+
+	standIn := &gitHubStandIn{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Bad credentials"}`))
+			return
+		}
+
+		if r.Method == http.MethodPost && r.URL.Path == "/graphql" {
+			var request struct {
+				Query     string         `json:"query"`
+				Variables map[string]any `json:"variables"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&request)
+			search, _ := request.Variables["q"].(string)
+			if search != "" {
+				standIn.mu.Lock()
+				standIn.searches = append(standIn.searches, search)
+				standIn.mu.Unlock()
+			}
+			for qualifier, body := range graphql {
+				if strings.Contains(qualifier, ":") && strings.Contains(search, qualifier) {
+					_ = json.NewEncoder(w).Encode(body)
+					return
+				}
+			}
+			for field, body := range graphql {
+				if strings.Contains(request.Query, field+"(") {
+					_ = json.NewEncoder(w).Encode(body)
+					return
+				}
+			}
+			_, _ = w.Write([]byte(`{"errors":[{"message":"unexpected query"}]}`))
+			return
+		}
+
+		body, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+			return
+		}
+		etag := `"` + r.URL.RequestURI() + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			standIn.notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+
+	standIn.client = newGitHubClientAt(strings.TrimPrefix(server.URL, "http://"), server.URL, server.URL+"/graphql", "secret", server.Client())
+	return standIn
+}
+
+func gitHubSearchItems(repositoryURLs ...string) map[string]any {
+	items := make([]map[string]string, 0, len(repositoryURLs))
+	for _, repositoryURL := range repositoryURLs {
+		items = append(items, map[string]string{"repository_url": repositoryURL})
+	}
+	return map[string]any{"total_count": len(items), "items": items}
+}
+
+func TestSyncGitHubPullRequests(t *testing.T) {
+	t.Parallel()
+
+	routes := map[string]any{
+		"/search/issues": gitHubSearchItems(
+			"https://api.github.com/repos/owner/api",
+			"https://api.github.com/repos/owner/api",
+			"https://api.github.com/repos/Owner/Web",
+			"https://api.github.com/repos/other/unscanned",
+		),
+	}
+	graphql := map[string]any{
+		"search": map[string]any{"data": map[string]any{"search": map[string]any{"nodes": []map[string]any{
+			{"repository": map[string]string{"nameWithOwner": "owner/api"}, "number": 1, "title": "Ready", "reviewDecision": "APPROVED"},
+			{"repository": map[string]string{"nameWithOwner": "owner/api"}, "number": 2, "title": "Draft", "isDraft": true},
+		}}}},
+		"review-requested:@me": map[string]any{"data": map[string]any{"search": map[string]any{"nodes": []map[string]any{
+			{"repository": map[string]string{"nameWithOwner": "Owner/Web"}, "number": 7, "title": "Add retries"},
+		}}}},
+	}
+	standIn := newGitHubStandIn(t, routes, graphql)
+
+	states, tracked, err := syncGitHubPullRequests(standIn.client, map[string]RemoteLocation{
+		"/src/api": {Host: "github.com", Owner: "owner", Name: "api"},
+		"/src/web": {Host: "github.com", Owner: "owner", Name: "web"},
+	})
+	if err != nil {
+		t.Fatalf("syncGitHubPullRequests() unexpected error: %v", err)
+	}
+
+	wantAPI := domain.PullRequestCount{Open: 2, MyOpen: 2, MyReady: 1, MyBlocked: 1}
+	if states["/src/api"] != wantAPI {
+		t.Errorf("api state = %+v, want %+v", states["/src/api"], wantAPI)
+	}
+	if want := (domain.PullRequestCount{Open: 1, ReviewRequested: 1}); states["/src/web"] != want {
+		t.Errorf("web state = %+v, want %+v", states["/src/web"], want)
+	}
+
+	if len(tracked) != 3 || tracked[0].Key != (pullrequests.Key{Owner: "owner", Repo: "api", Number: 1}) || tracked[0].Status != pullrequests.StatusReady {
+		t.Errorf("tracked = %+v, want #1 ready, #2 and the review request", tracked)
+	}
+	if review := tracked[len(tracked)-1]; review.Key != (pullrequests.Key{Owner: "Owner", Repo: "Web", Number: 7}) || review.Status != pullrequests.StatusReviewRequested {
+		t.Errorf("review request = %+v, want Owner/Web#7", review)
+	}
+	want := []string{
+		"is:pr is:open author:@me repo:owner/api repo:owner/web",
+		"is:pr is:open review-requested:@me repo:owner/api repo:owner/web",
+	}
+	if len(standIn.searches) != 2 || standIn.searches[0] != want[0] || standIn.searches[1] != want[1] {
+		t.Errorf("searches = %q, want %q", standIn.searches, want)
+	}
+}
+
+func TestGitHubClientMakesConditionalRequests(t *testing.T) {
+	t.Parallel()
+
+	routes := map[string]any{
+		"/search/issues": gitHubSearchItems("https://api.github.com/repos/owner/api"),
+	}
+	standIn := newGitHubStandIn(t, routes, nil)
+
+	for i := range 2 {
+		counts, partial, err := standIn.client.openPullRequestCounts([]string{"owner/api"})
+		if err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+		if counts["owner/api"] != 1 || partial["owner/api"] {
+			t.Errorf("request %d: counts = %v, partial = %v, want owner/api: 1 complete", i, counts, partial)
+		}
+	}
+	if got := standIn.notModified.Load(); got != 1 {
+		t.Errorf("304 responses = %d, want the repeat request answered from the cache", got)
+	}
+}
+
+func TestGetGitHubPullRequests(t *testing.T) {
+	t.Parallel()
+
+	node := func(number int, author string, contexts ...map[string]string) map[string]any {
+		return map[string]any{
+			"number":    number,
+			"title":     "Change",
+			"updatedAt": "2026-01-02T10:00:00Z",
+			"author":    map[string]string{"login": author},
+			"commits": map[string]any{"nodes": []map[string]any{{"commit": map[string]any{
+				"statusCheckRollup": map[string]any{"contexts": map[string]any{"nodes": contexts}},
+			}}}},
+		}
+	}
+	reviewed := node(2, "someone")
+	reviewed["reviewRequests"] = map[string]any{"nodes": []map[string]any{
+		{"requestedReviewer": map[string]string{"login": "octocat"}},
+		{"requestedReviewer": nil},
+	}}
+	graphql := map[string]any{
+		"repository": map[string]any{"data": map[string]any{"repository": map[string]any{"pullRequests": map[string]any{"nodes": []map[string]any{
+			node(1, "octocat",
+				map[string]string{"conclusion": "SUCCESS", "status": "COMPLETED"},
+				map[string]string{"status": "IN_PROGRESS"},
+				map[string]string{"state": "FAILURE"},
+			),
+			reviewed,
+		}}}}},
+	}
+	routes := map[string]any{"/user": map[string]string{"login": "octocat"}}
+	standIn := newGitHubStandIn(t, routes, graphql)
+
+	pullRequests, err := getGitHubPullRequests(standIn.client, RemoteLocation{Host: "github.com", Owner: "owner", Name: "api"})
+	if err != nil {
+		t.Fatalf("getGitHubPullRequests() unexpected error: %v", err)
+	}
+	if len(pullRequests) != 2 {
+		t.Fatalf("getGitHubPullRequests() = %+v, want 2", pullRequests)
+	}
+	want := domain.PullRequestChecks{Total: 3, Passed: 1, Running: 1, Failed: 1}
+	if !pullRequests[0].IsMine || pullRequests[0].Checks != want {
+		t.Errorf("first = %+v, want mine with checks %+v", pullRequests[0], want)
+	}
+	if pullRequests[1].IsMine || !pullRequests[1].ReviewRequested || pullRequests[1].Checks.Total != 0 {
+		t.Errorf("second = %+v, want someone else's awaiting review without checks", pullRequests[1])
+	}
+}
+
+func TestGetGitHubPullRequest(t *testing.T) {
+	t.Parallel()
+
+	graphql := map[string]any{
+		"pullRequest": map[string]any{"data": map[string]any{"repository": map[string]any{"pullRequest": map[string]any{
+			"number":      7,
+			"title":       "Add retries",
+			"author":      map[string]string{"login": "octocat"},
+			"headRefName": "retries",
+			"baseRefName": "main",
+			"mergeable":   "MERGEABLE",
+			"additions":   10,
+			"deletions":   2,
+			"labels":      map[string]any{"nodes": []map[string]string{{"name": "bug"}}},
+			"reviews": map[string]any{"nodes": []map[string]any{
+				{"author": map[string]string{"login": "alice"}, "state": "APPROVED"},
+			}},
+			"reviewRequests": map[string]any{"nodes": []map[string]any{
+				{"requestedReviewer": map[string]string{"login": "bob"}},
+				{"requestedReviewer": nil},
+			}},
+			"commits": map[string]any{"nodes": []map[string]any{{"commit": map[string]any{
+				"statusCheckRollup": map[string]any{"contexts": map[string]any{"nodes": []map[string]string{
+					{"name": "test", "conclusion": "FAILURE", "status": "COMPLETED", "detailsUrl": "https://github.com/owner/api/runs/1"},
+					{"context": "ci/deploy", "state": "PENDING", "targetUrl": "https://ci.example.com/9"},
+				}}},
+			}}}},
+		}}}},
+	}
+	standIn := newGitHubStandIn(t, nil, graphql)
+
+	view, err := standIn.client.pullRequest("owner", "api", 7)
+	if err != nil {
+		t.Fatalf("pullRequest() unexpected error: %v", err)
+	}
+	pr := gitHubPullRequest(view)
+
+	if pr.Number != 7 || pr.Mergeable != domain.MergeableClean || pr.Additions != 10 || pr.Deletions != 2 || len(pr.Labels) != 1 {
+		t.Errorf("pull request = %+v, want #7 mergeable +10 -2 labelled bug", pr)
+	}
+	if len(pr.Checks) != 2 || pr.Checks[0].Status != domain.CheckFailed || pr.Checks[1].Name != "ci/deploy" || pr.Checks[1].URL != "https://ci.example.com/9" {
+		t.Errorf("checks = %+v, want failed test then pending ci/deploy", pr.Checks)
+	}
+	wantReviewers := []domain.Reviewer{{Login: "alice", State: domain.ReviewApproved}, {Login: "bob", State: domain.ReviewPending}}
+	if len(pr.Reviewers) != 2 || pr.Reviewers[0] != wantReviewers[0] || pr.Reviewers[1] != wantReviewers[1] {
+		t.Errorf("reviewers = %+v, want %+v", pr.Reviewers, wantReviewers)
+	}
+
+	missing := newGitHubStandIn(t, nil, map[string]any{
+		"pullRequest": map[string]any{"data": map[string]any{"repository": map[string]any{"pullRequest": nil}}},
+	})
+	if _, err := missing.client.pullRequest("owner", "api", 8); err == nil || !strings.Contains(err.Error(), "#8 not found") {
+		t.Errorf("pullRequest() error = %v, want not found", err)
+	}
+}
+
+func TestGitHubClientReportsErrors(t *testing.T) {
+	t.Parallel()
+
+	standIn := newGitHubStandIn(t, nil, map[string]any{
+		"repository": map[string]any{"data": map[string]any{"repository": nil}, "errors": []map[string]string{{"message": "Could not resolve to a Repository"}}},
+	})
+
+	_, err := getGitHubPullRequests(standIn.client, RemoteLocation{Owner: "owner", Name: "missing"})
+	if err == nil || !strings.Contains(err.Error(), "Could not resolve") {
+		t.Errorf("GraphQL error = %v, want the message GitHub returned", err)
+	}
+
+	standIn.client.authValue = "Bearer wrong"
+	_, _, err = standIn.client.openPullRequestCounts([]string{"owner/api"})
+	if err == nil || !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), "GH_TOKEN") {
+		t.Errorf("REST error = %v, want 401 pointing at the token", err)
+	}
+}
+
+func TestGitHubClientFlagsIncompleteCounts(t *testing.T) {
+	t.Parallel()
+
+	truncated := gitHubSearchItems("https://api.github.com/repos/owner/api")
+	truncated["total_count"] = 5
+	standIn := newGitHubStandIn(t, map[string]any{"/search/issues": truncated}, nil)
+
+	counts, partial, err := standIn.client.openPullRequestCounts([]string{"owner/api"})
+	if err != nil {
+		t.Fatalf("openPullRequestCounts() unexpected error: %v", err)
+	}
+	if counts["owner/api"] != 1 || !partial["owner/api"] {
+		t.Errorf("counts = %v, partial = %v, want 1 flagged partial", counts, partial)
+	}
+}
+
+func TestConfiguredGitHubTokenStaysOnItsHost(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.GitHubConfig{
+		Hosts:     []string{"github.com", "ghe.acme.corp", "ghe.other.corp"},
+		Token:     "dotcom-secret",
+		Instances: []config.Instance{{Host: "ghe.acme.corp", Token: "acme-secret"}},
+	}
+
+	tests := []struct {
+		host      string
+		wantToken string
+		wantEnvs  []string
+		wantOK    bool
+	}{
+		{host: "github.com", wantToken: "dotcom-secret", wantEnvs: gitHubTokenEnvs, wantOK: true},
+		{host: "GHE.acme.corp", wantToken: "acme-secret", wantEnvs: gitHubEnterpriseTokenEnvs, wantOK: true},
+		{host: "ghe.other.corp", wantEnvs: gitHubEnterpriseTokenEnvs, wantOK: true},
+		{host: "github.evil.example"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			t.Parallel()
+
+			token, envs, ok := configuredGitHubToken(cfg, tt.host)
+			if token != tt.wantToken || !slices.Equal(envs, tt.wantEnvs) || ok != tt.wantOK {
+				t.Errorf("configuredGitHubToken(%q) = %q, %v, %v, want %q, %v, %v", tt.host, token, envs, ok, tt.wantToken, tt.wantEnvs, tt.wantOK)
+			}
+		})
+	}
+}
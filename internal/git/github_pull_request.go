@@ -0,0 +1,302 @@
+package git
+
+import (
+	"encoding/json"
+	"fmt"
+	"fresh/internal/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ghPullRequestView is one pull request as gh pr view prints it.
+type ghPullRequestView struct {
+	Number            int             `json:"number"`
+	Title             string          `json:"title"`
+	URL               string          `json:"url"`
+	Author            ghUser          `json:"author"`
+	HeadRefName       string          `json:"headRefName"`
+	BaseRefName       string          `json:"baseRefName"`
+	IsDraft           bool            `json:"isDraft"`
+	Mergeable         string          `json:"mergeable"`
+	Additions         int             `json:"additions"`
+	Deletions         int             `json:"deletions"`
+	Labels            []ghLabel       `json:"labels"`
+	StatusCheckRollup []ghCheckDetail `json:"statusCheckRollup"`
+	Reviews           []ghReview      `json:"reviews"`
+	ReviewRequests    []ghUser        `json:"reviewRequests"`
+}
+
+type ghLabel struct {
+	Name string `json:"name"`
+}
+
+type ghReview struct {
+	Author ghUser `json:"author"`
+	State  string `json:"state"`
+}
+
+// ghCheckDetail is a check run or, with Context and TargetURL set instead
+// of Name and DetailsURL, a commit status.
+type ghCheckDetail struct {
+	Name        string `json:"name"`
+	Context     string `json:"context"`
+	Conclusion  string `json:"conclusion"`
+	Status      string `json:"status"`
+	State       string `json:"state"`
+	StartedAt   string `json:"startedAt"`
+	CompletedAt string `json:"completedAt"`
+	DetailsURL  string `json:"detailsUrl"`
+	TargetURL   string `json:"targetUrl"`
+}
+
+type gqlPullRequestData struct {
+	Repository *struct {
+		PullRequest *gqlPullRequestViewNode `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+type gqlPullRequestViewNode struct {
+	ghPullRequestView
+	Labels struct {
+		Nodes []ghLabel `json:"nodes"`
+	} `json:"labels"`
+	Reviews struct {
+		Nodes []ghReview `json:"nodes"`
+	} `json:"reviews"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer *ghUser `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					Contexts struct {
+						Nodes []ghCheckDetail `json:"nodes"`
+					} `json:"contexts"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+// ghPullRequestViewFields are the fields gh pr view is asked for.
+const ghPullRequestViewFields = "number,title,url,author,headRefName,baseRefName,isDraft,mergeable,additions,deletions,labels,statusCheckRollup,reviews,reviewRequests"
+
+// pullRequestQuery fetches the fields gh pr view is asked for, aliased to
+// the names gh prints. The number is formatted in, since runners only pass
+// string variables.
+const pullRequestQuery = `
+query($owner: String!, $name: String!) {
+  rateLimit {
+    limit
+    remaining
+    resetAt
+  }
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: %d) {
+      number
+      title
+      url
+      author {
+        login
+      }
+      headRefName
+      baseRefName
+      isDraft
+      mergeable
+      additions
+      deletions
+      labels(first: 50) {
+        nodes {
+          name
+        }
+      }
+      reviews(last: 100) {
+        nodes {
+          author {
+            login
+          }
+          state
+        }
+      }
+      reviewRequests(first: 50) {
+        nodes {
+          requestedReviewer {
+            ... on User {
+              login
+            }
+            ... on Team {
+              name
+            }
+          }
+        }
+      }
+      commits(last: 1) {
+        nodes {
+          commit {
+            statusCheckRollup {
+              contexts(first: 100) {
+                nodes {
+                  ... on CheckRun {
+                    name
+                    conclusion
+                    status
+                    startedAt
+                    completedAt
+                    detailsUrl
+                  }
+                  ... on StatusContext {
+                    context
+                    state
+                    startedAt: createdAt
+                    targetUrl
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`
+
+// GetPullRequest shows one pull request through gh pr view.
+func (GhPullRequestService) GetPullRequest(location RemoteLocation, number int) (domain.PullRequest, error) {
+	ownerRepo := location.Slug()
+	if !strings.EqualFold(location.Host, defaultGitHubHost) {
+		ownerRepo = location.Host + "/" + ownerRepo
+	}
+	cmd := ghCommand(location.Host, "pr", "view", strconv.Itoa(number), "--repo", ownerRepo, "--json", ghPullRequestViewFields)
+	output, err := cmd.Output()
+	if err != nil {
+		return domain.PullRequest{}, normalizeGhError(err)
+	}
+
+	var view ghPullRequestView
+	if err := json.Unmarshal(output, &view); err != nil {
+		return domain.PullRequest{}, err
+	}
+	return gitHubPullRequest(view), nil
+}
+
+func (s GitHubAPIService) GetPullRequest(location RemoteLocation, number int) (domain.PullRequest, error) {
+	client, err := newGitHubClient(s.Config.GitHub, location.Host)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	view, err := client.pullRequest(location.Owner, location.Name, number)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	return gitHubPullRequest(view), nil
+}
+
+// pullRequest returns one pull request of owner/repo in the shape gh pr
+// view prints it.
+func (c *gitHubClient) pullRequest(owner, repo string, number int) (ghPullRequestView, error) {
+	var data gqlPullRequestData
+	variables := map[string]string{"owner": owner, "name": repo}
+	if err := c.graphql(fmt.Sprintf(pullRequestQuery, number), variables, &data); err != nil {
+		return ghPullRequestView{}, err
+	}
+	if data.Repository == nil || data.Repository.PullRequest == nil {
+		return ghPullRequestView{}, fmt.Errorf("github: pull request %s/%s#%d not found", owner, repo, number)
+	}
+
+	node := data.Repository.PullRequest
+	view := node.ghPullRequestView
+	view.Labels = node.Labels.Nodes
+	view.Reviews = node.Reviews.Nodes
+	view.ReviewRequests = nil
+	for _, request := range node.ReviewRequests.Nodes {
+		if request.RequestedReviewer != nil {
+			view.ReviewRequests = append(view.ReviewRequests, *request.RequestedReviewer)
+		}
+	}
+	if len(node.Commits.Nodes) > 0 && node.Commits.Nodes[0].Commit.StatusCheckRollup != nil {
+		view.StatusCheckRollup = node.Commits.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes
+	}
+	return view, nil
+}
+
+func gitHubPullRequest(view ghPullRequestView) domain.PullRequest {
+	pullRequest := domain.PullRequest{
+		Number:     view.Number,
+		Title:      view.Title,
+		URL:        view.URL,
+		Author:     view.Author.Login,
+		HeadBranch: view.HeadRefName,
+		BaseBranch: view.BaseRefName,
+		Draft:      view.IsDraft,
+		Additions:  view.Additions,
+		Deletions:  view.Deletions,
+	}
+	switch strings.ToUpper(view.Mergeable) {
+	case "MERGEABLE":
+		pullRequest.Mergeable = domain.MergeableClean
+	case "CONFLICTING":
+		pullRequest.Mergeable = domain.MergeableConflicting
+	}
+	for _, label := range view.Labels {
+		pullRequest.Labels = append(pullRequest.Labels, label.Name)
+	}
+
+	for _, check := range view.StatusCheckRollup {
+		run := domain.CheckRun{
+			Name:        check.Name,
+			Status:      checkStatusOf(classifyCheckSummary(check.Conclusion, check.Status, check.State)),
+			StartedAt:   parseAPITime(check.StartedAt),
+			CompletedAt: parseAPITime(check.CompletedAt),
+			URL:         check.DetailsURL,
+		}
+		if run.Name == "" {
+			run.Name = check.Context
+			run.URL = check.TargetURL
+		}
+		pullRequest.Checks = append(pullRequest.Checks, run)
+	}
+
+	reviews := make([]domain.Reviewer, 0, len(view.Reviews))
+	for _, review := range view.Reviews {
+		reviews = append(reviews, domain.Reviewer{Login: review.Author.Login, State: gitHubReviewState(review.State)})
+	}
+	var requested []string
+	for _, request := range view.ReviewRequests {
+		requested = append(requested, request.displayName())
+	}
+	pullRequest.Reviewers = foldReviewers(reviews, requested)
+	return pullRequest
+}
+
+func gitHubReviewState(state string) domain.ReviewState {
+	switch strings.ToUpper(strings.TrimSpace(state)) {
+	case "APPROVED":
+		return domain.ReviewApproved
+	case "CHANGES_REQUESTED":
+		return domain.ReviewChangesRequested
+	case "DISMISSED":
+		return domain.ReviewDismissed
+	case "PENDING":
+		return domain.ReviewPending
+	default:
+		return domain.ReviewCommented
+	}
+}
+
+// displayName is the user's login, or the name of a requested team.
+func (u ghUser) displayName() string {
+	if u.Login != "" {
+		return u.Login
+	}
+	return u.Name
+}
+
+func parseAPITime(value string) time.Time {
+	parsed, _ := time.Parse(time.RFC3339, value)
+	return parsed
+}
@@ -0,0 +1,126 @@
+package git
+
+import (
+	"encoding/json"
+	"fresh/internal/domain"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGitHubPullRequest(t *testing.T) {
+	t.Parallel()
+
+	output := `{
+		"number": 7,
+		"title": "Add retries",
+		"url": "https://github.com/owner/api/pull/7",
+		"author": {"login": "octocat"},
+		"headRefName": "retries",
+		"baseRefName": "main",
+		"isDraft": false,
+		"mergeable": "CONFLICTING",
+		"additions": 120,
+		"deletions": 8,
+		"labels": [{"name": "bug"}, {"name": "backend"}],
+		"statusCheckRollup": [
+			{"__typename": "CheckRun", "name": "test", "conclusion": "SUCCESS", "status": "COMPLETED", "startedAt": "2026-01-02T10:00:00Z", "completedAt": "2026-01-02T10:03:30Z", "detailsUrl": "https://github.com/owner/api/runs/1"},
+			{"__typename": "CheckRun", "name": "lint", "conclusion": "", "status": "IN_PROGRESS", "startedAt": "2026-01-02T10:00:00Z"},
+			{"__typename": "StatusContext", "context": "ci/deploy", "state": "FAILURE", "startedAt": "2026-01-02T10:01:00Z", "targetUrl": "https://ci.example.com/9"}
+		],
+		"reviews": [
+			{"author": {"login": "alice"}, "state": "CHANGES_REQUESTED"},
+			{"author": {"login": "bob"}, "state": "APPROVED"},
+			{"author": {"login": "bob"}, "state": "COMMENTED"}
+		],
+		"reviewRequests": [{"__typename": "Team", "name": "platform"}]
+	}`
+	var view ghPullRequestView
+	if err := json.Unmarshal([]byte(output), &view); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	pr := gitHubPullRequest(view)
+	if pr.Number != 7 || pr.Author != "octocat" || pr.HeadBranch != "retries" || pr.BaseBranch != "main" {
+		t.Errorf("pull request = %+v, want #7 by octocat from retries into main", pr)
+	}
+	if pr.Mergeable != domain.MergeableConflicting || pr.Additions != 120 || pr.Deletions != 8 {
+		t.Errorf("mergeable = %q, diff = +%d -%d, want conflicting +120 -8", pr.Mergeable, pr.Additions, pr.Deletions)
+	}
+	if !reflect.DeepEqual(pr.Labels, []string{"bug", "backend"}) {
+		t.Errorf("labels = %v, want [bug backend]", pr.Labels)
+	}
+
+	wantChecks := []struct {
+		name   string
+		status domain.CheckStatus
+		url    string
+	}{
+		{"test", domain.CheckPassed, "https://github.com/owner/api/runs/1"},
+		{"lint", domain.CheckRunning, ""},
+		{"ci/deploy", domain.CheckFailed, "https://ci.example.com/9"},
+	}
+	if len(pr.Checks) != len(wantChecks) {
+		t.Fatalf("checks = %+v, want %d", pr.Checks, len(wantChecks))
+	}
+	for i, want := range wantChecks {
+		check := pr.Checks[i]
+		if check.Name != want.name || check.Status != want.status || check.URL != want.url {
+			t.Errorf("checks[%d] = %+v, want %s %s %s", i, check, want.name, want.status, want.url)
+		}
+	}
+	if got := pr.Checks[0].Duration(time.Now()); got != 210*time.Second {
+		t.Errorf("test duration = %v, want 3m30s", got)
+	}
+
+	wantReviewers := []domain.Reviewer{
+		{Login: "alice", State: domain.ReviewChangesRequested},
+		{Login: "bob", State: domain.ReviewApproved},
+		{Login: "platform", State: domain.ReviewPending},
+	}
+	if !reflect.DeepEqual(pr.Reviewers, wantReviewers) {
+		t.Errorf("reviewers = %+v, want %+v", pr.Reviewers, wantReviewers)
+	}
+}
+
+func TestFoldReviewers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		reviews   []domain.Reviewer
+		requested []string
+		want      []domain.Reviewer
+	}{
+		{
+			name:    "latest verdict wins",
+			reviews: []domain.Reviewer{{Login: "alice", State: domain.ReviewChangesRequested}, {Login: "Alice", State: domain.ReviewApproved}},
+			want:    []domain.Reviewer{{Login: "Alice", State: domain.ReviewApproved}},
+		},
+		{
+			name:    "comment keeps earlier verdict",
+			reviews: []domain.Reviewer{{Login: "alice", State: domain.ReviewApproved}, {Login: "alice", State: domain.ReviewCommented}},
+			want:    []domain.Reviewer{{Login: "alice", State: domain.ReviewApproved}},
+		},
+		{
+			name:      "asked again is pending",
+			reviews:   []domain.Reviewer{{Login: "alice", State: domain.ReviewChangesRequested}},
+			requested: []string{"alice", "bob"},
+			want:      []domain.Reviewer{{Login: "alice", State: domain.ReviewPending}, {Login: "bob", State: domain.ReviewPending}},
+		},
+		{
+			name:    "ghost authors are dropped",
+			reviews: []domain.Reviewer{{Login: "", State: domain.ReviewCommented}},
+			want:    []domain.Reviewer{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := foldReviewers(tt.reviews, tt.requested); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("foldReviewers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
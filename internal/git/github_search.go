@@ -0,0 +1,277 @@
+package git
+
+import (
+	"encoding/json"
+	"fmt"
+	"fresh/internal/pullrequests"
+	"sort"
+	"strings"
+)
+
+// GitHub rejects search queries longer than 256 characters and returns at
+// most 1,000 results for one query, 100 at a time.
+const (
+	maxSearchQueryLength = 256
+	maxSearchResults     = 1000
+	searchPageSize       = 100
+)
+
+// graphqlRunner runs a GraphQL query and decodes the data of the response
+// into out, failing when GitHub reports errors. gh and the API client each
+// provide one.
+type graphqlRunner func(query string, variables map[string]string, out any) error
+
+// gqlResponse is the envelope of every GraphQL response.
+type gqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []gqlError      `json:"errors"`
+}
+
+type gqlError struct {
+	Message string `json:"message"`
+}
+
+// decodeGraphQL unwraps body into out.
+func decodeGraphQL(body []byte, out any) error {
+	var response gqlResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to parse github graphql response: %w", err)
+	}
+	if len(response.Errors) > 0 {
+		return fmt.Errorf("github: %s", response.Errors[0].Message)
+	}
+	if len(response.Data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(response.Data, out); err != nil {
+		return fmt.Errorf("failed to parse github graphql response: %w", err)
+	}
+	return nil
+}
+
+// ghGraphQL runs queries through `gh api graphql`.
+func ghGraphQL(host string) graphqlRunner {
+	return func(query string, variables map[string]string, out any) error {
+		args := []string{"api", "graphql", "-f", "query=" + query}
+		names := make([]string, 0, len(variables))
+		for name := range variables {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			args = append(args, "-f", name+"="+variables[name])
+		}
+
+		output, err := ghCommand(host, args...).Output()
+		if err != nil {
+			return normalizeGhError(err)
+		}
+		gitHubRateLimits.recordGraphQL(host, output)
+		return decodeGraphQL(output, out)
+	}
+}
+
+// searchBatch is one search covering some of the repositories.
+type searchBatch struct {
+	query      string
+	ownerRepos []string
+}
+
+// batchSearches spreads the repo: qualifiers for ownerRepos over as few
+// searches starting with base as fit GitHub's query length limit.
+func batchSearches(base string, ownerRepos []string) []searchBatch {
+	var batches []searchBatch
+	current := searchBatch{query: base}
+	for _, ownerRepo := range ownerRepos {
+		qualifier := " repo:" + ownerRepo
+		if len(current.ownerRepos) > 0 && len(current.query)+len(qualifier) > maxSearchQueryLength {
+			batches = append(batches, current)
+			current = searchBatch{query: base}
+		}
+		current.query += qualifier
+		current.ownerRepos = append(current.ownerRepos, ownerRepo)
+	}
+	if len(current.ownerRepos) > 0 {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// slugLookup maps lower-cased owner/repo slugs back to the ones asked for,
+// since search results may spell them differently.
+func slugLookup(ownerRepos []string) map[string]string {
+	lookup := make(map[string]string, len(ownerRepos))
+	for _, ownerRepo := range ownerRepos {
+		lookup[strings.ToLower(ownerRepo)] = ownerRepo
+	}
+	return lookup
+}
+
+type gqlSearchData struct {
+	Search struct {
+		IssueCount int `json:"issueCount"`
+		PageInfo   struct {
+			HasNextPage bool   `json:"hasNextPage"`
+			EndCursor   string `json:"endCursor"`
+		} `json:"pageInfo"`
+		Nodes []gqlPullRequestNode `json:"nodes"`
+	} `json:"search"`
+}
+
+// openPullRequestsQuery finds open pull requests for counting, so it only
+// asks for each one's repository.
+const openPullRequestsQuery = `
+query($q: String!, $after: String) {
+  rateLimit {
+    limit
+    remaining
+    resetAt
+  }
+  search(type: ISSUE, query: $q, first: 100, after: $after) {
+    issueCount
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+    nodes {
+      ... on PullRequest {
+        repository {
+          nameWithOwner
+        }
+      }
+    }
+  }
+}
+`
+
+// searchPullRequests follows the cursor of a search until it runs out or
+// reaches the results GitHub will return. partial is set when pull
+// requests were left out.
+func searchPullRequests(run graphqlRunner, query string, searchText string) ([]gqlPullRequestNode, bool, error) {
+	var nodes []gqlPullRequestNode
+	variables := map[string]string{"q": searchText}
+	for {
+		var page gqlSearchData
+		if err := run(query, variables, &page); err != nil {
+			return nil, false, err
+		}
+		nodes = append(nodes, page.Search.Nodes...)
+
+		if !page.Search.PageInfo.HasNextPage || page.Search.PageInfo.EndCursor == "" {
+			return nodes, page.Search.IssueCount > len(nodes), nil
+		}
+		if len(nodes) >= maxSearchResults {
+			return nodes, true, nil
+		}
+		variables["after"] = page.Search.PageInfo.EndCursor
+	}
+}
+
+// searchOpenPullRequestCounts counts the open pull requests of ownerRepos,
+// keyed by the slugs as given. partial holds the repositories whose count
+// may be too low.
+func searchOpenPullRequestCounts(run graphqlRunner, ownerRepos []string) (map[string]int, map[string]bool, error) {
+	lookup := slugLookup(ownerRepos)
+	counts := make(map[string]int)
+	partial := make(map[string]bool)
+	for _, batch := range batchSearches("is:pr is:open", ownerRepos) {
+		nodes, truncated, err := searchPullRequests(run, openPullRequestsQuery, batch.query)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, node := range nodes {
+			if ownerRepo, ok := lookup[strings.ToLower(node.Repository.NameWithOwner)]; ok {
+				counts[ownerRepo]++
+			}
+		}
+		markPartial(partial, batch.ownerRepos, truncated)
+	}
+	return counts, partial, nil
+}
+
+// searchMyPullRequests classifies the user's open pull requests in
+// ownerRepos. partial holds the repositories some of them were left out
+// for.
+func searchMyPullRequests(run graphqlRunner, ownerRepos []string) ([]pullrequests.Snapshot, map[string]myPullRequestSummary, map[string]bool, error) {
+	var nodes []gqlPullRequestNode
+	partial := make(map[string]bool)
+	for _, batch := range batchSearches("is:pr is:open author:@me", ownerRepos) {
+		found, truncated, err := searchPullRequests(run, myPullRequestsQuery, batch.query)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		nodes = append(nodes, found...)
+		markPartial(partial, batch.ownerRepos, truncated)
+	}
+
+	tracked, summaries := summarizeMyPullRequests(nodes)
+	return tracked, summaries, partial, nil
+}
+
+// reviewRequestsQuery finds pull requests waiting on the user's review,
+// which only need to be counted and named.
+const reviewRequestsQuery = `
+query($q: String!, $after: String) {
+  rateLimit {
+    limit
+    remaining
+    resetAt
+  }
+  search(type: ISSUE, query: $q, first: 100, after: $after) {
+    issueCount
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
+    nodes {
+      ... on PullRequest {
+        repository {
+          nameWithOwner
+        }
+        title
+        number
+      }
+    }
+  }
+}
+`
+
+// searchReviewRequests finds the open pull requests in ownerRepos that ask
+// for the user's review, directly or through a team, and counts them per
+// slug as given. partial holds the repositories some were left out for.
+func searchReviewRequests(run graphqlRunner, ownerRepos []string) ([]pullrequests.Snapshot, map[string]int, map[string]bool, error) {
+	lookup := slugLookup(ownerRepos)
+	var tracked []pullrequests.Snapshot
+	counts := make(map[string]int)
+	partial := make(map[string]bool)
+	for _, batch := range batchSearches("is:pr is:open review-requested:@me", ownerRepos) {
+		nodes, truncated, err := searchPullRequests(run, reviewRequestsQuery, batch.query)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		for _, node := range nodes {
+			owner, repo, ok := parseNameWithOwner(node.Repository.NameWithOwner)
+			ownerRepo, requested := lookup[strings.ToLower(node.Repository.NameWithOwner)]
+			if !ok || !requested || node.Number <= 0 {
+				continue
+			}
+			counts[ownerRepo]++
+			tracked = append(tracked, pullrequests.Snapshot{
+				Key:    pullrequests.Key{Owner: owner, Repo: repo, Number: node.Number},
+				Status: pullrequests.StatusReviewRequested,
+				Title:  strings.TrimSpace(node.Title),
+			})
+		}
+		markPartial(partial, batch.ownerRepos, truncated)
+	}
+	return tracked, counts, partial, nil
+}
+
+func markPartial(partial map[string]bool, ownerRepos []string, truncated bool) {
+	if !truncated {
+		return
+	}
+	for _, ownerRepo := range ownerRepos {
+		partial[ownerRepo] = true
+	}
+}
@@ -0,0 +1,134 @@
+package git
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBatchSearchesStayUnderQueryLimit(t *testing.T) {
+	t.Parallel()
+
+	var ownerRepos []string
+	for i := range 40 {
+		ownerRepos = append(ownerRepos, fmt.Sprintf("acme-platform/service-%02d", i))
+	}
+
+	batches := batchSearches("is:pr is:open author:@me", ownerRepos)
+	if len(batches) < 2 {
+		t.Fatalf("batchSearches() = %d batch, want the repositories split", len(batches))
+	}
+
+	covered := 0
+	for _, batch := range batches {
+		if len(batch.query) > maxSearchQueryLength {
+			t.Errorf("query is %d characters, want at most %d: %s", len(batch.query), maxSearchQueryLength, batch.query)
+		}
+		if !strings.HasPrefix(batch.query, "is:pr is:open author:@me repo:") {
+			t.Errorf("query = %q, want the base followed by repo qualifiers", batch.query)
+		}
+		for _, ownerRepo := range batch.ownerRepos {
+			if !strings.Contains(batch.query, " repo:"+ownerRepo) {
+				t.Errorf("query %q misses %s", batch.query, ownerRepo)
+			}
+		}
+		covered += len(batch.ownerRepos)
+	}
+	if covered != len(ownerRepos) {
+		t.Errorf("batches cover %d repositories, want %d", covered, len(ownerRepos))
+	}
+
+	if got := batchSearches("is:pr is:open", nil); len(got) != 0 {
+		t.Errorf("batchSearches(nil) = %+v, want no searches", got)
+	}
+}
+
+// pagedSearch answers searches with total pull requests spread over pages
+// of searchPageSize, round-robin across repos, the way GitHub pages a
+// cursor.
+func pagedSearch(total int, repos ...string) (graphqlRunner, *int) {
+	calls := 0
+	return func(_ string, variables map[string]string, out any) error {
+		calls++
+		start := 0
+		if after := variables["after"]; after != "" {
+			start, _ = strconv.Atoi(after)
+		}
+		end := min(start+searchPageSize, total)
+
+		nodes := make([]map[string]any, 0, end-start)
+		for i := start; i < end; i++ {
+			nodes = append(nodes, map[string]any{
+				"repository": map[string]string{"nameWithOwner": repos[i%len(repos)]},
+				"number":     i + 1,
+			})
+		}
+		page := map[string]any{"search": map[string]any{
+			"issueCount": total,
+			"pageInfo":   map[string]any{"hasNextPage": end < total, "endCursor": strconv.Itoa(end)},
+			"nodes":      nodes,
+		}}
+		data, _ := json.Marshal(page)
+		return json.Unmarshal(data, out)
+	}, &calls
+}
+
+func TestSearchOpenPullRequestCountsFollowsCursor(t *testing.T) {
+	t.Parallel()
+
+	run, calls := pagedSearch(250, "owner/api", "Owner/Web")
+	counts, partial, err := searchOpenPullRequestCounts(run, []string{"owner/api", "owner/web"})
+	if err != nil {
+		t.Fatalf("searchOpenPullRequestCounts() unexpected error: %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("pages fetched = %d, want 3", *calls)
+	}
+	if counts["owner/api"] != 125 || counts["owner/web"] != 125 {
+		t.Errorf("counts = %v, want 125 each", counts)
+	}
+	if len(partial) != 0 {
+		t.Errorf("partial = %v, want complete counts", partial)
+	}
+}
+
+func TestSearchOpenPullRequestCountsFlagsResultLimit(t *testing.T) {
+	t.Parallel()
+
+	run, calls := pagedSearch(1500, "owner/api")
+	counts, partial, err := searchOpenPullRequestCounts(run, []string{"owner/api"})
+	if err != nil {
+		t.Fatalf("searchOpenPullRequestCounts() unexpected error: %v", err)
+	}
+	if *calls != maxSearchResults/searchPageSize {
+		t.Errorf("pages fetched = %d, want %d", *calls, maxSearchResults/searchPageSize)
+	}
+	if counts["owner/api"] != maxSearchResults || !partial["owner/api"] {
+		t.Errorf("counts = %v, partial = %v, want %d flagged partial", counts, partial, maxSearchResults)
+	}
+}
+
+func TestSearchMyPullRequestsFollowsCursor(t *testing.T) {
+	t.Parallel()
+
+	run, _ := pagedSearch(150, "owner/api")
+	tracked, summaries, partial, err := searchMyPullRequests(run, []string{"owner/api"})
+	if err != nil {
+		t.Fatalf("searchMyPullRequests() unexpected error: %v", err)
+	}
+	if len(tracked) != 150 || summaries["owner/api"].MyOpen != 150 || len(partial) != 0 {
+		t.Errorf("tracked = %d, summary = %+v, partial = %v, want all 150", len(tracked), summaries["owner/api"], partial)
+	}
+}
+
+func TestDecodeGraphQLReportsErrors(t *testing.T) {
+	t.Parallel()
+
+	var out gqlSearchData
+	err := decodeGraphQL([]byte(`{"data":null,"errors":[{"message":"query too long"}]}`), &out)
+	if err == nil || !strings.Contains(err.Error(), "query too long") {
+		t.Errorf("decodeGraphQL() error = %v, want the GraphQL error", err)
+	}
+}
@@ -0,0 +1,167 @@
+package git
+
+import (
+	"fmt"
+	"fresh/internal/domain"
+	"fresh/internal/pullrequests"
+	"strings"
+	"sync"
+	"time"
+)
+
+// openPullRequestCounter counts the open pull requests of owner/repo
+// slugs, returning the slugs whose count may be too low.
+type openPullRequestCounter func(ownerRepos []string) (map[string]int, map[string]bool, error)
+
+// gitHubRepoSync is what the last sync found for one repository.
+type gitHubRepoSync struct {
+	open        int
+	openPartial bool
+	mine        myPullRequestSummary
+	minePartial bool
+	// reviewRequests counts the pull requests waiting on the user's
+	// review, which tracked holds alongside the user's own.
+	reviewRequests int
+	tracked        []pullrequests.Snapshot
+}
+
+// gitHubSyncCache remembers the last sync of every repository by host and
+// owner/repo, so a sync the rate limit cannot afford can reuse it.
+type gitHubSyncCache struct {
+	mu    sync.Mutex
+	repos map[string]map[string]gitHubRepoSync
+}
+
+var gitHubLastSyncs gitHubSyncCache
+
+func (c *gitHubSyncCache) lookup(host string, ownerRepos []string) map[string]gitHubRepoSync {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	found := make(map[string]gitHubRepoSync, len(ownerRepos))
+	for _, ownerRepo := range ownerRepos {
+		if repo, ok := c.repos[strings.ToLower(host)][strings.ToLower(ownerRepo)]; ok {
+			found[ownerRepo] = repo
+		}
+	}
+	return found
+}
+
+func (c *gitHubSyncCache) store(host string, repos map[string]gitHubRepoSync) {
+	host = strings.ToLower(host)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.repos == nil {
+		c.repos = make(map[string]map[string]gitHubRepoSync)
+	}
+	if c.repos[host] == nil {
+		c.repos[host] = make(map[string]gitHubRepoSync)
+	}
+	for ownerRepo, repo := range repos {
+		c.repos[host][strings.ToLower(ownerRepo)] = repo
+	}
+}
+
+// syncGitHubHost counts open pull requests with countOpen, then classifies
+// the user's own and finds those waiting on their review with searches run
+// through run, spending no more of host's rate limit than is left. When the
+// budget is low, counts the last sync found are reused rather than searched
+// again, since the user's own pull requests matter more; when it is
+// exhausted, the whole last sync is.
+func syncGitHubHost(host string, reposByPath map[string]RemoteLocation, countOpen openPullRequestCounter, run graphqlRunner) (map[string]domain.PullRequestState, []pullrequests.Snapshot, error) {
+	ownerRepos := sortedOwnerRepos(reposByPath)
+	budget, known := gitHubRateLimits.current(host, time.Now())
+	last := gitHubLastSyncs.lookup(host, ownerRepos)
+
+	if known && budget.Exhausted() {
+		if len(last) == 0 {
+			return nil, nil, fmt.Errorf("github: rate limit exhausted until %s", budget.ResetAt.Local().Format("15:04"))
+		}
+		return gitHubCachedStates(reposByPath, last)
+	}
+
+	countRepos := ownerRepos
+	if known && budget.Low() {
+		countRepos = nil
+		for _, ownerRepo := range ownerRepos {
+			if _, ok := last[ownerRepo]; !ok {
+				countRepos = append(countRepos, ownerRepo)
+			}
+		}
+	}
+	openCounts := make(map[string]int)
+	openPartial := make(map[string]bool)
+	if len(countRepos) > 0 {
+		var err error
+		if openCounts, openPartial, err = countOpen(countRepos); err != nil {
+			return nil, nil, err
+		}
+	}
+	counted := make(map[string]bool, len(countRepos))
+	for _, ownerRepo := range countRepos {
+		counted[ownerRepo] = true
+	}
+	for ownerRepo, repo := range last {
+		if !counted[ownerRepo] {
+			openCounts[ownerRepo] = repo.open
+			openPartial[ownerRepo] = repo.openPartial
+		}
+	}
+
+	tracked, mySummaries, myPartial, err := searchMyPullRequests(run, ownerRepos)
+	if err != nil {
+		return nil, nil, err
+	}
+	reviews, reviewRequests, reviewPartial, err := searchReviewRequests(run, ownerRepos)
+	if err != nil {
+		return nil, nil, err
+	}
+	tracked = append(tracked, reviews...)
+	for ownerRepo := range reviewPartial {
+		myPartial[ownerRepo] = true
+	}
+
+	synced := make(map[string]gitHubRepoSync, len(ownerRepos))
+	for _, ownerRepo := range ownerRepos {
+		synced[ownerRepo] = gitHubRepoSync{
+			open:           openCounts[ownerRepo],
+			openPartial:    openPartial[ownerRepo],
+			mine:           mySummaries[ownerRepo],
+			minePartial:    myPartial[ownerRepo],
+			reviewRequests: reviewRequests[ownerRepo],
+		}
+	}
+	for _, snapshot := range tracked {
+		for _, ownerRepo := range ownerRepos {
+			if strings.EqualFold(ownerRepo, snapshot.Key.Owner+"/"+snapshot.Key.Repo) {
+				repo := synced[ownerRepo]
+				repo.tracked = append(repo.tracked, snapshot)
+				synced[ownerRepo] = repo
+				break
+			}
+		}
+	}
+	gitHubLastSyncs.store(host, synced)
+
+	partial := openPartial
+	for ownerRepo := range myPartial {
+		partial[ownerRepo] = true
+	}
+	return gitHubPullRequestStates(reposByPath, openCounts, mySummaries, reviewRequests, partial), tracked, nil
+}
+
+// gitHubCachedStates rebuilds a sync from what the last one found.
+func gitHubCachedStates(reposByPath map[string]RemoteLocation, last map[string]gitHubRepoSync) (map[string]domain.PullRequestState, []pullrequests.Snapshot, error) {
+	openCounts := make(map[string]int, len(last))
+	mySummaries := make(map[string]myPullRequestSummary, len(last))
+	reviewRequests := make(map[string]int, len(last))
+	partial := make(map[string]bool, len(last))
+	var tracked []pullrequests.Snapshot
+	for ownerRepo, repo := range last {
+		openCounts[ownerRepo] = repo.open
+		mySummaries[ownerRepo] = repo.mine
+		reviewRequests[ownerRepo] = repo.reviewRequests
+		partial[ownerRepo] = repo.openPartial || repo.minePartial
+		tracked = append(tracked, repo.tracked...)
+	}
+	return gitHubPullRequestStates(reposByPath, openCounts, mySummaries, reviewRequests, partial), tracked, nil
+}
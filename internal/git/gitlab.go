@@ -0,0 +1,248 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"fresh/internal/config"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GitLabTokenEnv holds the personal access token used for gitlab.com.
+// Self-managed hosts use the token configured for them. Without either the
+// token glab stores for the host is used.
+const GitLabTokenEnv = "GITLAB_TOKEN"
+
+const defaultGitLabHost = "gitlab.com"
+
+var errGitLabTokenMissing = errors.New("gitlab: no token (set " + GitLabTokenEnv + " for gitlab.com, a token under gitlab.instances for other hosts, or run `glab auth login`)")
+
+// cachedGitLabUsers caches the authenticated username per host.
+var cachedGitLabUsers hostLoginCaches
+
+// gitLabClient talks to the REST API of one GitLab instance.
+type gitLabClient struct {
+	restClient
+	host string
+}
+
+type gitLabMergeRequest struct {
+	IID                 int    `json:"iid"`
+	ProjectID           int    `json:"project_id"`
+	Title               string `json:"title"`
+	WebURL              string `json:"web_url"`
+	SourceBranch        string `json:"source_branch"`
+	TargetBranch        string `json:"target_branch"`
+	Draft               bool   `json:"draft"`
+	WorkInProgress      bool   `json:"work_in_progress"`
+	HasConflicts        bool   `json:"has_conflicts"`
+	DetailedMergeStatus string `json:"detailed_merge_status"`
+	UpdatedAt           string `json:"updated_at"`
+	Author              struct {
+		Username string `json:"username"`
+	} `json:"author"`
+	Reviewers []struct {
+		Username string `json:"username"`
+	} `json:"reviewers"`
+	Labels     []string `json:"labels"`
+	References struct {
+		Full string `json:"full"`
+	} `json:"references"`
+	// HeadPipeline is only returned when a single merge request is fetched.
+	HeadPipeline *gitLabPipeline `json:"head_pipeline"`
+}
+
+type gitLabPipeline struct {
+	ID     int    `json:"id"`
+	Status string `json:"status"`
+}
+
+type gitLabJob struct {
+	Name         string `json:"name"`
+	Status       string `json:"status"`
+	AllowFailure bool   `json:"allow_failure"`
+	WebURL       string `json:"web_url"`
+	StartedAt    string `json:"started_at"`
+	FinishedAt   string `json:"finished_at"`
+}
+
+// gitLabReviewer is a reviewer of a merge request with where their review
+// stands.
+type gitLabReviewer struct {
+	User struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	State string `json:"state"`
+}
+
+type gitLabDiff struct {
+	Diff string `json:"diff"`
+}
+
+// isGitLabHost reports whether host is gitlab.com or a configured GitLab
+// instance.
+func isGitLabHost(cfg config.GitLabConfig, host string) bool {
+	for _, configured := range cfg.Hosts {
+		if strings.EqualFold(configured, host) {
+			return true
+		}
+	}
+	return false
+}
+
+func parseGitLabRemote(cfg *config.Config, remoteURL string) (RemoteLocation, bool) {
+	location, ok := ResolveRemoteURL(cfg, remoteURL)
+	if !ok || !isGitLabHost(cfg.GitLab, location.Host) {
+		return RemoteLocation{}, false
+	}
+	return location, true
+}
+
+func newGitLabClient(cfg config.GitLabConfig, host string) (*gitLabClient, error) {
+	token := configuredGitLabToken(cfg, host)
+	if token == "" {
+		token = glabToken(host)
+	}
+	if token == "" {
+		return nil, errGitLabTokenMissing
+	}
+
+	return newGitLabClientAt(host, "https://"+host+"/api/v4", token, newAPIHTTPClient(defaultConfig.Timeout.Default)), nil
+}
+
+func newGitLabClientAt(host string, baseURL string, token string, client *http.Client) *gitLabClient {
+	return &gitLabClient{
+		restClient: restClient{
+			name:       "gitlab",
+			baseURL:    baseURL,
+			authHeader: "PRIVATE-TOKEN",
+			authValue:  token,
+			tokenHint:  GitLabTokenEnv + " or the token configured for " + host,
+			http:       client,
+		},
+		host: host,
+	}
+}
+
+// configuredGitLabToken returns $GITLAB_TOKEN for gitlab.com and the
+// token cfg sets for any other host.
+func configuredGitLabToken(cfg config.GitLabConfig, host string) string {
+	if strings.EqualFold(host, defaultGitLabHost) {
+		return strings.TrimSpace(os.Getenv(GitLabTokenEnv))
+	}
+	instance, _ := findInstance(cfg.Instances, host)
+	return instance.Token
+}
+
+// glabToken returns the token glab has stored for host, if glab is installed
+// and logged in.
+func glabToken(host string) string {
+	cmd := createCommand(defaultConfig.Timeout.Default, "glab", "config", "get", "token", "--host", host)
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func gitLabProjectPath(project string) string {
+	return "/projects/" + url.PathEscape(project)
+}
+
+// openMergeRequestCount reads the total from the pagination headers so only
+// one merge request has to be transferred.
+func (c *gitLabClient) openMergeRequestCount(project string) (int, error) {
+	query := url.Values{"state": {"opened"}, "per_page": {"1"}}
+	var page []gitLabMergeRequest
+	header, err := c.get(gitLabProjectPath(project)+"/merge_requests", query, &page)
+	if err != nil {
+		return 0, err
+	}
+	if total, err := strconv.Atoi(header.Get("X-Total")); err == nil {
+		return total, nil
+	}
+	return len(page), nil
+}
+
+func (c *gitLabClient) myMergeRequests() ([]gitLabMergeRequest, error) {
+	query := url.Values{"state": {"opened"}, "scope": {"created_by_me"}, "per_page": {"100"}}
+	var mergeRequests []gitLabMergeRequest
+	if _, err := c.get("/merge_requests", query, &mergeRequests); err != nil {
+		return nil, err
+	}
+	return mergeRequests, nil
+}
+
+// reviewRequests finds the open merge requests username is a reviewer of.
+func (c *gitLabClient) reviewRequests(username string) ([]gitLabMergeRequest, error) {
+	query := url.Values{"state": {"opened"}, "scope": {"all"}, "reviewer_username": {username}, "per_page": {"100"}}
+	var mergeRequests []gitLabMergeRequest
+	if _, err := c.get("/merge_requests", query, &mergeRequests); err != nil {
+		return nil, err
+	}
+	return mergeRequests, nil
+}
+
+func (c *gitLabClient) projectMergeRequests(project string) ([]gitLabMergeRequest, error) {
+	query := url.Values{"state": {"opened"}, "per_page": {"100"}, "order_by": {"updated_at"}}
+	var mergeRequests []gitLabMergeRequest
+	if _, err := c.get(gitLabProjectPath(project)+"/merge_requests", query, &mergeRequests); err != nil {
+		return nil, err
+	}
+	return mergeRequests, nil
+}
+
+func (c *gitLabClient) mergeRequest(projectID int, iid int) (gitLabMergeRequest, error) {
+	var mergeRequest gitLabMergeRequest
+	_, err := c.get(fmt.Sprintf("/projects/%d/merge_requests/%d", projectID, iid), nil, &mergeRequest)
+	return mergeRequest, err
+}
+
+func (c *gitLabClient) projectMergeRequest(project string, iid int) (gitLabMergeRequest, error) {
+	var mergeRequest gitLabMergeRequest
+	_, err := c.get(fmt.Sprintf("%s/merge_requests/%d", gitLabProjectPath(project), iid), nil, &mergeRequest)
+	return mergeRequest, err
+}
+
+func (c *gitLabClient) mergeRequestReviewers(projectID int, iid int) ([]gitLabReviewer, error) {
+	var reviewers []gitLabReviewer
+	_, err := c.get(fmt.Sprintf("/projects/%d/merge_requests/%d/reviewers", projectID, iid), nil, &reviewers)
+	return reviewers, err
+}
+
+// mergeRequestDiffs returns the diff of each changed file, up to the first
+// hundred.
+func (c *gitLabClient) mergeRequestDiffs(projectID int, iid int) ([]gitLabDiff, error) {
+	query := url.Values{"per_page": {"100"}}
+	var diffs []gitLabDiff
+	_, err := c.get(fmt.Sprintf("/projects/%d/merge_requests/%d/diffs", projectID, iid), query, &diffs)
+	return diffs, err
+}
+
+func (c *gitLabClient) pipelineJobs(projectID int, pipelineID int) ([]gitLabJob, error) {
+	query := url.Values{"per_page": {"100"}}
+	var jobs []gitLabJob
+	_, err := c.get(fmt.Sprintf("/projects/%d/pipelines/%d/jobs", projectID, pipelineID), query, &jobs)
+	return jobs, err
+}
+
+func (c *gitLabClient) currentUser() string {
+	return cachedGitLabUsers.forHost(c.host).get(time.Now(), githubLoginCacheTTL, func() (string, error) {
+		var user struct {
+			Username string `json:"username"`
+		}
+		_, err := c.get("/user", nil, &user)
+		return user.Username, err
+	})
+}
+
+// projectOf splits a merge request reference such as "group/sub/repo!12"
+// into its project path.
+func (mr gitLabMergeRequest) projectOf() string {
+	project, _, _ := strings.Cut(mr.References.Full, "!")
+	return project
+}
@@ -0,0 +1,436 @@
+package git
+
+import (
+	"fresh/internal/config"
+	"fresh/internal/domain"
+	"fresh/internal/pullrequests"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GitLabMergeRequestService fills the pull request columns for repositories
+// hosted on GitLab from their merge requests.
+type GitLabMergeRequestService struct {
+	// Config lists the GitLab hosts and their tokens.
+	Config *config.Config
+}
+
+func (GitLabMergeRequestService) Name() string { return "gitlab" }
+
+func (s GitLabMergeRequestService) Match(remoteURL string) (RemoteLocation, bool) {
+	return parseGitLabRemote(s.Config, remoteURL)
+}
+
+func (s GitLabMergeRequestService) SyncPullRequests(host string, reposByPath map[string]RemoteLocation) (map[string]domain.PullRequestState, []pullrequests.Snapshot, error) {
+	client, err := newGitLabClient(s.Config.GitLab, host)
+	if err != nil {
+		return nil, nil, err
+	}
+	return syncGitLabMergeRequests(client, slugsByPath(reposByPath))
+}
+
+func (s GitLabMergeRequestService) ListPullRequests(location RemoteLocation) ([]domain.PullRequestDetails, error) {
+	client, err := newGitLabClient(s.Config.GitLab, location.Host)
+	if err != nil {
+		return nil, err
+	}
+	return getGitLabMergeRequests(client, location.Slug())
+}
+
+func (s GitLabMergeRequestService) GetPullRequest(location RemoteLocation, number int) (domain.PullRequest, error) {
+	client, err := newGitLabClient(s.Config.GitLab, location.Host)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+	return getGitLabMergeRequest(client, location.Slug(), number)
+}
+
+func (GitLabMergeRequestService) PullRequestURL(location RemoteLocation, number int) string {
+	return location.WebURL() + "/-/merge_requests/" + strconv.Itoa(number)
+}
+
+func (s GitLabMergeRequestService) CurrentUser(host string) string {
+	client, err := newGitLabClient(s.Config.GitLab, host)
+	if err != nil {
+		return ""
+	}
+	return client.currentUser()
+}
+
+// syncGitLabMergeRequests counts the open merge requests of every project
+// and classifies the user's own, keyed like GitHub pull requests with the
+// group path as owner.
+func syncGitLabMergeRequests(client *gitLabClient, projectsByPath map[string]string) (map[string]domain.PullRequestState, []pullrequests.Snapshot, error) {
+	projectSet := make(map[string]string)
+	for _, project := range projectsByPath {
+		projectSet[strings.ToLower(project)] = project
+	}
+	projects := make([]string, 0, len(projectSet))
+	for _, project := range projectSet {
+		projects = append(projects, project)
+	}
+
+	counts := make([]int, len(projects))
+	if err := forEachConcurrently(len(projects), func(i int) error {
+		count, err := client.openMergeRequestCount(projects[i])
+		counts[i] = count
+		return err
+	}); err != nil {
+		return nil, nil, err
+	}
+	openCounts := make(map[string]int, len(projects))
+	for i, project := range projects {
+		openCounts[strings.ToLower(project)] = counts[i]
+	}
+
+	mine, err := client.myMergeRequests()
+	if err != nil {
+		return nil, nil, err
+	}
+	var relevant []gitLabMergeRequest
+	for _, mr := range mine {
+		if _, ok := projectSet[strings.ToLower(mr.projectOf())]; ok && mr.IID > 0 {
+			relevant = append(relevant, mr)
+		}
+	}
+	// The list endpoint leaves out the head pipeline, which decides whether
+	// checks are failing or still running.
+	if err := forEachConcurrently(len(relevant), func(i int) error {
+		detailed, err := client.mergeRequest(relevant[i].ProjectID, relevant[i].IID)
+		if err == nil {
+			relevant[i].HeadPipeline = detailed.HeadPipeline
+		}
+		return err
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	summaries := make(map[string]myPullRequestSummary)
+	tracked := make([]pullrequests.Snapshot, 0, len(relevant))
+	for _, mr := range relevant {
+		project := strings.ToLower(mr.projectOf())
+		slash := strings.LastIndex(projectSet[project], "/")
+		if slash <= 0 {
+			continue
+		}
+
+		classification := classifyGitLabMergeRequest(mr)
+		tracked = append(tracked, pullrequests.Snapshot{
+			Key: pullrequests.Key{
+				Owner:  projectSet[project][:slash],
+				Repo:   projectSet[project][slash+1:],
+				Number: mr.IID,
+			},
+			Status: classification,
+			Title:  strings.TrimSpace(mr.Title),
+		})
+
+		summary := summaries[project]
+		summary.MyOpen++
+		switch classification {
+		case pullrequests.StatusReady:
+			summary.MyReady++
+		case pullrequests.StatusBlocked:
+			summary.MyBlocked++
+		case pullrequests.StatusReview:
+			summary.MyReview++
+		default:
+			summary.MyChecks++
+		}
+		summaries[project] = summary
+	}
+
+	// Merge requests can only be filtered by reviewer name, so review
+	// requests are skipped when the user cannot be looked up.
+	reviewRequests := make(map[string]int)
+	if username := client.currentUser(); username != "" {
+		reviews, err := client.reviewRequests(username)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, mr := range reviews {
+			project := strings.ToLower(mr.projectOf())
+			slash := strings.LastIndex(projectSet[project], "/")
+			if slash <= 0 || mr.IID <= 0 {
+				continue
+			}
+			reviewRequests[project]++
+			tracked = append(tracked, pullrequests.Snapshot{
+				Key: pullrequests.Key{
+					Owner:  projectSet[project][:slash],
+					Repo:   projectSet[project][slash+1:],
+					Number: mr.IID,
+				},
+				Status: pullrequests.StatusReviewRequested,
+				Title:  strings.TrimSpace(mr.Title),
+			})
+		}
+	}
+
+	states := make(map[string]domain.PullRequestState, len(projectsByPath))
+	for path, project := range projectsByPath {
+		key := strings.ToLower(project)
+		summary := summaries[key]
+		states[path] = domain.PullRequestCount{
+			Open:            openCounts[key],
+			MyOpen:          summary.MyOpen,
+			MyReady:         summary.MyReady,
+			MyBlocked:       summary.MyBlocked,
+			MyReview:        summary.MyReview,
+			MyChecks:        summary.MyChecks,
+			ReviewRequested: reviewRequests[key],
+		}
+	}
+	return states, tracked, nil
+}
+
+// gitLabBlockingMergeStatuses are detailed_merge_status values only the
+// author can resolve.
+var gitLabBlockingMergeStatuses = map[string]bool{
+	"broken_status":              true,
+	"conflict":                   true,
+	"need_rebase":                true,
+	"discussions_not_resolved":   true,
+	"draft_status":               true,
+	"blocked_status":             true,
+	"requested_changes":          true,
+	"jira_association_missing":   true,
+	"merge_request_blocked":      true,
+	"security_policy_violations": true,
+}
+
+// gitLabPendingMergeStatuses are detailed_merge_status values that settle
+// without anyone acting.
+var gitLabPendingMergeStatuses = map[string]bool{
+	"ci_still_running":        true,
+	"checking":                true,
+	"unchecked":               true,
+	"preparing":               true,
+	"status_checks_must_pass": true,
+}
+
+// classifyGitLabMergeRequest maps a merge request onto the statuses used for
+// GitHub: drafts, conflicts and failed pipelines block, running pipelines
+// wait on checks, missing approvals wait on review.
+func classifyGitLabMergeRequest(mr gitLabMergeRequest) pullrequests.Status {
+	if mr.Draft || mr.WorkInProgress || mr.HasConflicts {
+		return pullrequests.StatusBlocked
+	}
+
+	mergeStatus := strings.ToLower(strings.TrimSpace(mr.DetailedMergeStatus))
+	if gitLabBlockingMergeStatuses[mergeStatus] {
+		return pullrequests.StatusBlocked
+	}
+
+	gate := pullRequestCheckGateClear
+	if mr.HeadPipeline != nil {
+		gate = classifyGitLabPipelineGate(mr.HeadPipeline.Status)
+	}
+	if gate == pullRequestCheckGateFailing {
+		return pullrequests.StatusBlocked
+	}
+	if gate == pullRequestCheckGatePending || gitLabPendingMergeStatuses[mergeStatus] {
+		return pullrequests.StatusChecks
+	}
+
+	switch mergeStatus {
+	case "not_approved", "approvals_syncing":
+		return pullrequests.StatusReview
+	case "ci_must_pass":
+		// The pipeline has not succeeded but did not fail either, e.g. it
+		// has not run for the latest commit.
+		return pullrequests.StatusChecks
+	}
+	return pullrequests.StatusReady
+}
+
+func classifyGitLabPipelineGate(status string) pullRequestCheckGate {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "failed", "canceled":
+		return pullRequestCheckGateFailing
+	case "success", "skipped", "manual", "":
+		return pullRequestCheckGateClear
+	default:
+		return pullRequestCheckGatePending
+	}
+}
+
+func classifyGitLabJob(job gitLabJob) checkSummaryClass {
+	switch strings.ToLower(strings.TrimSpace(job.Status)) {
+	case "success":
+		return checkSummaryPassed
+	case "failed":
+		if job.AllowFailure {
+			return checkSummarySkipped
+		}
+		return checkSummaryFailed
+	case "canceled":
+		return checkSummaryFailed
+	case "skipped", "manual":
+		return checkSummarySkipped
+	case "running":
+		return checkSummaryRunning
+	default:
+		return checkSummaryWaiting
+	}
+}
+
+func summarizeGitLabJobs(jobs []gitLabJob) domain.PullRequestChecks {
+	summary := domain.PullRequestChecks{Total: len(jobs)}
+	for _, job := range jobs {
+		switch classifyGitLabJob(job) {
+		case checkSummaryPassed:
+			summary.Passed++
+		case checkSummaryRunning:
+			summary.Running++
+		case checkSummaryFailed:
+			summary.Failed++
+		case checkSummarySkipped:
+			summary.Skipped++
+		default:
+			summary.Waiting++
+		}
+	}
+	return summary
+}
+
+// getGitLabMergeRequests lists a project's open merge requests with the
+// jobs of each one's head pipeline summarised as checks.
+func getGitLabMergeRequests(client *gitLabClient, project string) ([]domain.PullRequestDetails, error) {
+	mergeRequests, err := client.projectMergeRequests(project)
+	if err != nil {
+		return nil, err
+	}
+
+	checks := make([]domain.PullRequestChecks, len(mergeRequests))
+	if err := forEachConcurrently(len(mergeRequests), func(i int) error {
+		detailed, err := client.mergeRequest(mergeRequests[i].ProjectID, mergeRequests[i].IID)
+		if err != nil || detailed.HeadPipeline == nil {
+			return err
+		}
+		jobs, err := client.pipelineJobs(mergeRequests[i].ProjectID, detailed.HeadPipeline.ID)
+		checks[i] = summarizeGitLabJobs(jobs)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	currentUser := client.currentUser()
+	pullRequests := make([]domain.PullRequestDetails, 0, len(mergeRequests))
+	for i, mr := range mergeRequests {
+		updatedAt, _ := time.Parse(time.RFC3339, mr.UpdatedAt)
+		reviewRequested := false
+		for _, reviewer := range mr.Reviewers {
+			if currentUser != "" && strings.EqualFold(strings.TrimSpace(reviewer.Username), currentUser) {
+				reviewRequested = true
+			}
+		}
+		pullRequests = append(pullRequests, domain.PullRequestDetails{
+			Number:          mr.IID,
+			Title:           mr.Title,
+			IsMine:          currentUser != "" && strings.EqualFold(strings.TrimSpace(mr.Author.Username), currentUser),
+			ReviewRequested: reviewRequested,
+			UpdatedAt:       updatedAt,
+			Checks:          checks[i],
+		})
+	}
+	return pullRequests, nil
+}
+
+// getGitLabMergeRequest shows a merge request with the jobs of its head
+// pipeline as checks. GitLab reports no line counts, so they are counted
+// from the diffs of the first hundred changed files.
+func getGitLabMergeRequest(client *gitLabClient, project string, iid int) (domain.PullRequest, error) {
+	mr, err := client.projectMergeRequest(project, iid)
+	if err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	var jobs []gitLabJob
+	var reviewers []gitLabReviewer
+	var diffs []gitLabDiff
+	if err := forEachConcurrently(3, func(i int) error {
+		var err error
+		switch i {
+		case 0:
+			if mr.HeadPipeline != nil {
+				jobs, err = client.pipelineJobs(mr.ProjectID, mr.HeadPipeline.ID)
+			}
+		case 1:
+			reviewers, err = client.mergeRequestReviewers(mr.ProjectID, mr.IID)
+		default:
+			diffs, err = client.mergeRequestDiffs(mr.ProjectID, mr.IID)
+		}
+		return err
+	}); err != nil {
+		return domain.PullRequest{}, err
+	}
+
+	pullRequest := domain.PullRequest{
+		Number:     mr.IID,
+		Title:      mr.Title,
+		URL:        mr.WebURL,
+		Author:     mr.Author.Username,
+		HeadBranch: mr.SourceBranch,
+		BaseBranch: mr.TargetBranch,
+		Draft:      mr.Draft || mr.WorkInProgress,
+		Mergeable:  gitLabMergeableState(mr),
+		Labels:     mr.Labels,
+	}
+	for _, diff := range diffs {
+		for _, line := range strings.Split(diff.Diff, "\n") {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				pullRequest.Additions++
+			case strings.HasPrefix(line, "-"):
+				pullRequest.Deletions++
+			}
+		}
+	}
+	for _, job := range jobs {
+		pullRequest.Checks = append(pullRequest.Checks, domain.CheckRun{
+			Name:        job.Name,
+			Status:      checkStatusOf(classifyGitLabJob(job)),
+			StartedAt:   parseAPITime(job.StartedAt),
+			CompletedAt: parseAPITime(job.FinishedAt),
+			URL:         job.WebURL,
+		})
+	}
+	for _, reviewer := range reviewers {
+		pullRequest.Reviewers = append(pullRequest.Reviewers, domain.Reviewer{
+			Login: reviewer.User.Username,
+			State: gitLabReviewState(reviewer.State),
+		})
+	}
+	return pullRequest, nil
+}
+
+func gitLabMergeableState(mr gitLabMergeRequest) domain.MergeableState {
+	if mr.HasConflicts {
+		return domain.MergeableConflicting
+	}
+	switch strings.ToLower(strings.TrimSpace(mr.DetailedMergeStatus)) {
+	case "conflict":
+		return domain.MergeableConflicting
+	case "", "checking", "unchecked", "preparing":
+		return domain.MergeableUnknown
+	default:
+		return domain.MergeableClean
+	}
+}
+
+func gitLabReviewState(state string) domain.ReviewState {
+	switch strings.ToLower(strings.TrimSpace(state)) {
+	case "approved":
+		return domain.ReviewApproved
+	case "requested_changes":
+		return domain.ReviewChangesRequested
+	case "reviewed":
+		return domain.ReviewCommented
+	case "unapproved":
+		return domain.ReviewDismissed
+	default:
+		return domain.ReviewPending
+	}
+}
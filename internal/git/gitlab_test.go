@@ -0,0 +1,321 @@
+package git
+
+import (
+	"fresh/internal/config"
+	"fresh/internal/domain"
+	"fresh/internal/pullrequests"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newGitLabStandIn serves routes as JSON and rejects requests without the
+// token, keeping the scope parameter in the route key.
+func newGitLabStandIn(t *testing.T, routes map[string]any, headers map[string]map[string]string) *gitLabClient {
+	t.Helper()
+
+	standIn := restStandIn{authHeader: "PRIVATE-TOKEN", authValue: "secret", pathPrefix: "/api/v4", queryKey: "scope"}
+	server, host, baseURL := standIn.serve(t, routes, headers)
+	return newGitLabClientAt(host, baseURL, "secret", server.Client())
+}
+
+func gitLabMR(project string, projectID, iid int, opts ...func(*gitLabMergeRequest)) gitLabMergeRequest {
+	mr := gitLabMergeRequest{IID: iid, ProjectID: projectID, Title: "Change " + project, DetailedMergeStatus: "mergeable"}
+	mr.References.Full = project + "!" + strconv.Itoa(iid)
+	for _, opt := range opts {
+		opt(&mr)
+	}
+	return mr
+}
+
+func TestSyncGitLabMergeRequests(t *testing.T) {
+	t.Parallel()
+
+	notApproved := func(mr *gitLabMergeRequest) { mr.DetailedMergeStatus = "not_approved" }
+	routes := map[string]any{
+		"/projects/group%2Fapi/merge_requests":       []gitLabMergeRequest{{IID: 1}},
+		"/projects/group%2Fsub%2Fweb/merge_requests": []gitLabMergeRequest{},
+		"/merge_requests?scope=created_by_me": []gitLabMergeRequest{
+			gitLabMR("group/api", 1, 1),
+			gitLabMR("group/api", 1, 2, notApproved),
+			gitLabMR("group/sub/web", 2, 3),
+			gitLabMR("other/unscanned", 3, 4),
+		},
+		"/merge_requests?scope=all": []gitLabMergeRequest{
+			gitLabMR("group/sub/web", 2, 5),
+		},
+		"/user":                        map[string]string{"username": "octocat"},
+		"/projects/1/merge_requests/1": gitLabMergeRequest{HeadPipeline: &gitLabPipeline{ID: 10, Status: "success"}},
+		"/projects/1/merge_requests/2": gitLabMergeRequest{},
+		"/projects/2/merge_requests/3": gitLabMergeRequest{HeadPipeline: &gitLabPipeline{ID: 11, Status: "failed"}},
+	}
+	headers := map[string]map[string]string{
+		"/projects/group%2Fapi/merge_requests":       {"X-Total": "7"},
+		"/projects/group%2Fsub%2Fweb/merge_requests": {"X-Total": "0"},
+	}
+	client := newGitLabStandIn(t, routes, headers)
+
+	states, tracked, err := syncGitLabMergeRequests(client, map[string]string{
+		"/src/api": "group/api",
+		"/src/web": "group/sub/web",
+	})
+	if err != nil {
+		t.Fatalf("syncGitLabMergeRequests() unexpected error: %v", err)
+	}
+
+	wantAPI := domain.PullRequestCount{Open: 7, MyOpen: 2, MyReady: 1, MyReview: 1}
+	if states["/src/api"] != wantAPI {
+		t.Errorf("api state = %+v, want %+v", states["/src/api"], wantAPI)
+	}
+	wantWeb := domain.PullRequestCount{MyOpen: 1, MyBlocked: 1, ReviewRequested: 1}
+	if states["/src/web"] != wantWeb {
+		t.Errorf("web state = %+v, want %+v", states["/src/web"], wantWeb)
+	}
+
+	if len(tracked) != 4 {
+		t.Fatalf("tracked = %+v, want 4 snapshots", tracked)
+	}
+	statuses := make(map[pullrequests.Key]pullrequests.Status)
+	for _, snapshot := range tracked {
+		statuses[snapshot.Key] = snapshot.Status
+	}
+	if got := statuses[pullrequests.Key{Owner: "group/sub", Repo: "web", Number: 3}]; got != pullrequests.StatusBlocked {
+		t.Errorf("group/sub/web!3 = %q, want blocked", got)
+	}
+	if got := statuses[pullrequests.Key{Owner: "group/sub", Repo: "web", Number: 5}]; got != pullrequests.StatusReviewRequested {
+		t.Errorf("group/sub/web!5 = %q, want a review request", got)
+	}
+}
+
+func TestSyncGitLabMergeRequestsReportsAuthErrors(t *testing.T) {
+	t.Parallel()
+
+	client := newGitLabStandIn(t, nil, nil)
+	client.authValue = "wrong"
+
+	_, _, err := syncGitLabMergeRequests(client, map[string]string{"/src/api": "group/api"})
+	if err == nil || !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), GitLabTokenEnv) {
+		t.Fatalf("syncGitLabMergeRequests() error = %v, want 401 mentioning %s", err, GitLabTokenEnv)
+	}
+}
+
+func TestGetGitLabMergeRequests(t *testing.T) {
+	t.Parallel()
+
+	mine := gitLabMR("group/api", 1, 1)
+	mine.Author.Username = "octocat"
+	mine.UpdatedAt = "2026-01-02T10:00:00Z"
+	theirs := gitLabMR("group/api", 1, 2)
+	theirs.Author.Username = "someone"
+
+	routes := map[string]any{
+		"/projects/group%2Fapi/merge_requests": []gitLabMergeRequest{mine, theirs},
+		"/projects/1/merge_requests/1":         gitLabMergeRequest{HeadPipeline: &gitLabPipeline{ID: 10, Status: "running"}},
+		"/projects/1/merge_requests/2":         gitLabMergeRequest{},
+		"/projects/1/pipelines/10/jobs": []gitLabJob{
+			{Status: "success"},
+			{Status: "running"},
+			{Status: "failed"},
+			{Status: "failed", AllowFailure: true},
+			{Status: "pending"},
+		},
+		"/user": map[string]string{"username": "octocat"},
+	}
+	client := newGitLabStandIn(t, routes, nil)
+
+	pullRequests, err := getGitLabMergeRequests(client, "group/api")
+	if err != nil {
+		t.Fatalf("getGitLabMergeRequests() unexpected error: %v", err)
+	}
+	if len(pullRequests) != 2 {
+		t.Fatalf("getGitLabMergeRequests() = %+v, want 2", pullRequests)
+	}
+
+	first := pullRequests[0]
+	if first.Number != 1 || !first.IsMine || first.UpdatedAt.IsZero() {
+		t.Errorf("first = %+v, want my merge request !1", first)
+	}
+	wantChecks := domain.PullRequestChecks{Total: 5, Passed: 1, Running: 1, Failed: 1, Skipped: 1, Waiting: 1}
+	if first.Checks != wantChecks {
+		t.Errorf("checks = %+v, want %+v", first.Checks, wantChecks)
+	}
+	if pullRequests[1].IsMine || pullRequests[1].Checks.Total != 0 {
+		t.Errorf("second = %+v, want someone else's without a pipeline", pullRequests[1])
+	}
+}
+
+func TestGetGitLabMergeRequest(t *testing.T) {
+	t.Parallel()
+
+	mr := gitLabMR("group/api", 1, 5)
+	mr.Author.Username = "octocat"
+	mr.SourceBranch, mr.TargetBranch = "retries", "main"
+	mr.WebURL = "https://gitlab.com/group/api/-/merge_requests/5"
+	mr.Labels = []string{"backend"}
+	mr.HasConflicts = true
+	mr.HeadPipeline = &gitLabPipeline{ID: 10, Status: "running"}
+
+	reviewer := func(username, state string) gitLabReviewer {
+		r := gitLabReviewer{State: state}
+		r.User.Username = username
+		return r
+	}
+	routes := map[string]any{
+		"/projects/group%2Fapi/merge_requests/5": mr,
+		"/projects/1/pipelines/10/jobs": []gitLabJob{
+			{Name: "test", Status: "success", WebURL: "https://gitlab.com/group/api/-/jobs/1", StartedAt: "2026-01-02T10:00:00Z", FinishedAt: "2026-01-02T10:00:45Z"},
+			{Name: "deploy", Status: "failed", AllowFailure: true},
+		},
+		"/projects/1/merge_requests/5/reviewers": []gitLabReviewer{reviewer("alice", "approved"), reviewer("bob", "unreviewed")},
+		"/projects/1/merge_requests/5/diffs": []gitLabDiff{
+			{Diff: "@@ -1,2 +1,3 @@\n line\n-old\n+new\n+more\n"},
+			{Diff: "@@ -0,0 +1 @@\n+file\n"},
+		},
+	}
+	client := newGitLabStandIn(t, routes, nil)
+
+	got, err := getGitLabMergeRequest(client, "group/api", 5)
+	if err != nil {
+		t.Fatalf("getGitLabMergeRequest() unexpected error: %v", err)
+	}
+	if got.Number != 5 || got.Author != "octocat" || got.HeadBranch != "retries" || got.BaseBranch != "main" || got.URL != mr.WebURL {
+		t.Errorf("merge request = %+v, want !5 by octocat from retries into main", got)
+	}
+	if got.Mergeable != domain.MergeableConflicting || got.Additions != 3 || got.Deletions != 1 {
+		t.Errorf("mergeable = %q, diff = +%d -%d, want conflicting +3 -1", got.Mergeable, got.Additions, got.Deletions)
+	}
+	if len(got.Checks) != 2 || got.Checks[0].Status != domain.CheckPassed || got.Checks[0].Duration(time.Now()) != 45*time.Second {
+		t.Errorf("checks = %+v, want test passed in 45s first", got.Checks)
+	}
+	if got.Checks[1].Status != domain.CheckSkipped {
+		t.Errorf("checks[1] = %+v, want the allowed failure skipped", got.Checks[1])
+	}
+	wantReviewers := []domain.Reviewer{
+		{Login: "alice", State: domain.ReviewApproved},
+		{Login: "bob", State: domain.ReviewPending},
+	}
+	if !reflect.DeepEqual(got.Reviewers, wantReviewers) {
+		t.Errorf("reviewers = %+v, want %+v", got.Reviewers, wantReviewers)
+	}
+}
+
+func TestClassifyGitLabMergeRequest(t *testing.T) {
+	t.Parallel()
+
+	pipeline := func(status string) func(*gitLabMergeRequest) {
+		return func(mr *gitLabMergeRequest) { mr.HeadPipeline = &gitLabPipeline{Status: status} }
+	}
+	mergeStatus := func(status string) func(*gitLabMergeRequest) {
+		return func(mr *gitLabMergeRequest) { mr.DetailedMergeStatus = status }
+	}
+
+	tests := []struct {
+		name string
+		opts []func(*gitLabMergeRequest)
+		want pullrequests.Status
+	}{
+		{name: "mergeable", want: pullrequests.StatusReady},
+		{name: "draft", opts: []func(*gitLabMergeRequest){func(mr *gitLabMergeRequest) { mr.Draft = true }}, want: pullrequests.StatusBlocked},
+		{name: "conflicts", opts: []func(*gitLabMergeRequest){func(mr *gitLabMergeRequest) { mr.HasConflicts = true }}, want: pullrequests.StatusBlocked},
+		{name: "unresolved discussions", opts: []func(*gitLabMergeRequest){mergeStatus("discussions_not_resolved")}, want: pullrequests.StatusBlocked},
+		{name: "failed pipeline", opts: []func(*gitLabMergeRequest){pipeline("failed")}, want: pullrequests.StatusBlocked},
+		{name: "running pipeline", opts: []func(*gitLabMergeRequest){pipeline("running")}, want: pullrequests.StatusChecks},
+		{name: "failed pipeline beats approval", opts: []func(*gitLabMergeRequest){mergeStatus("not_approved"), pipeline("failed")}, want: pullrequests.StatusBlocked},
+		{name: "running pipeline beats approval", opts: []func(*gitLabMergeRequest){mergeStatus("not_approved"), pipeline("pending")}, want: pullrequests.StatusChecks},
+		{name: "needs approval", opts: []func(*gitLabMergeRequest){mergeStatus("not_approved"), pipeline("success")}, want: pullrequests.StatusReview},
+		{name: "still checking", opts: []func(*gitLabMergeRequest){mergeStatus("checking")}, want: pullrequests.StatusChecks},
+		{name: "pipeline must pass", opts: []func(*gitLabMergeRequest){mergeStatus("ci_must_pass")}, want: pullrequests.StatusChecks},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			mr := gitLabMR("group/api", 1, 1, tt.opts...)
+			if got := classifyGitLabMergeRequest(mr); got != tt.want {
+				t.Errorf("classifyGitLabMergeRequest() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGitLabRemote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		remote string
+		want   string
+		ok     bool
+	}{
+		{remote: "git@gitlab.com:group/sub/api.git", want: "group/sub/api", ok: true},
+		{remote: "https://gitlab.com/team/web", want: "team/web", ok: true},
+		{remote: "https://gitlab.evil.example/team/web"},
+		{remote: "git@github.com:owner/repo.git"},
+		{remote: "git@git.example.com:team/web.git"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remote, func(t *testing.T) {
+			t.Parallel()
+
+			location, ok := parseGitLabRemote(config.DefaultConfig(), tt.remote)
+			if ok != tt.ok || (ok && location.Slug() != tt.want) {
+				t.Errorf("parseGitLabRemote(%q) = %+v, %v, want %q, %v", tt.remote, location, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestConfiguredGitLabTokenStaysOnItsHost(t *testing.T) {
+	t.Setenv(GitLabTokenEnv, "dotcom-secret")
+
+	cfg := config.GitLabConfig{
+		Hosts:     []string{"gitlab.com", "git.acme.corp", "git.other.corp"},
+		Instances: []config.Instance{{Host: "git.acme.corp", Token: "acme-secret"}},
+	}
+
+	tests := []struct {
+		host string
+		want string
+	}{
+		{host: "gitlab.com", want: "dotcom-secret"},
+		{host: "Git.Acme.Corp", want: "acme-secret"},
+		{host: "git.other.corp"},
+		{host: "gitlab.evil.example"},
+	}
+
+	for _, tt := range tests {
+		if got := configuredGitLabToken(cfg, tt.host); got != tt.want {
+			t.Errorf("configuredGitLabToken(%q) = %q, want %q", tt.host, got, tt.want)
+		}
+	}
+}
+
+func TestGitLabTokenNotSentAcrossRedirects(t *testing.T) {
+	t.Parallel()
+
+	var leaked bool
+	elsewhere := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked = r.Header.Get("PRIVATE-TOKEN") != ""
+		_, _ = w.Write([]byte(`{"username":"octocat"}`))
+	}))
+	t.Cleanup(elsewhere.Close)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, elsewhere.URL+r.URL.Path, http.StatusFound)
+	}))
+	t.Cleanup(server.Close)
+
+	client := newGitLabClientAt(strings.TrimPrefix(server.URL, "http://"), server.URL+"/api/v4", "secret", newAPIHTTPClient(time.Second))
+	var user struct{}
+	if _, err := client.get("/user", nil, &user); err == nil {
+		t.Fatal("get() expected an error for a cross-host redirect")
+	}
+	if leaked {
+		t.Fatal("PRIVATE-TOKEN was sent to the redirect target")
+	}
+}
@@ -0,0 +1,51 @@
+package git
+
+import (
+	"strings"
+
+	"fresh/internal/domain"
+)
+
+// commitLogLimit caps incoming and outgoing lists so a long-neglected clone
+// does not stall the view.
+const commitLogLimit = 200
+
+// CommitLog is what the upstream has that HEAD does not, and the reverse.
+type CommitLog struct {
+	Upstream string
+	Incoming []domain.Commit
+	Outgoing []domain.Commit
+}
+
+func GetCommitLog(repoPath string) CommitLog {
+	commits := CommitLog{Upstream: GetUpstream(repoPath)}
+	if commits.Upstream == "" {
+		return commits
+	}
+
+	Parallel(
+		func() { commits.Incoming = ListCommits(repoPath, "HEAD..@{u}", commitLogLimit) },
+		func() { commits.Outgoing = ListCommits(repoPath, "@{u}..HEAD", commitLogLimit) },
+	)
+	return commits
+}
+
+// CommitDiffStat returns the --stat summary for a single commit.
+func CommitDiffStat(repoPath string, hash string) (string, error) {
+	return showCommit(repoPath, "--stat", hash)
+}
+
+// CommitDiff returns the stat summary followed by the full patch.
+func CommitDiff(repoPath string, hash string) (string, error) {
+	return showCommit(repoPath, "--patch-with-stat", hash)
+}
+
+func showCommit(repoPath string, mode string, hash string) (string, error) {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "show", "--no-color", "--format=", mode, hash, "--")
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", commandError(output, err)
+	}
+	return strings.Trim(string(output), "\n"), nil
+}
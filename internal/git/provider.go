@@ -0,0 +1,205 @@
+package git
+
+import (
+	"errors"
+	"fresh/internal/config"
+	"fresh/internal/domain"
+	"fresh/internal/pullrequests"
+	"sort"
+)
+
+// PullRequestProvider serves the pull requests of repositories hosted on
+// one kind of forge, such as GitHub or GitLab.
+type PullRequestProvider interface {
+	// Name identifies the provider, such as "github".
+	Name() string
+	// Match reports whether the provider serves remoteURL and where the
+	// remote points.
+	Match(remoteURL string) (RemoteLocation, bool)
+	// SyncPullRequests counts the open pull requests of repositories on
+	// host, keyed by path, and classifies the user's own.
+	SyncPullRequests(host string, reposByPath map[string]RemoteLocation) (map[string]domain.PullRequestState, []pullrequests.Snapshot, error)
+	// ListPullRequests lists a repository's open pull requests.
+	ListPullRequests(location RemoteLocation) ([]domain.PullRequestDetails, error)
+	// CurrentUser returns the login of the authenticated user on host, or
+	// "" when it cannot be found.
+	CurrentUser(host string) string
+}
+
+var ErrNoWebURL = errors.New("no web page is known for this remote")
+
+// PullRequestGetter is implemented by providers that can show a single
+// pull request in full, with each check and reviewer.
+type PullRequestGetter interface {
+	GetPullRequest(location RemoteLocation, number int) (domain.PullRequest, error)
+}
+
+// PullRequestLinker is implemented by providers that know where a pull
+// request's web page is.
+type PullRequestLinker interface {
+	PullRequestURL(location RemoteLocation, number int) string
+}
+
+// ProviderRegistry picks the provider for each repository by its remote.
+// Providers are asked in order, so a more specific one should come first.
+type ProviderRegistry struct {
+	providers []PullRequestProvider
+}
+
+func NewProviderRegistry(providers ...PullRequestProvider) *ProviderRegistry {
+	return &ProviderRegistry{providers: providers}
+}
+
+// DefaultProviders returns the registry of every built-in provider for the
+// hosts cfg configures, with GitHub served by gh or by the API client as
+// cfg chooses. GitLab and Gitea come before GitHub so a configured host
+// wins over the github. name prefix.
+func DefaultProviders(cfg *config.Config) *ProviderRegistry {
+	var github PullRequestProvider = GhPullRequestService{Config: cfg}
+	if cfg.GitHub.Client == config.GitHubClientAPI {
+		github = GitHubAPIService{Config: cfg}
+	}
+	return NewProviderRegistry(
+		GitLabMergeRequestService{Config: cfg},
+		GiteaPullRequestService{Config: cfg},
+		github,
+	)
+}
+
+// Register adds provider after the existing ones.
+func (r *ProviderRegistry) Register(provider PullRequestProvider) {
+	r.providers = append(r.providers, provider)
+}
+
+// ProviderFor returns the provider serving remoteURL and where the remote
+// points.
+func (r *ProviderRegistry) ProviderFor(remoteURL string) (PullRequestProvider, RemoteLocation, bool) {
+	index, location, ok := r.match(remoteURL)
+	if !ok {
+		return nil, RemoteLocation{}, false
+	}
+	return r.providers[index], location, true
+}
+
+// providerHost groups the repositories one provider syncs in one go.
+type providerHost struct {
+	provider int
+	host     string
+}
+
+// GetPullRequestSync syncs every repository through its provider, one host
+// at a time, so an unreachable host only marks its own repositories as
+// failed. Repositories no provider serves stay PullRequestUnavailable.
+// Providers that report their rate limit leave the tightest in RateLimit.
+func (r *ProviderRegistry) GetPullRequestSync(repos []domain.Repository) PullRequestSync {
+	result := PullRequestSync{States: make(map[string]domain.PullRequestState, len(repos))}
+
+	groups := make(map[providerHost]map[string]RemoteLocation)
+	for _, repo := range repos {
+		index, location, ok := r.match(repo.RemoteURL)
+		if !ok {
+			result.States[repo.Path] = domain.PullRequestUnavailable{}
+			continue
+		}
+		result.States[repo.Path] = domain.PullRequestCount{}
+		key := providerHost{provider: index, host: location.Host}
+		if groups[key] == nil {
+			groups[key] = make(map[string]RemoteLocation)
+		}
+		groups[key][repo.Path] = location
+	}
+
+	for key, reposByPath := range groups {
+		provider := r.providers[key.provider]
+		states, tracked, err := provider.SyncPullRequests(key.host, reposByPath)
+		if reporter, ok := provider.(RateLimitReporter); ok {
+			if limit, ok := reporter.RateLimit(key.host); ok {
+				result.RateLimit = tighterRateLimit(result.RateLimit, limit)
+			}
+		}
+		if err != nil {
+			markReposError(result.States, slugsByPath(reposByPath), err)
+			continue
+		}
+		for path, state := range states {
+			result.States[path] = state
+		}
+		result.Tracked = append(result.Tracked, tracked...)
+	}
+
+	sort.Slice(result.Tracked, func(i, j int) bool {
+		return result.Tracked[i].Key.String() < result.Tracked[j].Key.String()
+	})
+	return result
+}
+
+// GetRepositoryPullRequests lists repo's open pull requests, the user's own
+// first.
+func (r *ProviderRegistry) GetRepositoryPullRequests(repo domain.Repository) ([]domain.PullRequestDetails, error) {
+	provider, location, ok := r.ProviderFor(repo.RemoteURL)
+	if !ok {
+		return nil, ErrPullRequestDetailsUnsupported
+	}
+	pullRequests, err := provider.ListPullRequests(location)
+	if err != nil {
+		return nil, err
+	}
+	sortPullRequestDetails(pullRequests)
+	return pullRequests, nil
+}
+
+// GetRepositoryPullRequest shows pull request number of repo in full.
+func (r *ProviderRegistry) GetRepositoryPullRequest(repo domain.Repository, number int) (domain.PullRequest, error) {
+	provider, location, ok := r.ProviderFor(repo.RemoteURL)
+	if !ok {
+		return domain.PullRequest{}, ErrPullRequestDetailsUnsupported
+	}
+	getter, ok := provider.(PullRequestGetter)
+	if !ok {
+		return domain.PullRequest{}, ErrPullRequestDetailsUnsupported
+	}
+	return getter.GetPullRequest(location, number)
+}
+
+// RepositoryWebURL returns the web page of repo's remote. Remotes no
+// provider serves still get one when they parse, since most forges serve
+// the repository at its slug.
+func (r *ProviderRegistry) RepositoryWebURL(repo domain.Repository) (string, error) {
+	if _, location, ok := r.ProviderFor(repo.RemoteURL); ok {
+		return location.WebURL(), nil
+	}
+	if location, ok := ResolveRemoteURL(nil, repo.RemoteURL); ok {
+		return location.WebURL(), nil
+	}
+	return "", ErrNoWebURL
+}
+
+// PullRequestWebURL returns the web page of pull request number of repo.
+func (r *ProviderRegistry) PullRequestWebURL(repo domain.Repository, number int) (string, error) {
+	provider, location, ok := r.ProviderFor(repo.RemoteURL)
+	if !ok {
+		return "", ErrNoWebURL
+	}
+	linker, ok := provider.(PullRequestLinker)
+	if !ok {
+		return "", ErrNoWebURL
+	}
+	return linker.PullRequestURL(location, number), nil
+}
+
+func (r *ProviderRegistry) match(remoteURL string) (int, RemoteLocation, bool) {
+	for i, provider := range r.providers {
+		if location, ok := provider.Match(remoteURL); ok {
+			return i, location, true
+		}
+	}
+	return 0, RemoteLocation{}, false
+}
+
+func slugsByPath(reposByPath map[string]RemoteLocation) map[string]string {
+	slugs := make(map[string]string, len(reposByPath))
+	for path, location := range reposByPath {
+		slugs[path] = location.Slug()
+	}
+	return slugs
+}
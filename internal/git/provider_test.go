@@ -0,0 +1,238 @@
+package git
+
+import (
+	"errors"
+	"fresh/internal/domain"
+	"fresh/internal/pullrequests"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeProvider serves remotes on hosts, failing syncs for the hosts in
+// failing, and records which repositories each sync was given.
+type fakeProvider struct {
+	name    string
+	hosts   []string
+	failing map[string]bool
+	details []domain.PullRequestDetails
+	budgets map[string]RateLimit
+
+	mu     sync.Mutex
+	synced map[string][]string
+}
+
+func (p *fakeProvider) Name() string { return p.name }
+
+func (p *fakeProvider) Match(remoteURL string) (RemoteLocation, bool) {
+	location, ok := ParseRemoteURL(remoteURL)
+	if !ok {
+		return RemoteLocation{}, false
+	}
+	for _, host := range p.hosts {
+		if location.Host == host {
+			return location, true
+		}
+	}
+	return RemoteLocation{}, false
+}
+
+func (p *fakeProvider) SyncPullRequests(host string, reposByPath map[string]RemoteLocation) (map[string]domain.PullRequestState, []pullrequests.Snapshot, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.synced == nil {
+		p.synced = make(map[string][]string)
+	}
+	for path := range reposByPath {
+		p.synced[host] = append(p.synced[host], path)
+	}
+	if p.failing[host] {
+		return nil, nil, errors.New(host + " unreachable")
+	}
+
+	states := make(map[string]domain.PullRequestState, len(reposByPath))
+	var tracked []pullrequests.Snapshot
+	for path, location := range reposByPath {
+		states[path] = domain.PullRequestCount{Open: 1, MyOpen: 1, MyReady: 1}
+		tracked = append(tracked, pullrequests.Snapshot{
+			Key:    pullrequests.Key{Owner: location.Owner, Repo: location.Name, Number: 1},
+			Status: pullrequests.StatusReady,
+		})
+	}
+	return states, tracked, nil
+}
+
+func (p *fakeProvider) ListPullRequests(RemoteLocation) ([]domain.PullRequestDetails, error) {
+	return append([]domain.PullRequestDetails(nil), p.details...), nil
+}
+
+func (p *fakeProvider) CurrentUser(string) string { return "octocat" }
+
+func (p *fakeProvider) RateLimit(host string) (RateLimit, bool) {
+	budget, ok := p.budgets[host]
+	return budget, ok
+}
+
+func TestProviderRegistrySyncsEachRepoThroughItsProvider(t *testing.T) {
+	t.Parallel()
+
+	hub := &fakeProvider{name: "hub", hosts: []string{"hub.example.com", "hub.acme.corp"}, failing: map[string]bool{"hub.acme.corp": true}}
+	lab := &fakeProvider{name: "lab", hosts: []string{"lab.example.com"}}
+	registry := NewProviderRegistry(hub)
+	registry.Register(lab)
+
+	repos := []domain.Repository{
+		{Path: "/src/api", RemoteURL: "git@hub.example.com:team/api.git"},
+		{Path: "/src/web", RemoteURL: "https://hub.example.com/team/web"},
+		{Path: "/src/internal", RemoteURL: "git@hub.acme.corp:corp/internal.git"},
+		{Path: "/src/infra", RemoteURL: "https://lab.example.com/ops/infra.git"},
+		{Path: "/src/local", RemoteURL: ""},
+		{Path: "/src/elsewhere", RemoteURL: "git@bitbucket.org:team/elsewhere.git"},
+	}
+	sync := registry.GetPullRequestSync(repos)
+
+	if got := len(hub.synced["hub.example.com"]); got != 2 {
+		t.Errorf("hub.example.com synced %d repositories, want both in one call", got)
+	}
+	if got := lab.synced["lab.example.com"]; len(got) != 1 || got[0] != "/src/infra" {
+		t.Errorf("lab synced %v, want [/src/infra]", got)
+	}
+
+	want := domain.PullRequestCount{Open: 1, MyOpen: 1, MyReady: 1}
+	for _, path := range []string{"/src/api", "/src/web", "/src/infra"} {
+		if sync.States[path] != want {
+			t.Errorf("state[%s] = %#v, want %#v", path, sync.States[path], want)
+		}
+	}
+	if state, ok := sync.States["/src/internal"].(domain.PullRequestError); !ok || !strings.Contains(state.Message, "unreachable") {
+		t.Errorf("state[/src/internal] = %#v, want the host's error", sync.States["/src/internal"])
+	}
+	for _, path := range []string{"/src/local", "/src/elsewhere"} {
+		if _, ok := sync.States[path].(domain.PullRequestUnavailable); !ok {
+			t.Errorf("state[%s] = %#v, want unavailable", path, sync.States[path])
+		}
+	}
+
+	if len(sync.Tracked) != 3 {
+		t.Fatalf("tracked = %+v, want 3 snapshots", sync.Tracked)
+	}
+	for i := 1; i < len(sync.Tracked); i++ {
+		if sync.Tracked[i-1].Key.String() > sync.Tracked[i].Key.String() {
+			t.Errorf("tracked not sorted: %+v", sync.Tracked)
+		}
+	}
+}
+
+func TestProviderRegistryPrefersEarlierProviders(t *testing.T) {
+	t.Parallel()
+
+	first := &fakeProvider{name: "first", hosts: []string{"git.example.com"}}
+	second := &fakeProvider{name: "second", hosts: []string{"git.example.com"}}
+	registry := NewProviderRegistry(first, second)
+
+	provider, location, ok := registry.ProviderFor("git@git.example.com:team/api.git")
+	if !ok || provider.Name() != "first" || location.Slug() != "team/api" {
+		t.Fatalf("ProviderFor() = %v, %+v, %v, want first for team/api", provider, location, ok)
+	}
+	if _, _, ok := registry.ProviderFor("git@other.example.com:team/api.git"); ok {
+		t.Error("ProviderFor() matched a host no provider serves")
+	}
+}
+
+func TestProviderRegistryGetRepositoryPullRequests(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	hub := &fakeProvider{
+		name:  "hub",
+		hosts: []string{"hub.example.com"},
+		details: []domain.PullRequestDetails{
+			{Number: 1, UpdatedAt: now.Add(-time.Hour)},
+			{Number: 2, UpdatedAt: now},
+			{Number: 3, UpdatedAt: now.Add(-2 * time.Hour), IsMine: true},
+			{Number: 4, UpdatedAt: now.Add(-3 * time.Hour), ReviewRequested: true},
+		},
+	}
+	registry := NewProviderRegistry(hub)
+
+	pullRequests, err := registry.GetRepositoryPullRequests(domain.Repository{RemoteURL: "git@hub.example.com:team/api.git"})
+	if err != nil {
+		t.Fatalf("GetRepositoryPullRequests() unexpected error: %v", err)
+	}
+	var order []int
+	for _, pr := range pullRequests {
+		order = append(order, pr.Number)
+	}
+	if len(order) != 4 || order[0] != 3 || order[1] != 4 || order[2] != 2 || order[3] != 1 {
+		t.Errorf("order = %v, want mine, then awaiting review, then most recently updated [3 4 2 1]", order)
+	}
+
+	_, err = registry.GetRepositoryPullRequests(domain.Repository{RemoteURL: "git@bitbucket.org:team/api.git"})
+	if !errors.Is(err, ErrPullRequestDetailsUnsupported) {
+		t.Errorf("GetRepositoryPullRequests() error = %v, want ErrPullRequestDetailsUnsupported", err)
+	}
+}
+
+// detailedProvider is a fakeProvider that can also show one pull request.
+type detailedProvider struct {
+	*fakeProvider
+}
+
+func (p detailedProvider) GetPullRequest(location RemoteLocation, number int) (domain.PullRequest, error) {
+	return domain.PullRequest{Number: number, Title: location.Slug()}, nil
+}
+
+func TestProviderRegistryGetRepositoryPullRequest(t *testing.T) {
+	t.Parallel()
+
+	registry := NewProviderRegistry(
+		detailedProvider{&fakeProvider{name: "hub", hosts: []string{"hub.example.com"}}},
+		&fakeProvider{name: "lab", hosts: []string{"lab.example.com"}},
+	)
+
+	pr, err := registry.GetRepositoryPullRequest(domain.Repository{RemoteURL: "git@hub.example.com:team/api.git"}, 7)
+	if err != nil {
+		t.Fatalf("GetRepositoryPullRequest() unexpected error: %v", err)
+	}
+	if pr.Number != 7 || pr.Title != "team/api" {
+		t.Errorf("GetRepositoryPullRequest() = %+v, want #7 of team/api", pr)
+	}
+
+	for _, remoteURL := range []string{"git@lab.example.com:team/api.git", "git@bitbucket.org:team/api.git"} {
+		_, err := registry.GetRepositoryPullRequest(domain.Repository{RemoteURL: remoteURL}, 7)
+		if !errors.Is(err, ErrPullRequestDetailsUnsupported) {
+			t.Errorf("GetRepositoryPullRequest(%s) error = %v, want ErrPullRequestDetailsUnsupported", remoteURL, err)
+		}
+	}
+}
+
+func TestProviderRegistryReportsTightestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	hub := &fakeProvider{
+		name:  "hub",
+		hosts: []string{"hub.example.com", "hub.acme.corp"},
+		budgets: map[string]RateLimit{
+			"hub.example.com": {Host: "hub.example.com", Limit: 5000, Remaining: 4000},
+			"hub.acme.corp":   {Host: "hub.acme.corp", Limit: 30, Remaining: 3},
+		},
+	}
+	lab := &fakeProvider{name: "lab", hosts: []string{"lab.example.com"}}
+	registry := NewProviderRegistry(hub, lab)
+
+	sync := registry.GetPullRequestSync([]domain.Repository{
+		{Path: "/src/api", RemoteURL: "git@hub.example.com:team/api.git"},
+		{Path: "/src/internal", RemoteURL: "git@hub.acme.corp:corp/internal.git"},
+		{Path: "/src/infra", RemoteURL: "https://lab.example.com/ops/infra.git"},
+	})
+	if sync.RateLimit.Host != "hub.acme.corp" || sync.RateLimit.Remaining != 3 {
+		t.Errorf("RateLimit = %+v, want the budget with the least left", sync.RateLimit)
+	}
+
+	sync = registry.GetPullRequestSync([]domain.Repository{{Path: "/src/infra", RemoteURL: "https://lab.example.com/ops/infra.git"}})
+	if sync.RateLimit.Known() {
+		t.Errorf("RateLimit = %+v, want unknown when no provider reports one", sync.RateLimit)
+	}
+}
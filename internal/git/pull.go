@@ -9,10 +9,18 @@ import (
 	"os/exec"
 	"strings"
 	"sync"
+	"time"
 )
 
 func Pull(repoPath string, lineCallback func(string)) domain.CommandOutcome {
-	cmd := createCommand(defaultConfig.Timeout.Pull, "git", "pull", "--rebase", "--progress")
+	return runStreamedCommand(repoPath, defaultConfig.Timeout.Pull, lineCallback, "pull", "--rebase", "--progress")
+}
+
+// runStreamedCommand runs git with args, forwarding each line of output to
+// lineCallback as it arrives. Progress output that redraws with carriage
+// returns is split into separate lines.
+func runStreamedCommand(repoPath string, timeout time.Duration, lineCallback func(string), args ...string) domain.CommandOutcome {
+	cmd := createCommand(timeout, "git", args...)
 	cmd.Dir = repoPath
 
 	stderrPipe, err := cmd.StderrPipe()
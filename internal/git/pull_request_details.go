@@ -10,19 +10,41 @@ import (
 	"time"
 )
 
-var ErrPullRequestDetailsUnsupported = errors.New("pull requests are currently only supported for GitHub repositories")
+var ErrPullRequestDetailsUnsupported = errors.New("pull requests are currently only supported for GitHub, GitLab and Gitea repositories")
 
 const githubLoginCacheTTL = time.Hour
 
-var cachedGitHubLogin githubLoginCache
+var cachedGitHubLogins hostLoginCaches
 
-type githubLoginCache struct {
+type loginCache struct {
 	mu          sync.Mutex
 	login       string
 	expiresAt   time.Time
 	initialized bool
 }
 
+// hostLoginCaches keeps one loginCache per host, since the user can be
+// logged in to several instances under different names.
+type hostLoginCaches struct {
+	mu     sync.Mutex
+	byHost map[string]*loginCache
+}
+
+func (c *hostLoginCaches) forHost(host string) *loginCache {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.byHost == nil {
+		c.byHost = make(map[string]*loginCache)
+	}
+	cache, ok := c.byHost[host]
+	if !ok {
+		cache = &loginCache{}
+		c.byHost[host] = cache
+	}
+	return cache
+}
+
 type ghRepoPullRequestRow struct {
 	Number            int                    `json:"number"`
 	Title             string                 `json:"title"`
@@ -31,6 +53,15 @@ type ghRepoPullRequestRow struct {
 	Author            struct {
 		Login string `json:"login"`
 	} `json:"author"`
+	// ReviewRequests names the users asked to review; team requests have
+	// no login.
+	ReviewRequests []ghUser `json:"reviewRequests"`
+}
+
+// ghUser is a user, or a team when only Name is set.
+type ghUser struct {
+	Login string `json:"login"`
+	Name  string `json:"name"`
 }
 
 type ghStatusCheckContext struct {
@@ -39,22 +70,22 @@ type ghStatusCheckContext struct {
 	State      string `json:"state"`
 }
 
-func GetRepositoryPullRequests(repo domain.Repository) ([]domain.PullRequestDetails, error) {
-	owner, name, ok := parseGitHubRemote(repo.RemoteURL)
-	if !ok {
-		return nil, ErrPullRequestDetailsUnsupported
+// ListPullRequests lists the open pull requests through gh, which
+// includes each one's checks.
+func (GhPullRequestService) ListPullRequests(location RemoteLocation) ([]domain.PullRequestDetails, error) {
+	ownerRepo := location.Slug()
+	if !strings.EqualFold(location.Host, defaultGitHubHost) {
+		ownerRepo = location.Host + "/" + ownerRepo
 	}
-
-	ownerRepo := owner + "/" + name
 	args := []string{
 		"pr", "list",
 		"--repo", ownerRepo,
 		"--state", "open",
 		"--limit", "200",
-		"--json", "number,title,updatedAt,author,statusCheckRollup",
+		"--json", "number,title,updatedAt,author,statusCheckRollup,reviewRequests",
 	}
 
-	cmd := createCommand(defaultConfig.Timeout.Default, "gh", args...)
+	cmd := ghCommand(location.Host, args...)
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, normalizeGhError(err)
@@ -65,35 +96,85 @@ func GetRepositoryPullRequests(repo domain.Repository) ([]domain.PullRequestDeta
 		return nil, err
 	}
 
-	currentUser := queryGitHubLogin()
+	return gitHubPullRequestDetails(rows, queryGitHubLogin(location.Host)), nil
+}
+
+func gitHubPullRequestDetails(rows []ghRepoPullRequestRow, currentUser string) []domain.PullRequestDetails {
 	pullRequests := make([]domain.PullRequestDetails, 0, len(rows))
 	for _, row := range rows {
 		updatedAt, _ := time.Parse(time.RFC3339, row.UpdatedAt)
+		reviewRequested := false
+		for _, request := range row.ReviewRequests {
+			if currentUser != "" && strings.EqualFold(strings.TrimSpace(request.Login), currentUser) {
+				reviewRequested = true
+			}
+		}
 		pullRequests = append(pullRequests, domain.PullRequestDetails{
-			Number:    row.Number,
-			Title:     row.Title,
-			IsMine:    currentUser != "" && strings.EqualFold(strings.TrimSpace(row.Author.Login), currentUser),
-			UpdatedAt: updatedAt,
-			Checks:    summarizePullRequestChecks(row.StatusCheckRollup),
+			Number:          row.Number,
+			Title:           row.Title,
+			IsMine:          currentUser != "" && strings.EqualFold(strings.TrimSpace(row.Author.Login), currentUser),
+			ReviewRequested: reviewRequested,
+			UpdatedAt:       updatedAt,
+			Checks:          summarizePullRequestChecks(row.StatusCheckRollup),
 		})
 	}
+	return pullRequests
+}
 
+// sortPullRequestDetails puts the user's own pull requests first, then
+// those waiting on their review, then the most recently updated.
+func sortPullRequestDetails(pullRequests []domain.PullRequestDetails) {
 	sort.Slice(pullRequests, func(i, j int) bool {
 		if pullRequests[i].IsMine != pullRequests[j].IsMine {
 			return pullRequests[i].IsMine
 		}
+		if pullRequests[i].ReviewRequested != pullRequests[j].ReviewRequested {
+			return pullRequests[i].ReviewRequested
+		}
 		if !pullRequests[i].UpdatedAt.Equal(pullRequests[j].UpdatedAt) {
 			return pullRequests[i].UpdatedAt.After(pullRequests[j].UpdatedAt)
 		}
 		return pullRequests[i].Number > pullRequests[j].Number
 	})
+}
+
+// foldReviewers keeps each reviewer's latest review, in the order they
+// first reviewed; a comment does not replace an earlier verdict. Requested
+// reviewers are pending, including those asked to review again.
+func foldReviewers(reviews []domain.Reviewer, requested []string) []domain.Reviewer {
+	var order []string
+	byLogin := make(map[string]domain.Reviewer)
+	set := func(reviewer domain.Reviewer) {
+		key := strings.ToLower(strings.TrimSpace(reviewer.Login))
+		if key == "" {
+			return
+		}
+		existing, ok := byLogin[key]
+		if !ok {
+			order = append(order, key)
+		} else if reviewer.State == domain.ReviewCommented && existing.State != domain.ReviewCommented {
+			return
+		}
+		byLogin[key] = reviewer
+	}
 
-	return pullRequests, nil
+	for _, review := range reviews {
+		set(review)
+	}
+	for _, login := range requested {
+		set(domain.Reviewer{Login: login, State: domain.ReviewPending})
+	}
+
+	reviewers := make([]domain.Reviewer, 0, len(order))
+	for _, key := range order {
+		reviewers = append(reviewers, byLogin[key])
+	}
+	return reviewers
 }
 
-func queryGitHubLogin() string {
-	return cachedGitHubLogin.get(time.Now(), githubLoginCacheTTL, func() (string, error) {
-		cmd := createCommand(defaultConfig.Timeout.Default, "gh", "api", "user", "--jq", ".login")
+func queryGitHubLogin(host string) string {
+	return cachedGitHubLogins.forHost(host).get(time.Now(), githubLoginCacheTTL, func() (string, error) {
+		cmd := ghCommand(host, "api", "user", "--jq", ".login")
 		output, err := cmd.Output()
 		if err != nil {
 			return "", err
@@ -102,7 +183,7 @@ func queryGitHubLogin() string {
 	})
 }
 
-func (c *githubLoginCache) get(now time.Time, ttl time.Duration, loader func() (string, error)) string {
+func (c *loginCache) get(now time.Time, ttl time.Duration, loader func() (string, error)) string {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
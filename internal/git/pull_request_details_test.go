@@ -93,10 +93,10 @@ func TestSummarizePullRequestChecks(t *testing.T) {
 	}
 }
 
-func TestGitHubLoginCacheGet_UsesCachedValueWithinTTL(t *testing.T) {
+func TestLoginCacheGet_UsesCachedValueWithinTTL(t *testing.T) {
 	t.Parallel()
 
-	var cache githubLoginCache
+	var cache loginCache
 	now := time.Date(2026, time.January, 2, 10, 0, 0, 0, time.UTC)
 	loadCalls := 0
 
@@ -119,10 +119,10 @@ func TestGitHubLoginCacheGet_UsesCachedValueWithinTTL(t *testing.T) {
 	}
 }
 
-func TestGitHubLoginCacheGet_RefreshesAfterTTL(t *testing.T) {
+func TestLoginCacheGet_RefreshesAfterTTL(t *testing.T) {
 	t.Parallel()
 
-	var cache githubLoginCache
+	var cache loginCache
 	now := time.Date(2026, time.January, 2, 10, 0, 0, 0, time.UTC)
 	loadCalls := 0
 
@@ -148,10 +148,10 @@ func TestGitHubLoginCacheGet_RefreshesAfterTTL(t *testing.T) {
 	}
 }
 
-func TestGitHubLoginCacheGet_CachesEmptyValueOnLoaderError(t *testing.T) {
+func TestLoginCacheGet_CachesEmptyValueOnLoaderError(t *testing.T) {
 	t.Parallel()
 
-	var cache githubLoginCache
+	var cache loginCache
 	now := time.Date(2026, time.January, 2, 10, 0, 0, 0, time.UTC)
 	loadCalls := 0
 
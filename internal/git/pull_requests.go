@@ -1,20 +1,34 @@
 package git
 
 import (
-	"encoding/json"
 	"fmt"
+	"fresh/internal/config"
 	"fresh/internal/domain"
 	"fresh/internal/pullrequests"
+	"os"
 	"os/exec"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
-type GhPullRequestService struct{}
+const defaultGitHubHost = "github.com"
+
+// GhPullRequestService fills the pull request columns for repositories
+// hosted on GitHub or GitHub Enterprise Server through the gh CLI.
+type GhPullRequestService struct {
+	// Config lists the GitHub hosts and, for SSH aliases, every other
+	// forge's.
+	Config *config.Config
+}
 
 type PullRequestSync struct {
 	States  map[string]domain.PullRequestState
 	Tracked []pullrequests.Snapshot
+	// RateLimit is the tightest API budget a provider reported, unknown
+	// when none did.
+	RateLimit RateLimit
 }
 
 type myPullRequestSummary struct {
@@ -25,20 +39,6 @@ type myPullRequestSummary struct {
 	MyChecks  int
 }
 
-type ghSearchPRRow struct {
-	Repository struct {
-		NameWithOwner string `json:"nameWithOwner"`
-	} `json:"repository"`
-}
-
-type gqlSearchResponse struct {
-	Data struct {
-		Search struct {
-			Nodes []gqlPullRequestNode `json:"nodes"`
-		} `json:"search"`
-	} `json:"data"`
-}
-
 type gqlPullRequestNode struct {
 	Repository struct {
 		NameWithOwner string `json:"nameWithOwner"`
@@ -70,45 +70,58 @@ type gqlStatusContextNode struct {
 	State      string `json:"state"`
 }
 
-func GetPullRequestSync(repos []domain.Repository) PullRequestSync {
-	return GhPullRequestService{}.GetPullRequestSync(repos)
+func (GhPullRequestService) Name() string { return "github" }
+
+func (s GhPullRequestService) Match(remoteURL string) (RemoteLocation, bool) {
+	return parseGitHubRemote(s.Config, remoteURL)
 }
 
-func (GhPullRequestService) GetPullRequestSync(repos []domain.Repository) PullRequestSync {
-	states := make(map[string]domain.PullRequestState, len(repos))
-	result := PullRequestSync{
-		States:  states,
-		Tracked: nil,
-	}
+func (GhPullRequestService) PullRequestURL(location RemoteLocation, number int) string {
+	return location.WebURL() + "/pull/" + strconv.Itoa(number)
+}
 
-	githubByPath, ownerRepos := collectGitHubRepos(repos)
-	for _, repo := range repos {
-		if _, ok := githubByPath[repo.Path]; !ok {
-			states[repo.Path] = domain.PullRequestUnavailable{}
-			continue
-		}
-		states[repo.Path] = domain.PullRequestCount{}
-	}
+func (GhPullRequestService) CurrentUser(host string) string {
+	return queryGitHubLogin(host)
+}
 
-	if len(ownerRepos) == 0 {
-		return result
+// SyncPullRequests counts open pull requests and classifies the user's own
+// with GraphQL searches, batched and paginated through gh.
+func (GhPullRequestService) SyncPullRequests(host string, reposByPath map[string]RemoteLocation) (map[string]domain.PullRequestState, []pullrequests.Snapshot, error) {
+	run := ghGraphQL(host)
+	countOpen := func(ownerRepos []string) (map[string]int, map[string]bool, error) {
+		return searchOpenPullRequestCounts(run, ownerRepos)
 	}
+	return syncGitHubHost(host, reposByPath, countOpen, run)
+}
 
-	openCounts, err := queryOpenPullRequestCounts(ownerRepos)
-	if err != nil {
-		result.States = markGitHubReposError(states, githubByPath, err)
-		return result
-	}
+func (GhPullRequestService) RateLimit(host string) (RateLimit, bool) {
+	return gitHubRateLimits.current(host, time.Now())
+}
 
-	tracked, mySummaries, err := queryMyPullRequests(ownerRepos)
-	if err != nil {
-		result.States = markGitHubReposError(states, githubByPath, err)
-		return result
+// sortedOwnerRepos returns the distinct owner/repo slugs of reposByPath.
+func sortedOwnerRepos(reposByPath map[string]RemoteLocation) []string {
+	slugSet := make(map[string]struct{}, len(reposByPath))
+	for _, location := range reposByPath {
+		slugSet[location.Slug()] = struct{}{}
 	}
+	ownerRepos := make([]string, 0, len(slugSet))
+	for slug := range slugSet {
+		ownerRepos = append(ownerRepos, slug)
+	}
+	sort.Strings(ownerRepos)
+	return ownerRepos
+}
 
-	for path, ownerRepo := range githubByPath {
+// gitHubPullRequestStates builds the state of every repository from the
+// counts and summaries keyed by owner/repo.
+func gitHubPullRequestStates(reposByPath map[string]RemoteLocation, openCounts map[string]int, mySummaries map[string]myPullRequestSummary, reviewRequests map[string]int, partial map[string]bool) map[string]domain.PullRequestState {
+	states := make(map[string]domain.PullRequestState, len(reposByPath))
+	for path, location := range reposByPath {
+		ownerRepo := location.Slug()
 		state := domain.PullRequestCount{}
 		state.Open = openCounts[ownerRepo]
+		state.ReviewRequested = reviewRequests[ownerRepo]
+		state.Partial = partial[ownerRepo]
 		if summary, ok := mySummaries[ownerRepo]; ok {
 			state.MyOpen = summary.MyOpen
 			state.MyReady = summary.MyReady
@@ -118,62 +131,44 @@ func (GhPullRequestService) GetPullRequestSync(repos []domain.Repository) PullRe
 		}
 		states[path] = state
 	}
-
-	result.Tracked = tracked
-	return result
+	return states
 }
 
-func collectGitHubRepos(repos []domain.Repository) (map[string]string, []string) {
-	byPath := make(map[string]string, len(repos))
-	ownerRepoSet := make(map[string]struct{}, len(repos))
-
-	for _, repo := range repos {
-		owner, name, ok := parseGitHubRemote(repo.RemoteURL)
-		if !ok {
-			continue
+// isGitHubHost reports whether host is github.com or a configured GitHub
+// Enterprise Server.
+func isGitHubHost(cfg config.GitHubConfig, host string) bool {
+	for _, configured := range cfg.Hosts {
+		if strings.EqualFold(configured, host) {
+			return true
 		}
-		ownerRepo := owner + "/" + name
-		byPath[repo.Path] = ownerRepo
-		ownerRepoSet[ownerRepo] = struct{}{}
-	}
-
-	ownerRepos := make([]string, 0, len(ownerRepoSet))
-	for ownerRepo := range ownerRepoSet {
-		ownerRepos = append(ownerRepos, ownerRepo)
 	}
-	sort.Strings(ownerRepos)
-
-	return byPath, ownerRepos
+	return false
 }
 
-func parseGitHubRemote(remoteURL string) (owner string, repo string, ok bool) {
-	if remoteURL == "" {
-		return "", "", false
+// parseGitHubRemote resolves SSH aliases before checking the host, so
+// git@github-work:org/repo is recognised when ~/.ssh/config points
+// github-work at a GitHub host.
+func parseGitHubRemote(cfg *config.Config, remoteURL string) (RemoteLocation, bool) {
+	location, ok := ResolveRemoteURL(cfg, remoteURL)
+	if !ok || !isGitHubHost(cfg.GitHub, location.Host) || strings.Contains(location.Owner, "/") {
+		return RemoteLocation{}, false
 	}
+	return location, true
+}
 
-	normalized := strings.TrimSpace(remoteURL)
-	normalized = strings.TrimSuffix(normalized, ".git")
-
-	slug := ""
-	switch {
-	case strings.HasPrefix(normalized, "git@github.com:"):
-		slug = strings.TrimPrefix(normalized, "git@github.com:")
-	case strings.HasPrefix(normalized, "ssh://git@github.com/"):
-		slug = strings.TrimPrefix(normalized, "ssh://git@github.com/")
-	case strings.HasPrefix(normalized, "https://github.com/"):
-		slug = strings.TrimPrefix(normalized, "https://github.com/")
-	case strings.HasPrefix(normalized, "http://github.com/"):
-		slug = strings.TrimPrefix(normalized, "http://github.com/")
-	default:
-		return "", "", false
+// ghCommand runs gh against host. gh api takes --hostname; the other
+// commands read the host from GH_HOST.
+func ghCommand(host string, args ...string) *exec.Cmd {
+	enterprise := host != "" && !strings.EqualFold(host, defaultGitHubHost)
+	if enterprise && len(args) > 0 && args[0] == "api" {
+		args = append([]string{"api", "--hostname", host}, args[1:]...)
 	}
 
-	parts := strings.Split(slug, "/")
-	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-		return "", "", false
+	cmd := createCommand(defaultConfig.Timeout.Default, "gh", args...)
+	if enterprise {
+		cmd.Env = append(os.Environ(), "GH_HOST="+host)
 	}
-
-	return parts[0], parts[1], true
+	return cmd
 }
 
 func parseNameWithOwner(nameWithOwner string) (owner string, repo string, ok bool) {
@@ -189,41 +184,21 @@ func parseNameWithOwner(nameWithOwner string) (owner string, repo string, ok boo
 	return parts[0], parts[1], true
 }
 
-func queryOpenPullRequestCounts(ownerRepos []string) (map[string]int, error) {
-	args := []string{"search", "prs", "--state", "open", "--limit", "100", "--json", "repository"}
-	for _, ownerRepo := range ownerRepos {
-		args = append(args, "--repo", ownerRepo)
-	}
-	args = append(args, "--")
-
-	cmd := createCommand(defaultConfig.Timeout.Default, "gh", args...)
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, normalizeGhError(err)
-	}
-
-	var rows []ghSearchPRRow
-	if err := json.Unmarshal(output, &rows); err != nil {
-		return nil, fmt.Errorf("failed to parse gh open-pr response: %w", err)
-	}
-
-	counts := make(map[string]int)
-	for _, row := range rows {
-		if row.Repository.NameWithOwner == "" {
-			continue
-		}
-		counts[row.Repository.NameWithOwner]++
-	}
-
-	return counts, nil
-}
-
-func queryMyPullRequests(ownerRepos []string) ([]pullrequests.Snapshot, map[string]myPullRequestSummary, error) {
-	queryText := "is:pr is:open author:@me " + strings.Join(prefixRepoQualifiers(ownerRepos), " ")
-
-	query := `
-query($q: String!) {
-  search(type: ISSUE, query: $q, first: 100) {
+// myPullRequestsQuery fetches what classifyMyPullRequest needs for the
+// pull requests a search finds.
+const myPullRequestsQuery = `
+query($q: String!, $after: String) {
+  rateLimit {
+    limit
+    remaining
+    resetAt
+  }
+  search(type: ISSUE, query: $q, first: 100, after: $after) {
+    issueCount
+    pageInfo {
+      hasNextPage
+      endCursor
+    }
     nodes {
       ... on PullRequest {
         repository {
@@ -260,26 +235,12 @@ query($q: String!) {
 }
 `
 
-	cmd := createCommand(
-		defaultConfig.Timeout.Default,
-		"gh", "api", "graphql",
-		"-f", "query="+query,
-		"-F", "q="+queryText,
-	)
-
-	output, err := cmd.Output()
-	if err != nil {
-		return nil, nil, normalizeGhError(err)
-	}
-
-	var response gqlSearchResponse
-	if err := json.Unmarshal(output, &response); err != nil {
-		return nil, nil, fmt.Errorf("failed to parse gh my-pr response: %w", err)
-	}
-
-	tracked := make([]pullrequests.Snapshot, 0, len(response.Data.Search.Nodes))
+// summarizeMyPullRequests classifies the user's pull requests and tallies
+// them per owner/repo.
+func summarizeMyPullRequests(nodes []gqlPullRequestNode) ([]pullrequests.Snapshot, map[string]myPullRequestSummary) {
+	tracked := make([]pullrequests.Snapshot, 0, len(nodes))
 	summaries := make(map[string]myPullRequestSummary)
-	for _, row := range response.Data.Search.Nodes {
+	for _, row := range nodes {
 		ownerRepo := row.Repository.NameWithOwner
 		owner, repo, ok := parseNameWithOwner(ownerRepo)
 		if !ok || row.Number <= 0 {
@@ -318,7 +279,7 @@ query($q: String!) {
 		return tracked[i].Key.String() < tracked[j].Key.String()
 	})
 
-	return tracked, summaries, nil
+	return tracked, summaries
 }
 
 func classifyMyPullRequest(row gqlPullRequestNode) pullrequests.Status {
@@ -404,14 +365,6 @@ func derefString(s *string) string {
 	return *s
 }
 
-func prefixRepoQualifiers(ownerRepos []string) []string {
-	qualifiers := make([]string, 0, len(ownerRepos))
-	for _, ownerRepo := range ownerRepos {
-		qualifiers = append(qualifiers, "repo:"+ownerRepo)
-	}
-	return qualifiers
-}
-
 func normalizeGhError(err error) error {
 	if err == nil {
 		return nil
@@ -427,13 +380,13 @@ func normalizeGhError(err error) error {
 	return err
 }
 
-func markGitHubReposError(states map[string]domain.PullRequestState, githubByPath map[string]string, err error) map[string]domain.PullRequestState {
+func markReposError(states map[string]domain.PullRequestState, reposByPath map[string]string, err error) map[string]domain.PullRequestState {
 	message := "gh integration unavailable"
 	if err != nil {
 		message = err.Error()
 	}
 
-	for path := range githubByPath {
+	for path := range reposByPath {
 		states[path] = domain.PullRequestError{Message: message}
 	}
 
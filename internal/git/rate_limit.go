@@ -0,0 +1,142 @@
+package git
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// lowRateLimitPercent is how much of its budget a host has left before
+// syncs start skipping low-priority queries.
+const lowRateLimitPercent = 10
+
+// RateLimit is the API budget a host reported for one of its rate limits,
+// such as GitHub's GraphQL or search limit.
+type RateLimit struct {
+	Host      string
+	Resource  string
+	Limit     int
+	Remaining int
+	ResetAt   time.Time
+}
+
+// Known reports whether a budget was reported at all.
+func (r RateLimit) Known() bool {
+	return r.Limit > 0
+}
+
+// Low reports whether less than lowRateLimitPercent of the budget is left.
+func (r RateLimit) Low() bool {
+	return r.Known() && r.Remaining*100 < r.Limit*lowRateLimitPercent
+}
+
+// Exhausted reports whether no requests are left until ResetAt.
+func (r RateLimit) Exhausted() bool {
+	return r.Known() && r.Remaining <= 0
+}
+
+// RateLimitReporter is implemented by providers that track the API budget
+// of the hosts they sync.
+type RateLimitReporter interface {
+	// RateLimit returns the tightest budget host reported that has not
+	// reset yet.
+	RateLimit(host string) (RateLimit, bool)
+}
+
+// tighterRateLimit returns whichever of a and b has the smaller share of
+// its budget left, preferring a known budget over an unknown one.
+func tighterRateLimit(a, b RateLimit) RateLimit {
+	if !b.Known() {
+		return a
+	}
+	if !a.Known() || b.Remaining*a.Limit < a.Remaining*b.Limit {
+		return b
+	}
+	return a
+}
+
+// rateLimitTracker remembers the last budget each host reported, per
+// resource, since one sync may draw on several of them.
+type rateLimitTracker struct {
+	mu      sync.Mutex
+	buckets map[string]map[string]RateLimit
+}
+
+// gitHubRateLimits is shared by gh and the API client.
+var gitHubRateLimits rateLimitTracker
+
+func (t *rateLimitTracker) record(limit RateLimit) {
+	if !limit.Known() {
+		return
+	}
+	host := strings.ToLower(limit.Host)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.buckets == nil {
+		t.buckets = make(map[string]map[string]RateLimit)
+	}
+	if t.buckets[host] == nil {
+		t.buckets[host] = make(map[string]RateLimit)
+	}
+	t.buckets[host][limit.Resource] = limit
+}
+
+// current returns the tightest budget of host that has not reset by now.
+func (t *rateLimitTracker) current(host string, now time.Time) (RateLimit, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var tightest RateLimit
+	for _, limit := range t.buckets[strings.ToLower(host)] {
+		if !limit.ResetAt.IsZero() && !limit.ResetAt.After(now) {
+			continue
+		}
+		tightest = tighterRateLimit(tightest, limit)
+	}
+	return tightest, tightest.Known()
+}
+
+// recordHeaders records the X-RateLimit-* headers GitHub sends with every
+// REST response.
+func (t *rateLimitTracker) recordHeaders(host string, header http.Header) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	resource := header.Get("X-RateLimit-Resource")
+	if resource == "" {
+		resource = "core"
+	}
+	var resetAt time.Time
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		resetAt = time.Unix(reset, 0)
+	}
+	t.record(RateLimit{Host: host, Resource: resource, Limit: limit, Remaining: remaining, ResetAt: resetAt})
+}
+
+// recordGraphQL records the rateLimit field of a GraphQL response, which
+// queries select alongside their data.
+func (t *rateLimitTracker) recordGraphQL(host string, body []byte) {
+	var response struct {
+		Data struct {
+			RateLimit *struct {
+				Limit     int       `json:"limit"`
+				Remaining int       `json:"remaining"`
+				ResetAt   time.Time `json:"resetAt"`
+			} `json:"rateLimit"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil || response.Data.RateLimit == nil {
+		return
+	}
+	rateLimit := response.Data.RateLimit
+	t.record(RateLimit{Host: host, Resource: "graphql", Limit: rateLimit.Limit, Remaining: rateLimit.Remaining, ResetAt: rateLimit.ResetAt})
+}
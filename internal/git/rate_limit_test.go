@@ -0,0 +1,118 @@
+package git
+
+import (
+	"fresh/internal/domain"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTrackerKeepsTightestUnresetBudget(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	var tracker rateLimitTracker
+
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", "30")
+	header.Set("X-RateLimit-Remaining", "2")
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(30*time.Second).Unix(), 10))
+	header.Set("X-RateLimit-Resource", "search")
+	tracker.recordHeaders("GitHub.com", header)
+	tracker.recordGraphQL("github.com", []byte(`{"data":{"rateLimit":{"limit":5000,"remaining":4210,"resetAt":"2026-01-02T10:30:00Z"}}}`))
+
+	budget, ok := tracker.current("github.com", now)
+	if !ok || budget.Resource != "search" || budget.Remaining != 2 || !budget.Low() {
+		t.Fatalf("current() = %+v, %v, want the low search budget", budget, ok)
+	}
+
+	budget, ok = tracker.current("github.com", now.Add(time.Minute))
+	if !ok || budget.Resource != "graphql" || budget.Remaining != 4210 || budget.Low() {
+		t.Errorf("after the search reset current() = %+v, %v, want the graphql budget", budget, ok)
+	}
+	if !budget.ResetAt.Equal(time.Date(2026, 1, 2, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("ResetAt = %s, want the resetAt GitHub reported", budget.ResetAt)
+	}
+
+	if _, ok := tracker.current("ghe.acme.corp", now); ok {
+		t.Error("current() reported a budget for a host that sent none")
+	}
+}
+
+func TestRateLimitTrackerIgnoresResponsesWithoutBudget(t *testing.T) {
+	t.Parallel()
+
+	var tracker rateLimitTracker
+	tracker.recordHeaders("github.com", http.Header{})
+	tracker.recordGraphQL("github.com", []byte(`{"data":{"search":{}}}`))
+	tracker.recordGraphQL("github.com", []byte(`not json`))
+
+	if budget, ok := tracker.current("github.com", time.Now()); ok {
+		t.Errorf("current() = %+v, want no budget", budget)
+	}
+}
+
+func TestSyncGitHubHostSkipsCountsWhenBudgetIsLow(t *testing.T) {
+	t.Parallel()
+
+	const host = "low-budget.example.com"
+	reposByPath := map[string]RemoteLocation{
+		"/src/api": {Host: host, Owner: "owner", Name: "api"},
+	}
+	var counted [][]string
+	countOpen := func(ownerRepos []string) (map[string]int, map[string]bool, error) {
+		counted = append(counted, ownerRepos)
+		return map[string]int{"owner/api": 7}, map[string]bool{}, nil
+	}
+	run, _ := pagedSearch(1, "owner/api")
+
+	if _, _, err := syncGitHubHost(host, reposByPath, countOpen, run); err != nil {
+		t.Fatalf("first sync unexpected error: %v", err)
+	}
+
+	gitHubRateLimits.record(RateLimit{Host: host, Resource: "graphql", Limit: 5000, Remaining: 100, ResetAt: time.Now().Add(time.Hour)})
+	reposByPath["/src/web"] = RemoteLocation{Host: host, Owner: "owner", Name: "web"}
+	states, tracked, err := syncGitHubHost(host, reposByPath, countOpen, run)
+	if err != nil {
+		t.Fatalf("low budget sync unexpected error: %v", err)
+	}
+	if len(counted) != 2 || len(counted[1]) != 1 || counted[1][0] != "owner/web" {
+		t.Errorf("counted %v, want only the repository the last sync did not count", counted)
+	}
+	api, _ := states["/src/api"].(domain.PullRequestCount)
+	if api.Open != 7 || api.MyOpen != 1 || api.ReviewRequested != 1 || len(tracked) != 2 {
+		t.Errorf("api state = %+v, tracked = %+v, want the count reused and the searches still made", api, tracked)
+	}
+}
+
+func TestSyncGitHubHostReusesLastSyncWhenBudgetIsExhausted(t *testing.T) {
+	t.Parallel()
+
+	const host = "exhausted.example.com"
+	reposByPath := map[string]RemoteLocation{
+		"/src/api": {Host: host, Owner: "owner", Name: "api"},
+	}
+	countOpen := func([]string) (map[string]int, map[string]bool, error) {
+		return map[string]int{"owner/api": 3}, map[string]bool{}, nil
+	}
+	run, calls := pagedSearch(1, "owner/api")
+
+	gitHubRateLimits.record(RateLimit{Host: host, Resource: "graphql", Limit: 5000, Remaining: 0, ResetAt: time.Now().Add(time.Hour)})
+	if _, _, err := syncGitHubHost(host, reposByPath, countOpen, run); err == nil || !strings.Contains(err.Error(), "rate limit exhausted") {
+		t.Fatalf("sync without a previous one error = %v, want the rate limit", err)
+	}
+
+	gitHubLastSyncs.store(host, map[string]gitHubRepoSync{"owner/api": {open: 3, mine: myPullRequestSummary{MyOpen: 1, MyReady: 1}}})
+	states, _, err := syncGitHubHost(host, reposByPath, countOpen, run)
+	if err != nil {
+		t.Fatalf("exhausted sync unexpected error: %v", err)
+	}
+	if *calls != 0 {
+		t.Errorf("searches = %d, want none while the budget is exhausted", *calls)
+	}
+	if want := (domain.PullRequestCount{Open: 3, MyOpen: 1, MyReady: 1}); states["/src/api"] != want {
+		t.Errorf("state = %+v, want the last sync %+v", states["/src/api"], want)
+	}
+}
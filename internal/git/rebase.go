@@ -0,0 +1,149 @@
+package git
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fresh/internal/domain"
+)
+
+// RebaseOntoDefault fetches the default branch from origin and rebases the
+// current branch onto it. A rebase that stops on conflicts is aborted so the
+// repository is left as it was, and the conflicting files are reported.
+// Repositories on the default branch itself, on a detached HEAD or with
+// uncommitted changes are skipped.
+func RebaseOntoDefault(repoPath string, lineCallback func(string)) domain.RebaseOutcome {
+	emit := func(line string) {
+		if lineCallback != nil {
+			lineCallback(line)
+		}
+	}
+
+	current, onBranch := GetCurrentBranch(repoPath).(domain.OnBranch)
+	skip := func(reason string) domain.RebaseOutcome {
+		emit("Skipped: " + reason)
+		return domain.RebaseOutcome{Branch: current.Name, Skipped: true, SkipReason: reason}
+	}
+	fail := func(outcome domain.CommandOutcome) domain.RebaseOutcome {
+		return domain.RebaseOutcome{CommandOutcome: outcome, Branch: current.Name}
+	}
+
+	if !onBranch {
+		return skip("not on a branch")
+	}
+
+	defaultBranch := DefaultBranch(repoPath)
+	switch {
+	case defaultBranch == "":
+		return skip("no default branch found")
+	case defaultBranch == current.Name:
+		return skip(fmt.Sprintf("already on %s", defaultBranch))
+	}
+
+	dirty, err := HasTrackedChanges(repoPath)
+	if err != nil {
+		return fail(domain.CommandOutcome{ExitCode: commandExitCode(err), FailureReason: err.Error()})
+	}
+	if dirty {
+		return skip("uncommitted changes")
+	}
+
+	onto := defaultBranch
+	if hasRemote(repoPath, "origin") {
+		emit(fmt.Sprintf("Fetching origin/%s", defaultBranch))
+		if outcome := runStreamedCommand(repoPath, defaultConfig.Timeout.Fetch, lineCallback, "fetch", "--progress", "origin", defaultBranch); !outcome.IsSuccess() {
+			return fail(outcome)
+		}
+		if refExists(repoPath, "refs/remotes/origin/"+defaultBranch) {
+			onto = "origin/" + defaultBranch
+		}
+	}
+
+	result := domain.RebaseOutcome{Branch: current.Name, Onto: onto}
+	if isAncestor(repoPath, onto, "HEAD") {
+		emit(fmt.Sprintf("%s already contains %s", current.Name, onto))
+		result.UpToDate = true
+		return result
+	}
+
+	emit(fmt.Sprintf("Rebasing %s onto %s", current.Name, onto))
+	outcome := runStreamedCommand(repoPath, defaultConfig.Timeout.Pull, lineCallback, "rebase", onto)
+	if outcome.IsSuccess() {
+		return result
+	}
+
+	result.CommandOutcome = outcome
+	if !isRebaseInProgress(repoPath) {
+		return result
+	}
+
+	result.ConflictedFiles = ListConflictedFiles(repoPath)
+	emit("Aborting rebase")
+	if abort := runStreamedCommand(repoPath, defaultConfig.Timeout.Default, lineCallback, "rebase", "--abort"); !abort.IsSuccess() {
+		result.FailureReason = "rebase --abort failed: " + abort.FailureReason
+		return result
+	}
+	switch count := len(result.ConflictedFiles); {
+	case count == 1:
+		result.FailureReason = "conflict in 1 file"
+	case count > 1:
+		result.FailureReason = fmt.Sprintf("conflicts in %d files", count)
+	}
+	return result
+}
+
+// ListConflictedFiles returns the paths left unmerged by a stopped merge or
+// rebase.
+func ListConflictedFiles(repoPath string) []string {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "diff", "--name-only", "--diff-filter=U")
+	cmd.Dir = repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+
+	var files []string
+	scanner := bufio.NewScanner(strings.NewReader(string(output)))
+	for scanner.Scan() {
+		if file := strings.TrimSpace(scanner.Text()); file != "" {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+func isAncestor(repoPath string, ancestor string, descendant string) bool {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "merge-base", "--is-ancestor", ancestor, descendant)
+	cmd.Dir = repoPath
+	return cmd.Run() == nil
+}
+
+func isRebaseInProgress(repoPath string) bool {
+	for _, dir := range []string{"rebase-merge", "rebase-apply"} {
+		cmd := createCommand(defaultConfig.Timeout.Default, "git", "rev-parse", "--quiet", "--git-path", dir)
+		cmd.Dir = repoPath
+		output, err := cmd.Output()
+		if err != nil {
+			continue
+		}
+		if pathExists(repoPath, strings.TrimSpace(string(output))) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathExists resolves path, which git may print relative to repoPath.
+func pathExists(repoPath string, path string) bool {
+	if path == "" {
+		return false
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(repoPath, path)
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,128 @@
+package git
+
+import (
+	"fresh/internal/config"
+	"net/url"
+	"strings"
+)
+
+// RemoteLocation is the host and repository slug parsed from a remote URL.
+// Owner may contain slashes for hosts with nested groups such as GitLab.
+type RemoteLocation struct {
+	Host  string
+	Owner string
+	Name  string
+}
+
+func (l RemoteLocation) Slug() string {
+	return l.Owner + "/" + l.Name
+}
+
+// WebURL is the repository's page, which every supported forge serves at
+// the slug.
+func (l RemoteLocation) WebURL() string {
+	return "https://" + l.Host + "/" + l.Slug()
+}
+
+// ParseRemoteURL understands scp-like (git@host:owner/repo.git), ssh:// and
+// http(s):// remote URLs for any host.
+func ParseRemoteURL(remoteURL string) (RemoteLocation, bool) {
+	raw := strings.TrimSpace(remoteURL)
+	if raw == "" {
+		return RemoteLocation{}, false
+	}
+
+	host := ""
+	path := ""
+
+	if strings.Contains(raw, "://") {
+		parsed, err := url.Parse(raw)
+		if err != nil {
+			return RemoteLocation{}, false
+		}
+		switch parsed.Scheme {
+		case "ssh", "git", "http", "https", "git+ssh", "ssh+git":
+		default:
+			return RemoteLocation{}, false
+		}
+		host = parsed.Hostname()
+		path = parsed.Path
+	} else {
+		at := strings.Index(raw, "@")
+		colon := strings.Index(raw, ":")
+		if colon <= 0 || (at >= 0 && at > colon) {
+			return RemoteLocation{}, false
+		}
+		host = raw[at+1 : colon]
+		path = raw[colon+1:]
+	}
+
+	path = strings.Trim(strings.TrimSuffix(strings.Trim(path, "/"), ".git"), "/")
+	slash := strings.LastIndex(path, "/")
+	if host == "" || slash <= 0 || slash == len(path)-1 {
+		return RemoteLocation{}, false
+	}
+
+	return RemoteLocation{
+		Host:  strings.ToLower(host),
+		Owner: path[:slash],
+		Name:  path[slash+1:],
+	}, true
+}
+
+// ResolveRemoteURL parses remoteURL like ParseRemoteURL and, for SSH
+// remotes, replaces a host alias from ~/.ssh/config with the host it
+// connects to, so git@github-work:org/repo resolves to github.com. cfg
+// names the forges sshRemoteHost recognises; with a nil cfg the host from
+// ~/.ssh/config always wins.
+func ResolveRemoteURL(cfg *config.Config, remoteURL string) (RemoteLocation, bool) {
+	location, ok := ParseRemoteURL(remoteURL)
+	if !ok || !isSSHRemoteURL(remoteURL) {
+		return location, ok
+	}
+	location.Host = sshRemoteHost(cfg, location.Host, sshHostName(location.Host))
+	return location, true
+}
+
+// sshWebHosts maps the hosts forges serve SSH on over port 443 to the host
+// their web pages and APIs are on.
+var sshWebHosts = map[string]string{
+	"ssh.github.com":    "github.com",
+	"altssh.gitlab.com": "gitlab.com",
+}
+
+// sshRemoteHost picks the host an SSH remote stands for from its alias and
+// the HostName ~/.ssh/config gives the alias. A HostName no provider
+// recognises, such as a proxy in front of github.com, is ignored when the
+// alias is a recognised host.
+func sshRemoteHost(cfg *config.Config, alias string, hostName string) string {
+	if web, ok := sshWebHosts[hostName]; ok {
+		hostName = web
+	}
+	if cfg != nil && !isForgeHost(cfg, hostName) && isForgeHost(cfg, alias) {
+		return strings.ToLower(alias)
+	}
+	return hostName
+}
+
+// isForgeHost reports whether any forge cfg configures serves host.
+func isForgeHost(cfg *config.Config, host string) bool {
+	if isGitHubHost(cfg.GitHub, host) || isGitLabHost(cfg.GitLab, host) {
+		return true
+	}
+	_, ok := findInstance(cfg.Gitea.Instances, host)
+	return ok
+}
+
+func isSSHRemoteURL(remoteURL string) bool {
+	scheme, _, found := strings.Cut(strings.TrimSpace(remoteURL), "://")
+	if !found {
+		return true
+	}
+	switch strings.ToLower(scheme) {
+	case "ssh", "git+ssh", "ssh+git":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,75 @@
+package git
+
+import (
+	"errors"
+	"fresh/internal/config"
+	"fresh/internal/domain"
+	"testing"
+)
+
+func TestParseRemoteURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		remote string
+		want   RemoteLocation
+		ok     bool
+	}{
+		{name: "scp github", remote: "git@github.com:octo/demo.git", want: RemoteLocation{Host: "github.com", Owner: "octo", Name: "demo"}, ok: true},
+		{name: "https github", remote: "https://github.com/octo/demo", want: RemoteLocation{Host: "github.com", Owner: "octo", Name: "demo"}, ok: true},
+		{name: "ssh with port", remote: "ssh://git@git.acme.corp:2222/team/svc.git", want: RemoteLocation{Host: "git.acme.corp", Owner: "team", Name: "svc"}, ok: true},
+		{name: "gitlab subgroup", remote: "https://gitlab.com/group/sub/project.git", want: RemoteLocation{Host: "gitlab.com", Owner: "group/sub", Name: "project"}, ok: true},
+		{name: "scp alias", remote: "github-work:org/repo", want: RemoteLocation{Host: "github-work", Owner: "org", Name: "repo"}, ok: true},
+		{name: "empty", remote: "", ok: false},
+		{name: "local path", remote: "/srv/git/repo.git", ok: false},
+		{name: "file scheme", remote: "file:///srv/git/repo.git", ok: false},
+		{name: "missing owner", remote: "https://github.com/demo", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := ParseRemoteURL(tt.remote)
+			if ok != tt.ok || got != tt.want {
+				t.Fatalf("ParseRemoteURL(%q) = %+v, %v; want %+v, %v", tt.remote, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestProviderRegistryWebURLs(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.DefaultConfig()
+	registry := NewProviderRegistry(GitLabMergeRequestService{Config: cfg}, GhPullRequestService{Config: cfg})
+	tests := []struct {
+		remoteURL   string
+		wantRepo    string
+		wantPR      string
+		wantPRError bool
+	}{
+		{remoteURL: "git@github.com:octo/demo.git", wantRepo: "https://github.com/octo/demo", wantPR: "https://github.com/octo/demo/pull/7"},
+		{remoteURL: "https://gitlab.com/group/sub/api.git", wantRepo: "https://gitlab.com/group/sub/api", wantPR: "https://gitlab.com/group/sub/api/-/merge_requests/7"},
+		{remoteURL: "git@bitbucket.org:team/api.git", wantRepo: "https://bitbucket.org/team/api", wantPRError: true},
+	}
+
+	for _, tt := range tests {
+		repo := domain.Repository{RemoteURL: tt.remoteURL}
+		if got, err := registry.RepositoryWebURL(repo); err != nil || got != tt.wantRepo {
+			t.Errorf("RepositoryWebURL(%s) = %q, %v, want %q", tt.remoteURL, got, err, tt.wantRepo)
+		}
+		got, err := registry.PullRequestWebURL(repo, 7)
+		if tt.wantPRError {
+			if !errors.Is(err, ErrNoWebURL) {
+				t.Errorf("PullRequestWebURL(%s) error = %v, want ErrNoWebURL", tt.remoteURL, err)
+			}
+		} else if err != nil || got != tt.wantPR {
+			t.Errorf("PullRequestWebURL(%s) = %q, %v, want %q", tt.remoteURL, got, err, tt.wantPR)
+		}
+	}
+
+	if _, err := registry.RepositoryWebURL(domain.Repository{}); !errors.Is(err, ErrNoWebURL) {
+		t.Errorf("RepositoryWebURL() without a remote error = %v, want ErrNoWebURL", err)
+	}
+}
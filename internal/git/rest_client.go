@@ -0,0 +1,225 @@
+package git
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// apiConcurrency bounds the requests made to one forge host at once.
+const apiConcurrency = 4
+
+// newAPIHTTPClient returns the HTTP client forge API clients use. It refuses
+// redirects to another host: net/http drops Authorization on such redirects
+// but forwards custom headers like GitLab's PRIVATE-TOKEN.
+func newAPIHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return fmt.Errorf("stopped after %d redirects", len(via))
+			}
+			if req.URL.Host != via[0].URL.Host {
+				return fmt.Errorf("refusing redirect from %s to %s", via[0].URL.Host, req.URL.Host)
+			}
+			return nil
+		},
+	}
+}
+
+// restClient makes authenticated GET requests to a forge's JSON API.
+type restClient struct {
+	// name prefixes errors, such as "gitlab".
+	name    string
+	baseURL string
+	// authHeader and authValue carry the token, e.g. PRIVATE-TOKEN for
+	// GitLab or Authorization for Gitea.
+	authHeader string
+	authValue  string
+	// tokenHint says where the token comes from, for 401 responses.
+	tokenHint string
+	http      *http.Client
+	// etags, when set, makes GET requests conditional on the last response
+	// to the same URL.
+	etags *etagCache
+	// onResponse, when set, sees the headers of every response, such as
+	// the rate limit GitHub reports.
+	onResponse func(http.Header)
+}
+
+// etagCache remembers responses by URL so a 304 Not Modified can be
+// answered from the previous body. GitHub does not count 304 responses
+// against the rate limit.
+type etagCache struct {
+	mu      sync.Mutex
+	entries map[string]etagEntry
+}
+
+type etagEntry struct {
+	etag   string
+	body   []byte
+	header http.Header
+}
+
+func (c *etagCache) lookup(endpoint string) (etagEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[endpoint]
+	return entry, ok
+}
+
+func (c *etagCache) store(endpoint string, entry etagEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]etagEntry)
+	}
+	c.entries[endpoint] = entry
+}
+
+// get decodes the JSON response to path into out and returns the response
+// headers, which carry pagination totals.
+func (c *restClient) get(path string, query url.Values, out any) (http.Header, error) {
+	endpoint := c.baseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	cached, hasCached := etagEntry{}, false
+	if c.etags != nil {
+		if cached, hasCached = c.etags.lookup(endpoint); hasCached {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && hasCached {
+		return cached.header, c.decode(cached.body, out)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, c.responseError(resp)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", c.name, err)
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" && c.etags != nil {
+		c.etags.store(endpoint, etagEntry{etag: etag, body: body, header: resp.Header})
+	}
+	return resp.Header, c.decode(body, out)
+}
+
+// post sends in as JSON to path and decodes the response into out.
+func (c *restClient) post(path string, in any, out any) (http.Header, error) {
+	payload, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, c.responseError(resp)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", c.name, err)
+	}
+	return resp.Header, c.decode(body, out)
+}
+
+func (c *restClient) do(req *http.Request) (*http.Response, error) {
+	req.Header.Set(c.authHeader, c.authValue)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", c.name, err)
+	}
+	if c.onResponse != nil {
+		c.onResponse(resp.Header)
+	}
+	return resp, nil
+}
+
+func (c *restClient) decode(body []byte, out any) error {
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", c.name, err)
+	}
+	return nil
+}
+
+func (c *restClient) responseError(resp *http.Response) error {
+	var body struct {
+		Message any    `json:"message"`
+		Error   string `json:"error"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	_ = json.Unmarshal(data, &body)
+
+	message := body.Error
+	if message == "" && body.Message != nil {
+		message = fmt.Sprint(body.Message)
+	}
+	if message == "" {
+		message = http.StatusText(resp.StatusCode)
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.tokenHint != "" {
+		message += " (check " + c.tokenHint + ")"
+	}
+	return fmt.Errorf("%s: %s (HTTP %d)", c.name, message, resp.StatusCode)
+}
+
+// forEachConcurrently calls fn for 0..n-1 with at most apiConcurrency calls
+// running and returns the first error.
+func forEachConcurrently(n int, fn func(i int) error) error {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	slots := make(chan struct{}, apiConcurrency)
+	for i := range n {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+			if err := fn(i); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
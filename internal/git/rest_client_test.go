@@ -0,0 +1,51 @@
+package git
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// restStandIn describes a forge's JSON API for tests: the header and value
+// that carry the token, the prefix routes sit under and the query parameter,
+// if any, that is kept in the route key.
+type restStandIn struct {
+	authHeader string
+	authValue  string
+	pathPrefix string
+	queryKey   string
+}
+
+// serve answers routes, keyed by escaped path, as JSON with the given
+// response headers and rejects requests without the token. It returns the
+// host and the API base URL to point a client at.
+func (s restStandIn) serve(t *testing.T, routes map[string]any, headers map[string]map[string]string) (*httptest.Server, string, string) {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(s.authHeader) != s.authValue {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"401 Unauthorized"}`))
+			return
+		}
+		path := strings.TrimPrefix(r.URL.EscapedPath(), s.pathPrefix)
+		if value := r.URL.Query().Get(s.queryKey); s.queryKey != "" && value != "" {
+			path += "?" + s.queryKey + "=" + value
+		}
+		body, ok := routes[path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"404 Not Found"}`))
+			return
+		}
+		for name, value := range headers[path] {
+			w.Header().Set(name, value)
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, strings.TrimPrefix(server.URL, "http://"), server.URL + s.pathPrefix
+}
@@ -0,0 +1,150 @@
+package git
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// maxSSHIncludeDepth stops Include directives that include each other.
+const maxSSHIncludeDepth = 8
+
+// sshHostEntry is one Host block of an OpenSSH client configuration.
+type sshHostEntry struct {
+	patterns []string
+	hostName string
+}
+
+var userSSHConfig struct {
+	once    sync.Once
+	entries []sshHostEntry
+}
+
+// sshHostName resolves an SSH host alias, such as github-work, to the
+// HostName ~/.ssh/config gives it. Hosts without one are returned as is.
+func sshHostName(alias string) string {
+	userSSHConfig.once.Do(func() {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return
+		}
+		dir := filepath.Join(home, ".ssh")
+		userSSHConfig.entries = readSSHConfig(filepath.Join(dir, "config"), dir, 0)
+	})
+	return resolveSSHHostName(userSSHConfig.entries, alias)
+}
+
+func readSSHConfig(file string, dir string, depth int) []sshHostEntry {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	return parseSSHConfig(f, func(pattern string) []sshHostEntry {
+		if depth >= maxSSHIncludeDepth {
+			return nil
+		}
+		if strings.HasPrefix(pattern, "~/") {
+			pattern = filepath.Join(filepath.Dir(dir), pattern[2:])
+		} else if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(dir, pattern)
+		}
+		matches, _ := filepath.Glob(pattern)
+		var entries []sshHostEntry
+		for _, match := range matches {
+			entries = append(entries, readSSHConfig(match, dir, depth+1)...)
+		}
+		return entries
+	})
+}
+
+// parseSSHConfig reads the Host blocks of an ssh_config file. Include
+// directives are expanded in place through include; Match blocks are
+// skipped because they depend on more than the host name.
+func parseSSHConfig(r io.Reader, include func(pattern string) []sshHostEntry) []sshHostEntry {
+	var entries []sshHostEntry
+	var current *sshHostEntry
+	inMatch := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		keyword, value := splitSSHConfigLine(scanner.Text())
+		switch keyword {
+		case "":
+		case "host":
+			entries = append(entries, sshHostEntry{patterns: strings.Fields(value)})
+			current = &entries[len(entries)-1]
+			inMatch = false
+		case "match":
+			current = nil
+			inMatch = true
+		case "hostname":
+			if current != nil && current.hostName == "" {
+				current.hostName = value
+			} else if current == nil && !inMatch {
+				// Settings before the first Host block apply to every host.
+				entries = append(entries, sshHostEntry{patterns: []string{"*"}, hostName: value})
+			}
+		case "include":
+			if current != nil || inMatch {
+				// Includes inside a block only apply to it; they rarely set
+				// HostName, so they are not followed.
+				continue
+			}
+			if include != nil {
+				for _, pattern := range strings.Fields(value) {
+					entries = append(entries, include(pattern)...)
+				}
+			}
+		}
+	}
+	return entries
+}
+
+func splitSSHConfigLine(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", ""
+	}
+	end := strings.IndexAny(line, " \t=")
+	if end < 0 {
+		return strings.ToLower(line), ""
+	}
+	value := strings.TrimLeft(line[end:], " \t=")
+	return strings.ToLower(line[:end]), strings.Trim(value, `"`)
+}
+
+// resolveSSHHostName applies the first HostName whose Host patterns match
+// alias, as ssh does, expanding the %h token to the alias.
+func resolveSSHHostName(entries []sshHostEntry, alias string) string {
+	for _, entry := range entries {
+		if entry.hostName == "" || !matchSSHHostPatterns(entry.patterns, alias) {
+			continue
+		}
+		return strings.ToLower(strings.ReplaceAll(entry.hostName, "%h", alias))
+	}
+	return alias
+}
+
+// matchSSHHostPatterns reports whether host matches any of the patterns
+// and none of the negated ones.
+func matchSSHHostPatterns(patterns []string, host string) bool {
+	host = strings.ToLower(host)
+	matched := false
+	for _, pattern := range patterns {
+		negated := strings.HasPrefix(pattern, "!")
+		ok, _ := path.Match(strings.ToLower(strings.TrimPrefix(pattern, "!")), host)
+		if !ok {
+			continue
+		}
+		if negated {
+			return false
+		}
+		matched = true
+	}
+	return matched
+}
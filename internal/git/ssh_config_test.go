@@ -0,0 +1,194 @@
+package git
+
+import (
+	"fresh/internal/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveSSHHostName(t *testing.T) {
+	t.Parallel()
+
+	const config = `
+# Work account
+Host github-work
+    HostName github.com
+    IdentityFile ~/.ssh/id_work
+
+Host ghe gh-enterprise
+  Hostname=github.acme.corp
+
+Host *.internal !legacy.internal
+    HostName %h.acme.corp
+
+Match host build
+    HostName ignored.example.com
+
+Host github-work
+    HostName shadowed.example.com
+`
+	entries := parseSSHConfig(strings.NewReader(config), nil)
+
+	tests := []struct {
+		alias string
+		want  string
+	}{
+		{alias: "github-work", want: "github.com"},
+		{alias: "gh-enterprise", want: "github.acme.corp"},
+		{alias: "GHE", want: "github.acme.corp"},
+		{alias: "git.internal", want: "git.internal.acme.corp"},
+		{alias: "legacy.internal", want: "legacy.internal"},
+		{alias: "build", want: "build"},
+		{alias: "github.com", want: "github.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alias, func(t *testing.T) {
+			t.Parallel()
+
+			if got := resolveSSHHostName(entries, tt.alias); got != tt.want {
+				t.Errorf("resolveSSHHostName(%q) = %q, want %q", tt.alias, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSSHRemoteHost(t *testing.T) {
+	t.Parallel()
+
+	const sshConfig = `
+Host github.com
+    HostName ssh.github.com
+    Port 443
+
+Host gitlab.com
+    Hostname altssh.gitlab.com
+
+Host github-work
+    HostName ssh.github.com
+
+Host bb
+    HostName bitbucket.org
+`
+	entries := parseSSHConfig(strings.NewReader(sshConfig), nil)
+	cfg := config.DefaultConfig()
+
+	tests := []struct {
+		alias string
+		want  string
+	}{
+		{alias: "github.com", want: "github.com"},
+		{alias: "gitlab.com", want: "gitlab.com"},
+		{alias: "github-work", want: "github.com"},
+		{alias: "bb", want: "bitbucket.org"},
+		{alias: "git.example.com", want: "git.example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.alias, func(t *testing.T) {
+			t.Parallel()
+
+			if got := sshRemoteHost(cfg, tt.alias, resolveSSHHostName(entries, tt.alias)); got != tt.want {
+				t.Errorf("sshRemoteHost(%q) = %q, want %q", tt.alias, got, tt.want)
+			}
+		})
+	}
+
+	if got := sshRemoteHost(cfg, "github.com", "proxy.corp.internal"); got != "github.com" {
+		t.Errorf("sshRemoteHost(github.com) behind a proxy = %q, want github.com", got)
+	}
+}
+
+func TestReadSSHConfigFollowsIncludes(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "config.d"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"config":           "Include config.d/*\n\nHost github-work\n    HostName github.com\n",
+		"config.d/work":    "Host gitlab-work\n    HostName gitlab.acme.corp\n",
+		"config.d/recurse": "Include ../config\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries := readSSHConfig(filepath.Join(dir, "config"), dir, 0)
+	if got := resolveSSHHostName(entries, "gitlab-work"); got != "gitlab.acme.corp" {
+		t.Errorf("included alias = %q, want gitlab.acme.corp", got)
+	}
+	if got := resolveSSHHostName(entries, "github-work"); got != "github.com" {
+		t.Errorf("alias = %q, want github.com", got)
+	}
+}
+
+func TestParseGitHubRemote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		remote   string
+		wantHost string
+		wantSlug string
+		ok       bool
+	}{
+		{remote: "git@github.com:owner/repo.git", wantHost: "github.com", wantSlug: "owner/repo", ok: true},
+		{remote: "https://github.com/owner/repo", wantHost: "github.com", wantSlug: "owner/repo", ok: true},
+		{remote: "ssh://git@github.com/owner/repo.git", wantHost: "github.com", wantSlug: "owner/repo", ok: true},
+		{remote: "git@github.acme.corp:team/repo.git"},
+		{remote: "https://github.evil.example/team/repo.git"},
+		{remote: "git@gitlab.com:group/repo.git"},
+		{remote: "https://github.com/owner/nested/repo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remote, func(t *testing.T) {
+			t.Parallel()
+
+			location, ok := parseGitHubRemote(config.DefaultConfig(), tt.remote)
+			if ok != tt.ok || location.Host != tt.wantHost || (ok && location.Slug() != tt.wantSlug) {
+				t.Errorf("parseGitHubRemote(%q) = %+v, %v, want %s %s, %v", tt.remote, location, ok, tt.wantHost, tt.wantSlug, tt.ok)
+			}
+		})
+	}
+}
+
+func TestGhCommandTargetsHost(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		host     string
+		args     []string
+		wantArgs string
+		wantEnv  bool
+	}{
+		{name: "github.com api", host: "github.com", args: []string{"api", "user"}, wantArgs: "gh api user"},
+		{name: "enterprise api", host: "github.acme.corp", args: []string{"api", "user"}, wantArgs: "gh api --hostname github.acme.corp user", wantEnv: true},
+		{name: "enterprise search", host: "github.acme.corp", args: []string{"search", "prs"}, wantArgs: "gh search prs", wantEnv: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := ghCommand(tt.host, tt.args...)
+			args := append([]string{"gh"}, cmd.Args[1:]...)
+			if got := strings.Join(args, " "); got != tt.wantArgs {
+				t.Errorf("args = %q, want %q", got, tt.wantArgs)
+			}
+			hasEnv := false
+			for _, env := range cmd.Env {
+				hasEnv = hasEnv || env == "GH_HOST="+tt.host
+			}
+			if hasEnv != tt.wantEnv {
+				t.Errorf("GH_HOST set = %v, want %v", hasEnv, tt.wantEnv)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package git
+
+import (
+	"errors"
+	"strings"
+
+	"fresh/internal/domain"
+	"fresh/internal/textutil"
+)
+
+// StashDiffStat returns the --stat summary of a stash, including any
+// untracked files it recorded.
+func StashDiffStat(repoPath string, ref string) (string, error) {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "stash", "show", "--stat", "--include-untracked", ref)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", commandError(output, err)
+	}
+	return strings.TrimRight(string(output), "\n"), nil
+}
+
+func ApplyStash(repoPath string, ref string) domain.CommandOutcome {
+	return runStashCommand(repoPath, "apply", ref)
+}
+
+func PopStash(repoPath string, ref string) domain.CommandOutcome {
+	return runStashCommand(repoPath, "pop", ref)
+}
+
+func DropStash(repoPath string, ref string) domain.CommandOutcome {
+	return runStashCommand(repoPath, "drop", ref)
+}
+
+func runStashCommand(repoPath string, action string, ref string) domain.CommandOutcome {
+	cmd := createCommand(defaultConfig.Timeout.Default, "git", "stash", action, "--quiet", ref)
+	cmd.Dir = repoPath
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return domain.CommandOutcome{
+			ExitCode:      commandExitCode(err),
+			FailureReason: textutil.FirstNonEmptyTrimmed(firstErrorLine(string(output)), string(output), err.Error()),
+		}
+	}
+	return domain.CommandOutcome{}
+}
+
+// firstErrorLine picks the line git prefixes with error: or fatal:, which is
+// more useful in a one-line status than the full conflict report.
+func firstErrorLine(output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		lower := strings.ToLower(strings.TrimSpace(line))
+		if strings.HasPrefix(lower, "error:") || strings.HasPrefix(lower, "fatal:") {
+			return strings.TrimSpace(line)
+		}
+	}
+	return ""
+}
+
+func commandError(output []byte, err error) error {
+	return errors.New(textutil.FirstNonEmptyTrimmed(firstErrorLine(string(output)), string(output), err.Error()))
+}
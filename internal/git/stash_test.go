@@ -0,0 +1,38 @@
+package git
+
+import "testing"
+
+func TestFirstErrorLine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{
+			name:   "conflict report",
+			output: "Auto-merging main.go\nCONFLICT (content): Merge conflict in main.go\nerror: could not restore untracked files from stash\n",
+			want:   "error: could not restore untracked files from stash",
+		},
+		{
+			name:   "fatal",
+			output: "fatal: log for 'stash' only has 2 entries\n",
+			want:   "fatal: log for 'stash' only has 2 entries",
+		},
+		{
+			name:   "no error line",
+			output: "On branch main\n",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := firstErrorLine(tt.output); got != tt.want {
+				t.Fatalf("firstErrorLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ type Kind = pullrequests.NotificationKind
 const (
 	KindProgress Kind = pullrequests.NotificationKindProgress
 	KindBlocked  Kind = pullrequests.NotificationKindBlocked
+	KindReview   Kind = pullrequests.NotificationKindReview
 )
 
 type PRKey = pullrequests.Key
@@ -36,8 +37,17 @@ type Notifier struct {
 const (
 	glassSoundPath = "/System/Library/Sounds/Glass.aiff"
 	blowSoundPath  = "/System/Library/Sounds/Blow.aiff"
+
+	alertCommand = "osascript"
+	soundCommand = "afplay"
 )
 
+// Backends lists the programs notifications are delivered through. When
+// they are missing the notifier falls back to the terminal bell.
+func Backends() []string {
+	return []string{alertCommand, soundCommand}
+}
+
 func NewNotifier() *Notifier {
 	return &Notifier{
 		entries: make(map[string]scheduledNotification),
@@ -148,7 +158,7 @@ func (n *Notifier) send(notification Notification) {
 	title, body, soundPath := buildPayload(notification)
 
 	cmd := exec.Command(
-		"osascript",
+		alertCommand,
 		"-e",
 		fmt.Sprintf(`display notification "%s" with title "%s"`, escapeAppleScriptString(body), escapeAppleScriptString(title)),
 	)
@@ -157,7 +167,7 @@ func (n *Notifier) send(notification Notification) {
 		fmt.Print("\a")
 	}
 
-	if err := exec.Command("afplay", soundPath).Start(); err != nil {
+	if err := exec.Command(soundCommand, soundPath).Start(); err != nil {
 		fmt.Print("\a")
 	}
 }
@@ -172,15 +182,21 @@ func buildPayload(notification Notification) (title, body, soundPath string) {
 	case KindBlocked:
 		body = fmt.Sprintf("Blocked - %s", notification.Reason)
 		soundPath = blowSoundPath
+	case KindReview:
+		body = fmt.Sprintf("Review - %s", notification.Reason)
+		soundPath = glassSoundPath
 	default:
 		body = fmt.Sprintf("Progress - %s", notification.Reason)
 		soundPath = glassSoundPath
 	}
 
 	if strings.TrimSpace(notification.Reason) == "" {
-		if notification.Kind == KindBlocked {
+		switch notification.Kind {
+		case KindBlocked:
 			body = "Blocked"
-		} else {
+		case KindReview:
+			body = "Review"
+		default:
 			body = "Progress"
 		}
 	}
@@ -41,3 +41,22 @@ func TestBuildPayload_WithoutPullRequestTitleUsesRepoName(t *testing.T) {
 		t.Fatalf("title = %q, want %q", title, "api")
 	}
 }
+
+func TestBuildPayload_ReviewRequest(t *testing.T) {
+	t.Parallel()
+
+	notification := Notification{
+		Key:    PRKey{Owner: "acme", Repo: "api", Number: 12},
+		Kind:   KindReview,
+		Reason: "acme/api#12 needs your review",
+	}
+
+	_, body, soundPath := buildPayload(notification)
+
+	if body != "Review - acme/api#12 needs your review" {
+		t.Fatalf("body = %q, want %q", body, "Review - acme/api#12 needs your review")
+	}
+	if soundPath != glassSoundPath {
+		t.Fatalf("soundPath = %q, want %q", soundPath, glassSoundPath)
+	}
+}
@@ -57,6 +57,13 @@ func (c *NotificationCoordinator) Sync(tracked []Snapshot, options ApplyOptions,
 				Reason:           fmt.Sprintf("%s is no longer blocked", change.Key.String()),
 				PullRequestTitle: change.Title,
 			})
+		case ChangeReviewRequested:
+			sink.Upsert(Notification{
+				Key:              change.Key,
+				Kind:             NotificationKindReview,
+				Reason:           fmt.Sprintf("%s needs your review", change.Key.String()),
+				PullRequestTitle: change.Title,
+			})
 		case ChangeBlockedRemoved:
 			sink.Resolve(change.Key)
 		}
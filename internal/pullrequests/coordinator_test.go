@@ -161,3 +161,22 @@ func TestNotificationCoordinator_BlockedRemovedOnlyResolves(t *testing.T) {
 		t.Fatalf("upserts = %d, want 0", len(sink.upserts))
 	}
 }
+
+func TestNotificationCoordinator_ReviewRequestUpsertsReview(t *testing.T) {
+	t.Parallel()
+
+	coordinator := NewNotificationCoordinator(nil)
+	_ = coordinator.Sync(nil, ApplyOptions{Seed: true}, nil)
+
+	sink := &fakeNotificationSink{}
+	_ = coordinator.Sync([]Snapshot{
+		{Key: Key{Owner: "acme", Repo: "api", Number: 12}, Status: StatusReviewRequested, Title: "Add retries"},
+	}, ApplyOptions{}, sink)
+
+	if len(sink.upserts) != 1 || sink.upserts[0].Kind != NotificationKindReview {
+		t.Fatalf("upserts = %+v, want one %q", sink.upserts, NotificationKindReview)
+	}
+	if sink.upserts[0].Reason != "acme/api#12 needs your review" {
+		t.Fatalf("reason = %q, want the review request", sink.upserts[0].Reason)
+	}
+}
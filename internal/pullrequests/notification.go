@@ -7,6 +7,7 @@ type NotificationKind string
 const (
 	NotificationKindProgress NotificationKind = "progress"
 	NotificationKindBlocked  NotificationKind = "blocked"
+	NotificationKindReview   NotificationKind = "review"
 )
 
 type Notification struct {
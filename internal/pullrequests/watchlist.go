@@ -12,6 +12,9 @@ const (
 	StatusBlocked Status = "blocked"
 	StatusReview  Status = "review"
 	StatusChecks  Status = "checks"
+	// StatusReviewRequested marks someone else's pull request waiting on
+	// the user's review.
+	StatusReviewRequested Status = "review_requested"
 )
 
 type Snapshot struct {
@@ -27,6 +30,7 @@ const (
 	ChangeBecameUnblocked ChangeKind = "became_unblocked"
 	ChangeBecameMergeable ChangeKind = "became_mergeable"
 	ChangeBlockedRemoved  ChangeKind = "blocked_removed"
+	ChangeReviewRequested ChangeKind = "review_requested"
 )
 
 type Change struct {
@@ -96,20 +100,28 @@ func (w *Watchlist) Apply(current []Snapshot, options ApplyOptions) []Change {
 				continue
 			}
 
-			if currentStatus == StatusBlocked {
+			switch currentStatus {
+			case StatusBlocked:
 				changes = append(changes, Change{
 					Kind:    ChangeBecameBlocked,
 					Key:     key,
 					Current: currentStatus,
 					Title:   title,
 				})
-			} else if currentStatus == StatusReady {
+			case StatusReady:
 				changes = append(changes, Change{
 					Kind:    ChangeBecameMergeable,
 					Key:     key,
 					Current: currentStatus,
 					Title:   title,
 				})
+			case StatusReviewRequested:
+				changes = append(changes, Change{
+					Kind:    ChangeReviewRequested,
+					Key:     key,
+					Current: currentStatus,
+					Title:   title,
+				})
 			}
 		case previousStatus != currentStatus:
 			switch {
@@ -303,3 +303,28 @@ func TestWatchlist_ChangeRetainsPreviousTitleWhenCurrentSnapshotOmitsIt(t *testi
 		t.Fatalf("title = %q, want %q", changes[0].Title, "Fix flaky checks")
 	}
 }
+
+func TestWatchlist_NewReviewRequestAfterSeedEmitsAlert(t *testing.T) {
+	t.Parallel()
+
+	watchlist := NewWatchlist()
+	watchlist.Apply([]Snapshot{
+		{Key: Key{Owner: "acme", Repo: "web", Number: 44}, Status: StatusReviewRequested},
+	}, ApplyOptions{Seed: true})
+
+	changes := watchlist.Apply([]Snapshot{
+		{Key: Key{Owner: "acme", Repo: "web", Number: 44}, Status: StatusReviewRequested},
+		{Key: Key{Owner: "acme", Repo: "api", Number: 12}, Status: StatusReviewRequested, Title: "Add retries"},
+	}, ApplyOptions{})
+
+	if len(changes) != 1 {
+		t.Fatalf("changes = %+v, want only the new request", changes)
+	}
+	if changes[0].Kind != ChangeReviewRequested || changes[0].Key.Number != 12 || changes[0].Title != "Add retries" {
+		t.Fatalf("change = %+v, want %q for #12", changes[0], ChangeReviewRequested)
+	}
+
+	if changes := watchlist.Apply(nil, ApplyOptions{}); len(changes) != 0 {
+		t.Fatalf("changes = %+v, want none once the reviews are done", changes)
+	}
+}
@@ -0,0 +1,220 @@
+package repofilter
+
+import (
+	"sort"
+	"strings"
+
+	"fresh/internal/domain"
+)
+
+type predicate func(domain.Repository) bool
+
+var predicates = map[string]predicate{
+	"is:dirty": func(repo domain.Repository) bool {
+		_, ok := repo.LocalState.(domain.DirtyLocalState)
+		return ok
+	},
+	"is:clean": func(repo domain.Repository) bool {
+		_, ok := repo.LocalState.(domain.CleanLocalState)
+		return ok
+	},
+	"is:behind": func(repo domain.Repository) bool {
+		return repo.RemoteState != nil && repo.CanPull()
+	},
+	"is:ahead": func(repo domain.Repository) bool {
+		switch s := repo.RemoteState.(type) {
+		case domain.Ahead:
+			return s.Count > 0
+		case domain.Diverged:
+			return s.AheadCount > 0
+		}
+		return false
+	},
+	"is:diverged": func(repo domain.Repository) bool {
+		_, ok := repo.RemoteState.(domain.Diverged)
+		return ok
+	},
+	"is:synced": func(repo domain.Repository) bool {
+		_, ok := repo.RemoteState.(domain.Synced)
+		return ok
+	},
+	"is:no-upstream": func(repo domain.Repository) bool {
+		_, ok := repo.RemoteState.(domain.NoUpstream)
+		return ok
+	},
+	"is:detached": func(repo domain.Repository) bool {
+		_, ok := repo.Branches.Current.(domain.DetachedHead)
+		return ok
+	},
+	"is:error": func(repo domain.Repository) bool {
+		_, remoteErr := repo.RemoteState.(domain.RemoteError)
+		_, localErr := repo.LocalState.(domain.LocalStateError)
+		_, prErr := repo.PullRequests.(domain.PullRequestError)
+		return remoteErr || localErr || prErr
+	},
+	"is:busy": func(repo domain.Repository) bool {
+		return repo.Activity != nil && repo.IsBusy()
+	},
+	"is:fork": func(repo domain.Repository) bool {
+		switch repo.Fork.(type) {
+		case domain.ForkStatus, domain.ForkError:
+			return true
+		}
+		return false
+	},
+	"is:fork-behind": func(repo domain.Repository) bool {
+		fork, ok := repo.Fork.(domain.ForkStatus)
+		return ok && fork.Behind > 0
+	},
+	"has:stash": func(repo domain.Repository) bool {
+		return repo.StashCount > 0
+	},
+	"has:prunable": func(repo domain.Repository) bool {
+		return len(repo.Branches.Merged) > 0
+	},
+	"has:pr": func(repo domain.Repository) bool {
+		return repo.PullRequestCount().Open > 0
+	},
+	"has:my-pr": func(repo domain.Repository) bool {
+		return repo.PullRequestCount().MyOpen > 0
+	},
+	"has:pr-ready": func(repo domain.Repository) bool {
+		return repo.PullRequestCount().MyReady > 0
+	},
+	"has:pr-blocked": func(repo domain.Repository) bool {
+		return repo.PullRequestCount().MyBlocked > 0
+	},
+	"has:pr-review": func(repo domain.Repository) bool {
+		return repo.PullRequestCount().MyReview > 0
+	},
+	"has:pr-checks": func(repo domain.Repository) bool {
+		return repo.PullRequestCount().MyChecks > 0
+	},
+}
+
+// pullRequestPredicates read pull request state, which is only loaded when a
+// provider has been queried.
+var pullRequestPredicates = map[string]bool{
+	"has:pr":         true,
+	"has:my-pr":      true,
+	"has:pr-ready":   true,
+	"has:pr-blocked": true,
+	"has:pr-review":  true,
+	"has:pr-checks":  true,
+}
+
+// Predicates returns the supported status predicates in sorted order.
+func Predicates() []string {
+	names := make([]string, 0, len(predicates))
+	for name := range predicates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type condition struct {
+	match  predicate
+	negate bool
+}
+
+// Query is a parsed filter expression. Free-text terms are fuzzy matched
+// against the repository name and branch and substring matched against the
+// path; status predicates such as is:dirty or has:pr-blocked narrow further
+// and may be negated with a leading "-". Every term must match.
+type Query struct {
+	Raw        string
+	terms      []string
+	conditions []condition
+	Unknown    []string
+
+	pullRequests bool
+}
+
+func Parse(raw string) Query {
+	query := Query{Raw: strings.TrimSpace(raw)}
+
+	for _, field := range strings.Fields(strings.ToLower(query.Raw)) {
+		negate := false
+		token := field
+		if len(token) > 1 && (token[0] == '-' || token[0] == '!') {
+			negate = true
+			token = token[1:]
+		}
+
+		if strings.HasPrefix(token, "is:") || strings.HasPrefix(token, "has:") {
+			match, ok := predicates[token]
+			if !ok {
+				query.Unknown = append(query.Unknown, field)
+				continue
+			}
+			query.conditions = append(query.conditions, condition{match: match, negate: negate})
+			query.pullRequests = query.pullRequests || pullRequestPredicates[token]
+			continue
+		}
+
+		query.terms = append(query.terms, field)
+	}
+
+	return query
+}
+
+func (q Query) IsEmpty() bool {
+	return len(q.terms) == 0 && len(q.conditions) == 0
+}
+
+// UsesPullRequests reports whether the query has a predicate that needs
+// pull request state to match.
+func (q Query) UsesPullRequests() bool {
+	return q.pullRequests
+}
+
+func (q Query) Matches(repo domain.Repository) bool {
+	for _, cond := range q.conditions {
+		if cond.match(repo) == cond.negate {
+			return false
+		}
+	}
+
+	if len(q.terms) == 0 {
+		return true
+	}
+
+	name := strings.ToLower(repo.Name)
+	branch := strings.ToLower(branchName(repo.Branches.Current))
+	path := strings.ToLower(repo.Path)
+
+	for _, term := range q.terms {
+		if !FuzzyMatch(term, name) && !FuzzyMatch(term, branch) && !strings.Contains(path, term) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FuzzyMatch reports whether every rune of pattern appears in text in order.
+func FuzzyMatch(pattern, text string) bool {
+	if pattern == "" {
+		return true
+	}
+
+	patternRunes := []rune(pattern)
+	next := 0
+	for _, r := range text {
+		if r == patternRunes[next] {
+			next++
+			if next == len(patternRunes) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func branchName(branch domain.Branch) string {
+	if onBranch, ok := branch.(domain.OnBranch); ok {
+		return onBranch.Name
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+package repofilter
+
+import (
+	"testing"
+
+	"fresh/internal/domain"
+	"fresh/internal/testhelpers"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		text    string
+		want    bool
+	}{
+		{pattern: "", text: "anything", want: true},
+		{pattern: "frsh", text: "fresh", want: true},
+		{pattern: "fresh", text: "fresh", want: true},
+		{pattern: "hsf", text: "fresh", want: false},
+		{pattern: "freshy", text: "fresh", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := FuzzyMatch(tt.pattern, tt.text); got != tt.want {
+			t.Errorf("FuzzyMatch(%q, %q) = %v, want %v", tt.pattern, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestQueryMatchesTextAcrossNameBranchAndPath(t *testing.T) {
+	t.Parallel()
+
+	repo := testhelpers.NewTestRepository("payments-api").
+		Path("/work/backend/payments-api").
+		CurrentBranch(domain.OnBranch{Name: "feature/refunds"}).
+		Build()
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "pmtapi", want: true},
+		{query: "refunds", want: true},
+		{query: "backend", want: true},
+		{query: "PAYMENTS", want: true},
+		{query: "pay frd", want: true},
+		{query: "pay billing", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := Parse(tt.query).Matches(repo); got != tt.want {
+			t.Errorf("Parse(%q).Matches() = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestQueryPredicates(t *testing.T) {
+	t.Parallel()
+
+	dirtyBehind := testhelpers.NewTestRepository("dirty-behind").
+		LocalState(domain.DirtyLocalState{Modified: 1}).
+		RemoteState(domain.Behind{Count: 2}).
+		PullRequests(domain.PullRequestCount{Open: 2, MyOpen: 1, MyBlocked: 1}).
+		Fork(domain.ForkStatus{Branch: "main", Behind: 3}).
+		Build()
+	clean := testhelpers.NewTestRepository("clean").
+		PullRequests(domain.PullRequestCount{}).
+		Fork(domain.NotFork{}).
+		Build()
+
+	tests := []struct {
+		query     string
+		wantDirty bool
+		wantClean bool
+	}{
+		{query: "is:dirty", wantDirty: true, wantClean: false},
+		{query: "-is:dirty", wantDirty: false, wantClean: true},
+		{query: "is:behind", wantDirty: true, wantClean: false},
+		{query: "is:synced", wantDirty: false, wantClean: true},
+		{query: "has:pr-blocked", wantDirty: true, wantClean: false},
+		{query: "is:dirty clean", wantDirty: false, wantClean: false},
+		{query: "is:clean clean", wantDirty: false, wantClean: true},
+		{query: "is:fork-behind", wantDirty: true, wantClean: false},
+		{query: "-is:fork", wantDirty: false, wantClean: true},
+	}
+
+	for _, tt := range tests {
+		query := Parse(tt.query)
+		if got := query.Matches(dirtyBehind); got != tt.wantDirty {
+			t.Errorf("Parse(%q).Matches(dirty-behind) = %v, want %v", tt.query, got, tt.wantDirty)
+		}
+		if got := query.Matches(clean); got != tt.wantClean {
+			t.Errorf("Parse(%q).Matches(clean) = %v, want %v", tt.query, got, tt.wantClean)
+		}
+	}
+}
+
+func TestParseReportsUnknownPredicates(t *testing.T) {
+	t.Parallel()
+
+	query := Parse("is:dirt api")
+	if len(query.Unknown) != 1 || query.Unknown[0] != "is:dirt" {
+		t.Fatalf("Unknown = %v, want [is:dirt]", query.Unknown)
+	}
+	if query.IsEmpty() {
+		t.Fatal("expected free-text term to keep the query non-empty")
+	}
+}
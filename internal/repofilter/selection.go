@@ -0,0 +1,80 @@
+package repofilter
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"fresh/internal/domain"
+)
+
+// Selection narrows the repositories a headless command works on. Query uses
+// the TUI's filter syntax, Names keeps only repositories with one of the
+// given names, and Exclude drops repositories whose name matches one of its
+// glob patterns.
+type Selection struct {
+	Query   string
+	Names   []string
+	Exclude []string
+}
+
+// Validate reports unknown filter predicates and malformed exclude patterns,
+// which would otherwise silently match nothing.
+func (s Selection) Validate() error {
+	if unknown := Parse(s.Query).Unknown; len(unknown) > 0 {
+		return fmt.Errorf("unknown filter %s", strings.Join(unknown, ", "))
+	}
+	for _, pattern := range s.Exclude {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid exclude pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// UsesPullRequests reports whether the query needs pull request state, so
+// callers know to load it before applying the selection.
+func (s Selection) UsesPullRequests() bool {
+	return Parse(s.Query).UsesPullRequests()
+}
+
+// IncludesName reports whether Names and Exclude keep a repository with the
+// given name. It needs no repository state, so callers can skip building
+// repositories it rejects; the query is only checked by Apply.
+func (s Selection) IncludesName(name string) bool {
+	return s.named(name) && !s.excluded(name)
+}
+
+func (s Selection) Apply(repos []domain.Repository) []domain.Repository {
+	query := Parse(s.Query)
+
+	var matched []domain.Repository
+	for _, repo := range repos {
+		if !query.Matches(repo) || !s.IncludesName(repo.Name) {
+			continue
+		}
+		matched = append(matched, repo)
+	}
+	return matched
+}
+
+func (s Selection) named(name string) bool {
+	if len(s.Names) == 0 {
+		return true
+	}
+	for _, candidate := range s.Names {
+		if candidate == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Selection) excluded(name string) bool {
+	for _, pattern := range s.Exclude {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
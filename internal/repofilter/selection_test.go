@@ -0,0 +1,105 @@
+package repofilter
+
+import (
+	"testing"
+
+	"fresh/internal/domain"
+	"fresh/internal/testhelpers"
+)
+
+func TestSelectionApply(t *testing.T) {
+	t.Parallel()
+
+	repos := []domain.Repository{
+		testhelpers.NewTestRepository("api").RemoteState(domain.Behind{Count: 1}).Build(),
+		testhelpers.NewTestRepository("api-legacy").RemoteState(domain.Behind{Count: 1}).Build(),
+		testhelpers.NewTestRepository("web").Build(),
+	}
+
+	tests := []struct {
+		name      string
+		selection Selection
+		want      []string
+	}{
+		{name: "no filter", want: []string{"api", "api-legacy", "web"}},
+		{name: "query", selection: Selection{Query: "is:behind"}, want: []string{"api", "api-legacy"}},
+		{name: "exclude glob", selection: Selection{Query: "is:behind", Exclude: []string{"*-legacy"}}, want: []string{"api"}},
+		{name: "names", selection: Selection{Names: []string{"web", "api"}}, want: []string{"api", "web"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := tt.selection.Apply(repos)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Apply() = %d repos, want %v", len(got), tt.want)
+			}
+			for i, name := range tt.want {
+				if got[i].Name != name {
+					t.Fatalf("Apply()[%d] = %s, want %s", i, got[i].Name, name)
+				}
+			}
+		})
+	}
+}
+
+func TestSelectionValidate(t *testing.T) {
+	t.Parallel()
+
+	if err := (Selection{Query: "is:dirty api", Exclude: []string{"old-*"}}).Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if err := (Selection{Query: "is:dirt"}).Validate(); err == nil {
+		t.Fatal("Validate() expected error for unknown predicate")
+	}
+	if err := (Selection{Exclude: []string{"["}}).Validate(); err == nil {
+		t.Fatal("Validate() expected error for malformed glob")
+	}
+}
+
+func TestSelectionIncludesName(t *testing.T) {
+	t.Parallel()
+
+	selection := Selection{Query: "is:dirty", Names: []string{"api", "api-legacy"}, Exclude: []string{"*-legacy"}}
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{name: "api", want: true},
+		{name: "api-legacy", want: false},
+		{name: "web", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := selection.IncludesName(tt.name); got != tt.want {
+				t.Fatalf("IncludesName(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectionUsesPullRequests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{query: "", want: false},
+		{query: "is:behind api", want: false},
+		{query: "has:pr", want: true},
+		{query: "-has:pr-blocked", want: true},
+		{query: "is:dirty has:my-pr", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			t.Parallel()
+			if got := (Selection{Query: tt.query}).UsesPullRequests(); got != tt.want {
+				t.Fatalf("UsesPullRequests() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
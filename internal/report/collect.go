@@ -0,0 +1,88 @@
+package report
+
+import (
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"fresh/internal/config"
+	"fresh/internal/domain"
+	"fresh/internal/git"
+	"fresh/internal/repofilter"
+	"fresh/internal/scanner"
+)
+
+type CollectOptions struct {
+	// Fetch updates remote-tracking refs before the remote state is read.
+	Fetch bool
+	// PullRequests queries pull request counts for repositories a provider
+	// serves. A selection with a pull request predicate queries them
+	// regardless.
+	PullRequests bool
+	// Selection narrows the result. Repositories its names and excludes
+	// reject are never built or fetched.
+	Selection repofilter.Selection
+	// Concurrency bounds how many repositories are built at once, defaulting
+	// to one per CPU.
+	Concurrency int
+}
+
+// Collect scans scanDir and builds every selected repository, the way the TUI
+// does, sorted by name and then path so output is stable between runs.
+func Collect(scanDir string, cfg *config.Config, opts CollectOptions) []domain.Repository {
+	s := scanner.New(scanDir)
+	go s.Scan()
+
+	mode := git.RefreshModeBuildOnly
+	if opts.Fetch {
+		mode = git.RefreshModeFetchAndBuild
+	}
+
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		repos []domain.Repository
+	)
+	workers := opts.Concurrency
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	paths := s.GetRepoChannel()
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range paths {
+				if !opts.Selection.IncludesName(filepath.Base(path)) {
+					continue
+				}
+				repo := git.RefreshRepository(path, cfg, git.RefreshRepositoryOptions{Mode: mode})
+				mu.Lock()
+				repos = append(repos, repo)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	sort.Slice(repos, func(i, j int) bool {
+		left, right := strings.ToLower(repos[i].Name), strings.ToLower(repos[j].Name)
+		if left != right {
+			return left < right
+		}
+		return repos[i].Path < repos[j].Path
+	})
+
+	if (opts.PullRequests || opts.Selection.UsesPullRequests()) && len(repos) > 0 {
+		prSync := git.DefaultProviders(cfg).GetPullRequestSync(repos)
+		for i := range repos {
+			if state, ok := prSync.States[repos[i].Path]; ok {
+				repos[i].PullRequests = state
+			}
+		}
+	}
+
+	return opts.Selection.Apply(repos)
+}
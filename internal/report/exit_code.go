@@ -0,0 +1,28 @@
+package report
+
+// Exit codes are bit flags so a script can tell every condition apart from
+// a single run; 1 is left for usage and runtime errors.
+const (
+	ExitOK       = 0
+	ExitDirty    = 2
+	ExitBehind   = 4
+	ExitDiverged = 8
+)
+
+// ExitCode combines the flags for every repository that is dirty, behind
+// its upstream or diverged from it.
+func ExitCode(statuses []RepositoryStatus) int {
+	code := ExitOK
+	for _, status := range statuses {
+		if status.Local.State == LocalDirty {
+			code |= ExitDirty
+		}
+		switch status.Remote.State {
+		case RemoteBehind:
+			code |= ExitBehind
+		case RemoteDiverged:
+			code |= ExitDiverged
+		}
+	}
+	return code
+}
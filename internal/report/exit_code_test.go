@@ -0,0 +1,33 @@
+package report
+
+import "testing"
+
+func TestExitCode(t *testing.T) {
+	t.Parallel()
+
+	clean := RepositoryStatus{Local: LocalStatus{State: LocalClean}, Remote: RemoteStatus{State: RemoteSynced}}
+	dirty := RepositoryStatus{Local: LocalStatus{State: LocalDirty}, Remote: RemoteStatus{State: RemoteSynced}}
+	behind := RepositoryStatus{Local: LocalStatus{State: LocalClean}, Remote: RemoteStatus{State: RemoteBehind}}
+	diverged := RepositoryStatus{Local: LocalStatus{State: LocalDirty}, Remote: RemoteStatus{State: RemoteDiverged}}
+
+	tests := []struct {
+		name     string
+		statuses []RepositoryStatus
+		want     int
+	}{
+		{name: "no repositories", want: ExitOK},
+		{name: "all clean", statuses: []RepositoryStatus{clean, clean}, want: ExitOK},
+		{name: "dirty", statuses: []RepositoryStatus{clean, dirty}, want: ExitDirty},
+		{name: "behind", statuses: []RepositoryStatus{behind}, want: ExitBehind},
+		{name: "combined", statuses: []RepositoryStatus{behind, diverged}, want: ExitDirty | ExitBehind | ExitDiverged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ExitCode(tt.statuses); got != tt.want {
+				t.Fatalf("ExitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+type Format string
+
+const (
+	FormatTable  Format = "table"
+	FormatJSON   Format = "json"
+	FormatNDJSON Format = "ndjson"
+)
+
+var Formats = []Format{FormatTable, FormatJSON, FormatNDJSON}
+
+func ParseFormat(value string) (Format, error) {
+	for _, format := range Formats {
+		if strings.EqualFold(value, string(format)) {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format %q (want table, json or ndjson)", value)
+}
+
+func Write(w io.Writer, format Format, statuses []RepositoryStatus) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, statuses)
+	case FormatNDJSON:
+		return writeNDJSON(w, statuses)
+	default:
+		return writeTable(w, statuses)
+	}
+}
+
+func writeJSON(w io.Writer, statuses []RepositoryStatus) error {
+	if statuses == nil {
+		statuses = []RepositoryStatus{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(statuses)
+}
+
+// writeNDJSON writes one compact object per line so results can be streamed
+// through tools such as jq without holding the whole array.
+func writeNDJSON(w io.Writer, statuses []RepositoryStatus) error {
+	encoder := json.NewEncoder(w)
+	for _, status := range statuses {
+		if err := encoder.Encode(status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeTable(w io.Writer, statuses []RepositoryStatus) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "REPO\tBRANCH\tLOCAL\tREMOTE\tSTASHES\tPRUNABLE\tPRS\tPATH")
+	for _, status := range statuses {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%d\t%d\t%s\t%s\n",
+			status.Name,
+			tableBranch(status),
+			tableLocal(status.Local),
+			tableRemote(status.Remote),
+			status.Stashes,
+			len(status.PrunableBranches),
+			tablePullRequests(status.PullRequests),
+			status.Path,
+		)
+	}
+	return tw.Flush()
+}
+
+func tableBranch(status RepositoryStatus) string {
+	switch {
+	case status.Detached:
+		return "(detached)"
+	case status.Branch == "":
+		return "-"
+	default:
+		return status.Branch
+	}
+}
+
+func tableLocal(local LocalStatus) string {
+	if local.State != LocalDirty {
+		return local.State
+	}
+
+	var parts []string
+	for _, count := range []struct {
+		prefix string
+		value  int
+	}{{"+", local.Added}, {"~", local.Modified}, {"-", local.Deleted}, {"?", local.Untracked}} {
+		if count.value > 0 {
+			parts = append(parts, count.prefix+strconv.Itoa(count.value))
+		}
+	}
+	return local.State + " " + strings.Join(parts, " ")
+}
+
+func tableRemote(remote RemoteStatus) string {
+	switch remote.State {
+	case RemoteAhead:
+		return fmt.Sprintf("ahead %d", remote.Ahead)
+	case RemoteBehind:
+		return fmt.Sprintf("behind %d", remote.Behind)
+	case RemoteDiverged:
+		return fmt.Sprintf("diverged +%d -%d", remote.Ahead, remote.Behind)
+	default:
+		return remote.State
+	}
+}
+
+func tablePullRequests(prs *PullRequestStatus) string {
+	switch {
+	case prs == nil:
+		return "-"
+	case prs.Error != "":
+		return "error"
+	}
+
+	var notes []string
+	if prs.MyOpen > 0 {
+		notes = append(notes, fmt.Sprintf("%d mine", prs.MyOpen))
+	}
+	if prs.ReviewRequested > 0 {
+		notes = append(notes, fmt.Sprintf("%d to review", prs.ReviewRequested))
+	}
+	if len(notes) == 0 {
+		return openPullRequests(prs)
+	}
+	return fmt.Sprintf("%s (%s)", openPullRequests(prs), strings.Join(notes, ", "))
+}
+
+// openPullRequests marks partial counts with a trailing +.
+func openPullRequests(prs *PullRequestStatus) string {
+	if prs.Partial {
+		return strconv.Itoa(prs.Open) + "+"
+	}
+	return strconv.Itoa(prs.Open)
+}
@@ -0,0 +1,132 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func testStatuses() []RepositoryStatus {
+	return []RepositoryStatus{
+		{
+			Name:   "api",
+			Path:   "/src/api",
+			Branch: "main",
+			Local:  LocalStatus{State: LocalDirty, Modified: 2},
+			Remote: RemoteStatus{State: RemoteBehind, Behind: 3},
+		},
+		{
+			Name:         "web",
+			Path:         "/src/web",
+			Branch:       "feature",
+			Local:        LocalStatus{State: LocalClean},
+			Remote:       RemoteStatus{State: RemoteSynced},
+			PullRequests: &PullRequestStatus{Open: 2, MyOpen: 1},
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"table", "JSON", "ndjson"} {
+		if _, err := ParseFormat(value); err != nil {
+			t.Fatalf("ParseFormat(%q) unexpected error: %v", value, err)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Fatal("ParseFormat(xml) expected error")
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, testStatuses()); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	var got []RepositoryStatus
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, buf.String())
+	}
+	if len(got) != 2 || got[0].Remote.Behind != 3 || got[1].PullRequests.MyOpen != 1 {
+		t.Fatalf("decoded = %+v", got)
+	}
+}
+
+func TestWriteJSONWithoutRepositoriesIsEmptyArray(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatJSON, nil); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	if strings.TrimSpace(buf.String()) != "[]" {
+		t.Fatalf("output = %q, want []", buf.String())
+	}
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatNDJSON, testStatuses()); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines = %d, want 2:\n%s", len(lines), buf.String())
+	}
+	for _, line := range lines {
+		var status RepositoryStatus
+		if err := json.Unmarshal([]byte(line), &status); err != nil {
+			t.Fatalf("line %q is not a JSON object: %v", line, err)
+		}
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := Write(&buf, FormatTable, testStatuses()); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+
+	out := buf.String()
+	for _, want := range []string{"REPO", "dirty ~2", "behind 3", "2 (1 mine)", "/src/web"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("table missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestTablePullRequests(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		prs  *PullRequestStatus
+		want string
+	}{
+		{name: "unavailable", prs: nil, want: "-"},
+		{name: "error", prs: &PullRequestStatus{Error: "rate limited"}, want: "error"},
+		{name: "open only", prs: &PullRequestStatus{Open: 4}, want: "4"},
+		{name: "partial", prs: &PullRequestStatus{Open: 1000, Partial: true}, want: "1000+"},
+		{name: "mine", prs: &PullRequestStatus{Open: 4, MyOpen: 1}, want: "4 (1 mine)"},
+		{name: "to review", prs: &PullRequestStatus{Open: 4, MyOpen: 1, ReviewRequested: 2}, want: "4 (1 mine, 2 to review)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tablePullRequests(tt.prs); got != tt.want {
+				t.Errorf("tablePullRequests() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package report
+
+import "fresh/internal/domain"
+
+// RepositoryStatus is the machine-readable form of a repository. Field names
+// are part of the JSON output and should only ever be added to.
+type RepositoryStatus struct {
+	Name             string             `json:"name"`
+	Path             string             `json:"path"`
+	Branch           string             `json:"branch"`
+	Detached         bool               `json:"detached"`
+	Local            LocalStatus        `json:"local"`
+	Remote           RemoteStatus       `json:"remote"`
+	Stashes          int                `json:"stashes"`
+	PrunableBranches []string           `json:"prunable_branches"`
+	PullRequests     *PullRequestStatus `json:"pull_requests,omitempty"`
+	Fork             *ForkStatus        `json:"fork,omitempty"`
+}
+
+type LocalStatus struct {
+	State     string `json:"state"`
+	Added     int    `json:"added"`
+	Modified  int    `json:"modified"`
+	Deleted   int    `json:"deleted"`
+	Untracked int    `json:"untracked"`
+	Error     string `json:"error,omitempty"`
+}
+
+type RemoteStatus struct {
+	State  string `json:"state"`
+	Ahead  int    `json:"ahead"`
+	Behind int    `json:"behind"`
+	Error  string `json:"error,omitempty"`
+}
+
+type PullRequestStatus struct {
+	Open      int `json:"open"`
+	MyOpen    int `json:"my_open"`
+	MyReady   int `json:"my_ready"`
+	MyBlocked int `json:"my_blocked"`
+	MyReview  int `json:"my_review"`
+	MyChecks  int `json:"my_checks"`
+	// ReviewRequested counts other people's pull requests waiting on the
+	// user's review.
+	ReviewRequested int `json:"review_requested"`
+	// Partial is set when a search limit was reached and the counts may be
+	// too low.
+	Partial bool   `json:"partial,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type ForkStatus struct {
+	Branch string `json:"branch"`
+	Ahead  int    `json:"ahead"`
+	Behind int    `json:"behind"`
+	Error  string `json:"error,omitempty"`
+}
+
+const (
+	LocalClean = "clean"
+	LocalDirty = "dirty"
+	LocalError = "error"
+
+	RemoteSynced     = "synced"
+	RemoteAhead      = "ahead"
+	RemoteBehind     = "behind"
+	RemoteDiverged   = "diverged"
+	RemoteNoUpstream = "no-upstream"
+	RemoteDetached   = "detached"
+	RemoteError      = "error"
+)
+
+func NewRepositoryStatus(repo domain.Repository) RepositoryStatus {
+	status := RepositoryStatus{
+		Name:             repo.Name,
+		Path:             repo.Path,
+		Stashes:          repo.StashCount,
+		PrunableBranches: append([]string{}, repo.Branches.Merged...),
+		Local:            newLocalStatus(repo.LocalState),
+		Remote:           newRemoteStatus(repo.RemoteState),
+		PullRequests:     newPullRequestStatus(repo.PullRequests),
+		Fork:             newForkStatus(repo.Fork),
+	}
+
+	switch branch := repo.Branches.Current.(type) {
+	case domain.OnBranch:
+		status.Branch = branch.Name
+	case domain.DetachedHead:
+		status.Branch = branch.CommitSHA
+		status.Detached = true
+	}
+	return status
+}
+
+func NewRepositoryStatuses(repos []domain.Repository) []RepositoryStatus {
+	statuses := make([]RepositoryStatus, len(repos))
+	for i, repo := range repos {
+		statuses[i] = NewRepositoryStatus(repo)
+	}
+	return statuses
+}
+
+func newLocalStatus(state domain.LocalState) LocalStatus {
+	switch s := state.(type) {
+	case domain.DirtyLocalState:
+		return LocalStatus{
+			State:     LocalDirty,
+			Added:     s.Added,
+			Modified:  s.Modified,
+			Deleted:   s.Deleted,
+			Untracked: s.Untracked,
+		}
+	case domain.LocalStateError:
+		return LocalStatus{State: LocalError, Error: s.Message}
+	default:
+		return LocalStatus{State: LocalClean}
+	}
+}
+
+func newRemoteStatus(state domain.RemoteState) RemoteStatus {
+	switch s := state.(type) {
+	case domain.Ahead:
+		return RemoteStatus{State: RemoteAhead, Ahead: s.Count}
+	case domain.Behind:
+		return RemoteStatus{State: RemoteBehind, Behind: s.Count}
+	case domain.Diverged:
+		return RemoteStatus{State: RemoteDiverged, Ahead: s.AheadCount, Behind: s.BehindCount}
+	case domain.NoUpstream:
+		return RemoteStatus{State: RemoteNoUpstream}
+	case domain.DetachedRemote:
+		return RemoteStatus{State: RemoteDetached}
+	case domain.RemoteError:
+		return RemoteStatus{State: RemoteError, Error: s.Message}
+	default:
+		return RemoteStatus{State: RemoteSynced}
+	}
+}
+
+func newPullRequestStatus(state domain.PullRequestState) *PullRequestStatus {
+	switch s := state.(type) {
+	case domain.PullRequestCount:
+		return &PullRequestStatus{
+			Open:            s.Open,
+			MyOpen:          s.MyOpen,
+			MyReady:         s.MyReady,
+			MyBlocked:       s.MyBlocked,
+			MyReview:        s.MyReview,
+			MyChecks:        s.MyChecks,
+			ReviewRequested: s.ReviewRequested,
+			Partial:         s.Partial,
+		}
+	case domain.PullRequestError:
+		return &PullRequestStatus{Error: s.Message}
+	default:
+		return nil
+	}
+}
+
+func newForkStatus(state domain.ForkState) *ForkStatus {
+	switch s := state.(type) {
+	case domain.ForkStatus:
+		return &ForkStatus{Branch: s.Branch, Ahead: s.Ahead, Behind: s.Behind}
+	case domain.ForkError:
+		return &ForkStatus{Error: s.Message}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,84 @@
+package report
+
+import (
+	"testing"
+
+	"fresh/internal/domain"
+	"fresh/internal/testhelpers"
+)
+
+func TestNewRepositoryStatus(t *testing.T) {
+	t.Parallel()
+
+	repo := testhelpers.NewTestRepository("api").
+		LocalState(domain.DirtyLocalState{Added: 1, Untracked: 2}).
+		RemoteState(domain.Diverged{AheadCount: 1, BehindCount: 3}).
+		PullRequests(domain.PullRequestCount{Open: 4, MyOpen: 1}).
+		Build()
+	repo.StashCount = 2
+	repo.Branches.Merged = []string{"old"}
+
+	got := NewRepositoryStatus(repo)
+	if got.Branch != "main" || got.Detached {
+		t.Fatalf("branch = %q detached %v, want main", got.Branch, got.Detached)
+	}
+	if got.Local != (LocalStatus{State: LocalDirty, Added: 1, Untracked: 2}) {
+		t.Fatalf("local = %+v", got.Local)
+	}
+	if got.Remote != (RemoteStatus{State: RemoteDiverged, Ahead: 1, Behind: 3}) {
+		t.Fatalf("remote = %+v", got.Remote)
+	}
+	if got.PullRequests == nil || got.PullRequests.Open != 4 || got.PullRequests.MyOpen != 1 {
+		t.Fatalf("pull requests = %+v", got.PullRequests)
+	}
+	if got.Stashes != 2 || len(got.PrunableBranches) != 1 {
+		t.Fatalf("stashes = %d prunable = %v", got.Stashes, got.PrunableBranches)
+	}
+	if got.Fork != nil {
+		t.Fatalf("fork = %+v, want nil", got.Fork)
+	}
+}
+
+func TestNewRepositoryStatusReportsDetachedCommit(t *testing.T) {
+	t.Parallel()
+
+	repo := testhelpers.NewTestRepository("api").
+		CurrentBranch(domain.DetachedHead{CommitSHA: "3f2a9c1"}).
+		Build()
+
+	got := NewRepositoryStatus(repo)
+	if got.Branch != "3f2a9c1" || !got.Detached {
+		t.Fatalf("branch = %q detached %v, want the detached commit 3f2a9c1", got.Branch, got.Detached)
+	}
+}
+
+func TestNewRepositoryStatusStates(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		remote     domain.RemoteState
+		prs        domain.PullRequestState
+		wantRemote string
+		wantPRs    bool
+	}{
+		{name: "synced", remote: domain.Synced{}, prs: domain.PullRequestUnavailable{}, wantRemote: RemoteSynced},
+		{name: "behind", remote: domain.Behind{Count: 2}, prs: domain.PullRequestCount{}, wantRemote: RemoteBehind, wantPRs: true},
+		{name: "no upstream", remote: domain.NoUpstream{}, prs: domain.PullRequestError{Message: "gh missing"}, wantRemote: RemoteNoUpstream, wantPRs: true},
+		{name: "error", remote: domain.RemoteError{Message: "boom"}, wantRemote: RemoteError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			repo := testhelpers.NewTestRepository("api").RemoteState(tt.remote).PullRequests(tt.prs).Build()
+			got := NewRepositoryStatus(repo)
+			if got.Remote.State != tt.wantRemote {
+				t.Fatalf("remote state = %q, want %q", got.Remote.State, tt.wantRemote)
+			}
+			if (got.PullRequests != nil) != tt.wantPRs {
+				t.Fatalf("pull requests = %+v, want present %v", got.PullRequests, tt.wantPRs)
+			}
+		})
+	}
+}
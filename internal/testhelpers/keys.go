@@ -0,0 +1,25 @@
+package testhelpers
+
+import (
+	tea "charm.land/bubbletea/v2"
+)
+
+// KeyPress returns the message a terminal sends for key: a single
+// character, or "enter", "esc" or "tab".
+func KeyPress(key string) tea.KeyPressMsg {
+	switch key {
+	case "enter":
+		return tea.KeyPressMsg{Code: tea.KeyEnter}
+	case "esc":
+		return tea.KeyPressMsg{Code: tea.KeyEscape}
+	case "tab":
+		return tea.KeyPressMsg{Code: tea.KeyTab}
+	}
+	return tea.KeyPressMsg{Code: []rune(key)[0], Text: key}
+}
+
+// Press sends key to a view model and returns the command it produced.
+func Press[M interface{ Update(tea.Msg) (M, tea.Cmd) }](m M, key string) tea.Cmd {
+	_, cmd := m.Update(KeyPress(key))
+	return cmd
+}
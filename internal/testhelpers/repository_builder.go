@@ -1,6 +1,10 @@
 package testhelpers
 
-import "fresh/internal/domain"
+import (
+	"time"
+
+	"fresh/internal/domain"
+)
 
 type RepositoryBuilder struct {
 	repo domain.Repository
@@ -34,6 +38,11 @@ func (b *RepositoryBuilder) RemoteURL(remoteURL string) *RepositoryBuilder {
 	return b
 }
 
+func (b *RepositoryBuilder) Fork(state domain.ForkState) *RepositoryBuilder {
+	b.repo.Fork = state
+	return b
+}
+
 func (b *RepositoryBuilder) Activity(activity domain.Activity) *RepositoryBuilder {
 	b.repo.Activity = activity
 	return b
@@ -69,6 +78,11 @@ func (b *RepositoryBuilder) StashCount(count int) *RepositoryBuilder {
 	return b
 }
 
+func (b *RepositoryBuilder) LastCommitAt(at time.Time) *RepositoryBuilder {
+	b.repo.LastCommitAt = at
+	return b
+}
+
 func (b *RepositoryBuilder) Build() domain.Repository {
 	return b.repo
 }
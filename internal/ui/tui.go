@@ -1,11 +1,18 @@
 package ui
 
 import (
+	"fresh/internal/config"
 	"fresh/internal/domain"
+	"fresh/internal/git"
 	"fresh/internal/notifications"
+	"fresh/internal/ui/views/branches"
+	"fresh/internal/ui/views/commitlog"
+	"fresh/internal/ui/views/details"
 	"fresh/internal/ui/views/listing"
+	"fresh/internal/ui/views/pullrequest"
 	"fresh/internal/ui/views/pullrequests"
 	"fresh/internal/ui/views/scanning"
+	"fresh/internal/ui/views/stashes"
 
 	tea "charm.land/bubbletea/v2"
 )
@@ -16,6 +23,11 @@ const (
 	ScanningView CurrentView = iota
 	RepoListView
 	RepoPRListView
+	RepoPRView
+	RepoDetailsView
+	RepoStashesView
+	RepoCommitLogView
+	RepoBranchesView
 )
 
 type MainModel struct {
@@ -23,12 +35,19 @@ type MainModel struct {
 	scanningView     *scanning.Model
 	listingView      *listing.Model
 	pullRequestsView *pullrequests.Model
+	pullRequestView  *pullrequest.Model
+	detailsView      *details.Model
+	stashesView      *stashes.Model
+	commitLogView    *commitlog.Model
+	branchesView     *branches.Model
 	pullRequestCache map[string][]domain.PullRequestDetails
+	providers        *git.ProviderRegistry
+	cfg              *config.Config
 	notifier         *notifications.Notifier
 	width, height    int
 }
 
-func New(scanDir string, notifier ...*notifications.Notifier) *MainModel {
+func New(scanDir string, cfg *config.Config, notifier ...*notifications.Notifier) *MainModel {
 	var injectedNotifier *notifications.Notifier
 	if len(notifier) > 0 {
 		injectedNotifier = notifier[0]
@@ -36,8 +55,10 @@ func New(scanDir string, notifier ...*notifications.Notifier) *MainModel {
 
 	return &MainModel{
 		currentView:      ScanningView,
-		scanningView:     scanning.New(scanDir),
+		scanningView:     scanning.New(scanDir, cfg),
 		pullRequestCache: make(map[string][]domain.PullRequestDetails),
+		providers:        git.DefaultProviders(cfg),
+		cfg:              cfg,
 		notifier:         injectedNotifier,
 	}
 }
@@ -50,25 +71,40 @@ func (m *MainModel) Init() tea.Cmd {
 		return m.listingView.Init()
 	case RepoPRListView:
 		return m.pullRequestsView.Init()
+	case RepoPRView:
+		return m.pullRequestView.Init()
+	case RepoDetailsView:
+		return m.detailsView.Init()
+	case RepoStashesView:
+		return m.stashesView.Init()
+	case RepoCommitLogView:
+		return m.commitLogView.Init()
+	case RepoBranchesView:
+		return m.branchesView.Init()
 	default:
 		return nil
 	}
 }
 
 func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
+	var cmd, followUp tea.Cmd
 
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 	case tea.KeyPressMsg:
-		if msg.String() == "ctrl+c" || msg.String() == "q" {
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if msg.String() == "q" && !m.isCapturingInput() {
 			return m, tea.Quit
 		}
 	case scanning.ScanFinishedMsg:
 		m.currentView = RepoListView
 		m.listingView = listing.NewWithNotifier(msg.Repos, m.notifier)
+		m.listingView.Providers = m.providers
+		m.listingView.Config = m.cfg
 		m.listingView.SetSize(m.width, m.height)
 		return m, m.listingView.Init()
 
@@ -76,10 +112,61 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cached := append([]domain.PullRequestDetails(nil), m.pullRequestCache[msg.Repo.Path]...)
 		m.currentView = RepoPRListView
 		m.pullRequestsView = pullrequests.New(msg.Repo, cached)
+		m.pullRequestsView.Providers = m.providers
+		m.pullRequestsView.Config = m.cfg
 		m.pullRequestsView.SetSize(m.width, m.height)
 		return m, m.pullRequestsView.Init()
 
-	case pullrequests.BackToRepoListMsg:
+	case pullrequests.OpenPullRequestMsg:
+		m.currentView = RepoPRView
+		m.pullRequestView = pullrequest.New(msg.Repo, msg.Number)
+		m.pullRequestView.Providers = m.providers
+		m.pullRequestView.Config = m.cfg
+		m.pullRequestView.SetSize(m.width, m.height)
+		return m, m.pullRequestView.Init()
+
+	case pullrequest.BackToPullRequestsMsg:
+		m.currentView = RepoPRListView
+		if m.pullRequestsView != nil {
+			m.pullRequestsView.SetSize(m.width, m.height)
+		}
+		return m, nil
+
+	case listing.OpenRepositoryDetailsMsg:
+		m.currentView = RepoDetailsView
+		m.detailsView = details.New(msg.Repo)
+		m.detailsView.SetSize(m.width, m.height)
+		return m, m.detailsView.Init()
+
+	case listing.OpenStashesMsg:
+		m.currentView = RepoStashesView
+		m.stashesView = stashes.New(msg.Repo, m.cfg)
+		m.stashesView.SetSize(m.width, m.height)
+		return m, m.stashesView.Init()
+
+	case listing.OpenCommitLogMsg:
+		m.currentView = RepoCommitLogView
+		m.commitLogView = commitlog.New(msg.Repo)
+		m.commitLogView.SetSize(m.width, m.height)
+		return m, m.commitLogView.Init()
+
+	case listing.OpenBranchesMsg:
+		m.currentView = RepoBranchesView
+		m.branchesView = branches.New(msg.Repo)
+		m.branchesView.SetSize(m.width, m.height)
+		return m, m.branchesView.Init()
+
+	case stashes.StashActionCompleteMsg:
+		if m.listingView != nil {
+			followUp = m.listingView.RefreshRepository(msg.RepoPath)
+		}
+
+	case branches.CheckoutCompleteMsg:
+		if m.listingView != nil {
+			followUp = m.listingView.RefreshRepository(msg.RepoPath)
+		}
+
+	case pullrequests.BackToRepoListMsg, details.BackToRepoListMsg, stashes.BackToRepoListMsg, commitlog.BackToRepoListMsg, branches.BackToRepoListMsg:
 		m.currentView = RepoListView
 		if m.listingView != nil {
 			m.listingView.SetSize(m.width, m.height)
@@ -105,10 +192,55 @@ func (m *MainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.pullRequestsView != nil {
 			m.pullRequestsView, cmd = m.pullRequestsView.Update(msg)
 		}
+	case RepoPRView:
+		if m.pullRequestView != nil {
+			m.pullRequestView, cmd = m.pullRequestView.Update(msg)
+		}
+	case RepoDetailsView:
+		if m.detailsView != nil {
+			m.detailsView, cmd = m.detailsView.Update(msg)
+		}
+	case RepoStashesView:
+		if m.stashesView != nil {
+			m.stashesView, cmd = m.stashesView.Update(msg)
+		}
+	case RepoCommitLogView:
+		if m.commitLogView != nil {
+			m.commitLogView, cmd = m.commitLogView.Update(msg)
+		}
+	case RepoBranchesView:
+		if m.branchesView != nil {
+			m.branchesView, cmd = m.branchesView.Update(msg)
+		}
+	}
+
+	if background := m.updateListingInBackground(msg); background != nil || followUp != nil {
+		return m, tea.Batch(cmd, background, followUp)
 	}
 	return m, cmd
 }
 
+// updateListingInBackground keeps the repository list receiving its own
+// progress and sync messages while another view is in front, so streamed
+// pulls and refreshes are not dropped. Everything else, key presses
+// included, stays with the active view.
+func (m *MainModel) updateListingInBackground(msg tea.Msg) tea.Cmd {
+	if m.currentView == RepoListView || m.currentView == ScanningView || m.listingView == nil {
+		return nil
+	}
+	if !listing.IsBackgroundMsg(msg) {
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.listingView, cmd = m.listingView.Update(msg)
+	return cmd
+}
+
+func (m *MainModel) isCapturingInput() bool {
+	return m.currentView == RepoListView && m.listingView != nil && m.listingView.IsCapturingInput()
+}
+
 func (m *MainModel) View() tea.View {
 	v := tea.NewView("")
 	switch m.currentView {
@@ -124,6 +256,31 @@ func (m *MainModel) View() tea.View {
 			return v
 		}
 		v.SetContent(m.pullRequestsView.View())
+	case RepoPRView:
+		if m.pullRequestView == nil {
+			return v
+		}
+		v.SetContent(m.pullRequestView.View())
+	case RepoDetailsView:
+		if m.detailsView == nil {
+			return v
+		}
+		v.SetContent(m.detailsView.View())
+	case RepoStashesView:
+		if m.stashesView == nil {
+			return v
+		}
+		v.SetContent(m.stashesView.View())
+	case RepoCommitLogView:
+		if m.commitLogView == nil {
+			return v
+		}
+		v.SetContent(m.commitLogView.View())
+	case RepoBranchesView:
+		if m.branchesView == nil {
+			return v
+		}
+		v.SetContent(m.branchesView.View())
 	}
 	return v
 }
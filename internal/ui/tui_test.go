@@ -1,17 +1,22 @@
 package ui
 
 import (
+	"fresh/internal/config"
 	"fresh/internal/domain"
+	"fresh/internal/ui/views/pullrequests"
 	"fresh/internal/ui/views/scanning"
+	"os/exec"
 	"testing"
+	"time"
 
+	"charm.land/bubbles/v2/spinner"
 	tea "charm.land/bubbletea/v2"
 )
 
 func TestMainModel_InitialViewIsScanning(t *testing.T) {
 	t.Parallel()
 
-	m := New(t.TempDir())
+	m := New(t.TempDir(), config.DefaultConfig())
 	if m.currentView != ScanningView {
 		t.Errorf("initial view = %d, want ScanningView (%d)", m.currentView, ScanningView)
 	}
@@ -20,7 +25,7 @@ func TestMainModel_InitialViewIsScanning(t *testing.T) {
 func TestMainModel_ScanFinishedMsg_TransitionsToListingView(t *testing.T) {
 	t.Parallel()
 
-	m := New(t.TempDir())
+	m := New(t.TempDir(), config.DefaultConfig())
 
 	repos := []domain.Repository{
 		makeTestRepository("test-repo"),
@@ -47,7 +52,7 @@ func TestMainModel_ScanFinishedMsg_TransitionsToListingView(t *testing.T) {
 func TestMainModel_QuitOnCtrlC(t *testing.T) {
 	t.Parallel()
 
-	m := New(t.TempDir())
+	m := New(t.TempDir(), config.DefaultConfig())
 	msg := tea.KeyPressMsg{Code: 'c', Mod: tea.ModCtrl}
 
 	_, cmd := m.Update(msg)
@@ -59,7 +64,7 @@ func TestMainModel_QuitOnCtrlC(t *testing.T) {
 func TestMainModel_QuitOnQ(t *testing.T) {
 	t.Parallel()
 
-	m := New(t.TempDir())
+	m := New(t.TempDir(), config.DefaultConfig())
 	msg := tea.KeyPressMsg{Code: 'q'}
 
 	_, cmd := m.Update(msg)
@@ -71,7 +76,7 @@ func TestMainModel_QuitOnQ(t *testing.T) {
 func TestMainModel_WindowSizeMsg_StoresDimensions(t *testing.T) {
 	t.Parallel()
 
-	m := New(t.TempDir())
+	m := New(t.TempDir(), config.DefaultConfig())
 	msg := tea.WindowSizeMsg{Width: 200, Height: 50}
 
 	result, _ := m.Update(msg)
@@ -88,7 +93,7 @@ func TestMainModel_WindowSizeMsg_StoresDimensions(t *testing.T) {
 func TestMainModel_DelegatesKeyMsgToListingInRepoListView(t *testing.T) {
 	t.Parallel()
 
-	m := New(t.TempDir())
+	m := New(t.TempDir(), config.DefaultConfig())
 
 	repos := []domain.Repository{
 		makeTestRepository("a"),
@@ -107,7 +112,7 @@ func TestMainModel_DelegatesKeyMsgToListingInRepoListView(t *testing.T) {
 func TestMainModel_EnterTransitionsToPullRequestView(t *testing.T) {
 	t.Parallel()
 
-	m := New(t.TempDir())
+	m := New(t.TempDir(), config.DefaultConfig())
 
 	repos := []domain.Repository{
 		newTestRepository("repo-a").RemoteURL("https://github.com/octo/repo-a").Build(),
@@ -136,7 +141,7 @@ func TestMainModel_EnterTransitionsToPullRequestView(t *testing.T) {
 func TestMainModel_EscapeTransitionsBackToListingView(t *testing.T) {
 	t.Parallel()
 
-	m := New(t.TempDir())
+	m := New(t.TempDir(), config.DefaultConfig())
 
 	repos := []domain.Repository{
 		newTestRepository("repo-a").RemoteURL("https://github.com/octo/repo-a").Build(),
@@ -160,10 +165,37 @@ func TestMainModel_EscapeTransitionsBackToListingView(t *testing.T) {
 	}
 }
 
+func TestMainModel_PullRequestEscapesBackToPullRequestList(t *testing.T) {
+	t.Parallel()
+
+	m := New(t.TempDir(), config.DefaultConfig())
+
+	repos := []domain.Repository{
+		newTestRepository("repo-a").RemoteURL("https://github.com/octo/repo-a").Build(),
+	}
+	m.Update(scanning.ScanFinishedMsg{Repos: repos})
+	_, openCmd := m.Update(tea.KeyPressMsg{Code: '\r'})
+	m.Update(openCmd())
+
+	m.Update(pullrequests.OpenPullRequestMsg{Repo: repos[0], Number: 7})
+	if m.currentView != RepoPRView || m.pullRequestView == nil || m.pullRequestView.Number != 7 {
+		t.Fatalf("view = %d, want RepoPRView (%d) showing #7", m.currentView, RepoPRView)
+	}
+
+	_, backCmd := m.Update(tea.KeyPressMsg{Code: 27})
+	if backCmd == nil {
+		t.Fatal("expected non-nil back command after escape")
+	}
+	m.Update(backCmd())
+	if m.currentView != RepoPRListView {
+		t.Errorf("view after esc = %d, want RepoPRListView (%d)", m.currentView, RepoPRListView)
+	}
+}
+
 func TestMainModel_ScanFinishedMsg_WithEmptyRepos(t *testing.T) {
 	t.Parallel()
 
-	m := New(t.TempDir())
+	m := New(t.TempDir(), config.DefaultConfig())
 	msg := scanning.ScanFinishedMsg{Repos: []domain.Repository{}}
 
 	result, _ := m.Update(msg)
@@ -176,3 +208,100 @@ func TestMainModel_ScanFinishedMsg_WithEmptyRepos(t *testing.T) {
 		t.Errorf("repos count = %d, want 0", len(model.listingView.Repositories))
 	}
 }
+
+func TestMainModel_QDoesNotQuitWhileFiltering(t *testing.T) {
+	t.Parallel()
+
+	m := New(t.TempDir(), config.DefaultConfig())
+	m.Update(scanning.ScanFinishedMsg{Repos: []domain.Repository{makeTestRepository("quartz")}})
+	m.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	if cmd != nil {
+		if _, ok := cmd().(tea.QuitMsg); ok {
+			t.Fatal("did not expect q to quit while typing a filter")
+		}
+	}
+	if m.listingView.FilterInput.Value() != "q" {
+		t.Fatalf("filter value = %q, want %q", m.listingView.FilterInput.Value(), "q")
+	}
+}
+
+func TestMainModel_DetailsKeyTransitionsToDetailsView(t *testing.T) {
+	t.Parallel()
+
+	m := New(t.TempDir(), config.DefaultConfig())
+	repos := []domain.Repository{makeTestRepository("repo-a")}
+	m.Update(scanning.ScanFinishedMsg{Repos: repos})
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: 'i', Text: "i"})
+	if cmd == nil {
+		t.Fatal("expected non-nil cmd after i")
+	}
+
+	result, _ := m.Update(cmd())
+	model := result.(*MainModel)
+	if model.currentView != RepoDetailsView {
+		t.Fatalf("view after i = %d, want RepoDetailsView (%d)", model.currentView, RepoDetailsView)
+	}
+	if model.detailsView == nil || model.detailsView.Repo.Path != repos[0].Path {
+		t.Fatal("expected details view for selected repository")
+	}
+}
+
+func TestMainModel_PullStartedBeforeOpeningDetailsCompletes(t *testing.T) {
+	t.Parallel()
+
+	// A repository without an upstream makes git pull fail at once, which
+	// completes the pull just the same.
+	dir := t.TempDir()
+	if output, err := exec.Command("git", "init", "--quiet", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v: %s", err, output)
+	}
+
+	m := New(t.TempDir(), config.DefaultConfig())
+	repo := newTestRepository("repo-a").Path(dir).RemoteState(domain.Behind{Count: 1}).Build()
+	m.Update(scanning.ScanFinishedMsg{Repos: []domain.Repository{repo}})
+	// Stand in for the initial refresh having finished.
+	m.listingView.Repositories[0].Activity = &domain.IdleActivity{}
+
+	_, pull := m.Update(tea.KeyPressMsg{Code: 'p', Text: "p"})
+	if pull == nil || !m.listingView.Repositories[0].IsBusy() {
+		t.Fatal("expected p to start a pull")
+	}
+	_, open := m.Update(tea.KeyPressMsg{Code: 'i', Text: "i"})
+	m.Update(open())
+	if m.currentView != RepoDetailsView {
+		t.Fatalf("view after i = %d, want RepoDetailsView (%d)", m.currentView, RepoDetailsView)
+	}
+
+	msgs := make(chan tea.Msg, 64)
+	run := func(cmd tea.Cmd) {
+		if cmd != nil {
+			go func() { msgs <- cmd() }()
+		}
+	}
+	run(pull)
+
+	deadline := time.After(10 * time.Second)
+	for m.listingView.Repositories[0].IsBusy() {
+		select {
+		case msg := <-msgs:
+			switch msg := msg.(type) {
+			case tea.BatchMsg:
+				for _, cmd := range msg {
+					run(cmd)
+				}
+			case spinner.TickMsg:
+			default:
+				_, cmd := m.Update(msg)
+				run(cmd)
+			}
+		case <-deadline:
+			t.Fatal("pull did not complete while the details view was in front")
+		}
+	}
+	if m.currentView != RepoDetailsView {
+		t.Fatal("expected the details view to stay in front")
+	}
+}
@@ -0,0 +1,256 @@
+package branches
+
+import (
+	"fmt"
+	"strings"
+
+	"fresh/internal/domain"
+	"fresh/internal/ui/views/common"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/spinner"
+	tea "charm.land/bubbletea/v2"
+)
+
+type branchKeyMap struct {
+	checkout key.Binding
+	refresh  key.Binding
+	back     key.Binding
+}
+
+func newBranchKeyMap() *branchKeyMap {
+	return &branchKeyMap{
+		checkout: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "check out branch"),
+		),
+		refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh branches"),
+		),
+		back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back to repositories"),
+		),
+	}
+}
+
+// RemoteBranch is a branch that only exists on a remote. Checking it out
+// creates a local tracking branch named Name.
+type RemoteBranch struct {
+	Ref  string
+	Name string
+}
+
+type Model struct {
+	Repo          domain.Repository
+	Local         []domain.BranchStatus
+	Remote        []RemoteBranch
+	DefaultBranch string
+	Cursor        int
+	Keys          *branchKeyMap
+	Loading       bool
+	Busy          bool
+	Spinner       spinner.Model
+	Status        string
+	StatusFailed  bool
+	width, height int
+}
+
+func New(repo domain.Repository) *Model {
+	return &Model{
+		Repo:    repo,
+		Keys:    newBranchKeyMap(),
+		Loading: true,
+		Spinner: common.NewPullRequestSpinner(),
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *Model) Init() tea.Cmd {
+	return tea.Batch(performBranchLoad(m.Repo), m.Spinner.Tick)
+}
+
+func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, m.Keys.back) || msg.Code == 27:
+			return m, backToRepoList()
+		case key.Matches(msg, m.Keys.refresh):
+			m.Loading = true
+			return m, tea.Batch(performBranchLoad(m.Repo), m.Spinner.Tick)
+		case key.Matches(msg, m.Keys.checkout) || msg.Code == '\r':
+			return m, m.checkoutSelected()
+		case msg.String() == "up", msg.String() == "k":
+			if m.Cursor > 0 {
+				m.Cursor--
+			}
+		case msg.String() == "down", msg.String() == "j":
+			if m.Cursor < m.branchCount()-1 {
+				m.Cursor++
+			}
+		}
+
+	case BranchesLoadedMsg:
+		if msg.RepoPath != m.Repo.Path {
+			return m, nil
+		}
+		m.Loading = false
+		m.Busy = false
+		m.Local = msg.Local
+		m.Remote = remoteOnlyBranches(msg.Local, msg.RemoteBranches)
+		m.DefaultBranch = msg.DefaultBranch
+		m.Cursor = max(0, min(m.Cursor, m.branchCount()-1))
+
+	case CheckoutCompleteMsg:
+		if msg.RepoPath != m.Repo.Path {
+			return m, nil
+		}
+		m.Status, m.StatusFailed = describeCheckout(msg.Outcome)
+		m.Loading = true
+		return m, tea.Batch(performBranchLoad(m.Repo), m.Spinner.Tick)
+
+	case spinner.TickMsg:
+		if m.Loading || m.Busy {
+			var cmd tea.Cmd
+			m.Spinner, cmd = m.Spinner.Update(msg)
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+// remoteOnlyBranches keeps remote branches without a local branch of the same
+// name, one per name, preferring origin when several remotes have it.
+func remoteOnlyBranches(local []domain.BranchStatus, refs []string) []RemoteBranch {
+	localNames := make(map[string]bool, len(local))
+	for _, branch := range local {
+		localNames[branch.Name] = true
+	}
+
+	var order []string
+	byName := make(map[string]RemoteBranch)
+	for _, ref := range refs {
+		slash := strings.Index(ref, "/")
+		if slash <= 0 || slash == len(ref)-1 {
+			continue
+		}
+		name := ref[slash+1:]
+		if localNames[name] {
+			continue
+		}
+		existing, seen := byName[name]
+		if !seen {
+			order = append(order, name)
+		}
+		if !seen || (strings.HasPrefix(ref, "origin/") && !strings.HasPrefix(existing.Ref, "origin/")) {
+			byName[name] = RemoteBranch{Ref: ref, Name: name}
+		}
+	}
+
+	remote := make([]RemoteBranch, len(order))
+	for i, name := range order {
+		remote[i] = byName[name]
+	}
+	return remote
+}
+
+func describeCheckout(outcome domain.CheckoutOutcome) (string, bool) {
+	switch {
+	case outcome.Skipped:
+		return "Skipped: " + outcome.SkipReason, true
+	case !outcome.IsSuccess():
+		return fmt.Sprintf("Could not switch to %s: %s", outcome.Branch, outcome.FailureReason), true
+	case outcome.AlreadyOn:
+		return "Already on " + outcome.Branch, false
+	default:
+		return "Switched to " + outcome.Branch, false
+	}
+}
+
+func (m *Model) branchCount() int {
+	return len(m.Local) + len(m.Remote)
+}
+
+func (m *Model) selectedBranchName() (string, bool) {
+	switch {
+	case m.Cursor < 0 || m.Cursor >= m.branchCount():
+		return "", false
+	case m.Cursor < len(m.Local):
+		return m.Local[m.Cursor].Name, true
+	default:
+		return m.Remote[m.Cursor-len(m.Local)].Name, true
+	}
+}
+
+func (m *Model) checkoutSelected() tea.Cmd {
+	name, ok := m.selectedBranchName()
+	if !ok || m.Busy || m.Loading {
+		return nil
+	}
+	m.Busy = true
+	m.Status = "Switching to " + name + "..."
+	m.StatusFailed = false
+	return tea.Batch(performCheckout(m.Repo.Path, name), m.Spinner.Tick)
+}
+
+func (m *Model) View() string {
+	var s strings.Builder
+
+	title := fmt.Sprintf("\nBranches for %s\n", m.Repo.Name)
+	s.WriteString(common.HeaderStyle.Render(title))
+	s.WriteString("\n")
+
+	if m.Loading && m.Local == nil && m.Remote == nil {
+		s.WriteString(common.TextGrey.Render(m.Spinner.View() + " Loading branches..."))
+	} else {
+		s.WriteString(m.renderBranches())
+	}
+
+	if status := m.renderStatus(); status != "" {
+		s.WriteString("\n\n")
+		s.WriteString(status)
+	}
+
+	s.WriteString("\n\n")
+	s.WriteString(m.buildFooter())
+	return s.String()
+}
+
+func (m *Model) renderStatus() string {
+	switch {
+	case m.Status == "":
+		return ""
+	case m.Busy:
+		return common.TextGrey.Render("  " + m.Spinner.View() + " " + m.Status)
+	case m.StatusFailed:
+		return common.PullOutputWarn.Render("  " + m.Status)
+	default:
+		return common.PullOutputSuccess.Render("  " + m.Status)
+	}
+}
+
+func (m *Model) buildFooter() string {
+	hotkeys := []string{
+		"↑/↓ navigate",
+		"enter check out",
+		"r refresh",
+		"esc back",
+		"q quit",
+	}
+	style := common.FooterStyle
+	if m.width > 0 {
+		style = style.Width(m.width)
+	}
+	return style.Render(strings.Join(hotkeys, "  •  "))
+}
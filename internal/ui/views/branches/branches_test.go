@@ -0,0 +1,118 @@
+package branches
+
+import (
+	"strings"
+	"testing"
+
+	"fresh/internal/domain"
+	"fresh/internal/testhelpers"
+)
+
+func loadedModel(t *testing.T) *Model {
+	t.Helper()
+
+	m := New(domain.Repository{Name: "demo", Path: "/tmp/demo"})
+	m.Update(BranchesLoadedMsg{
+		RepoPath: "/tmp/demo",
+		Local: []domain.BranchStatus{
+			{Name: "main", Upstream: "origin/main", IsCurrent: true},
+			{Name: "feature", Upstream: "origin/feature", Behind: 2},
+		},
+		RemoteBranches: []string{"origin/main", "origin/feature", "origin/release", "upstream/release", "upstream/experiment"},
+		DefaultBranch:  "main",
+	})
+	return m
+}
+
+func TestRemoteOnlyBranches_HidesLocalAndPrefersOrigin(t *testing.T) {
+	t.Parallel()
+
+	m := loadedModel(t)
+
+	want := []RemoteBranch{
+		{Ref: "origin/release", Name: "release"},
+		{Ref: "upstream/experiment", Name: "experiment"},
+	}
+	if len(m.Remote) != len(want) {
+		t.Fatalf("remote = %+v, want %+v", m.Remote, want)
+	}
+	for i := range want {
+		if m.Remote[i] != want[i] {
+			t.Fatalf("remote[%d] = %+v, want %+v", i, m.Remote[i], want[i])
+		}
+	}
+}
+
+func TestView_ShowsLocalAndRemoteSections(t *testing.T) {
+	t.Parallel()
+
+	view := loadedModel(t).View()
+	for _, want := range []string{"Local branches (2)", "Remote branches (2)", "feature", "origin/release", "default"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
+
+func TestUpdate_EnterChecksOutSelectedRemoteBranch(t *testing.T) {
+	t.Parallel()
+
+	m := loadedModel(t)
+	m.Cursor = 2
+
+	cmd := testhelpers.Press(m, "enter")
+	if cmd == nil || !m.Busy {
+		t.Fatal("expected checkout to start")
+	}
+	if !strings.Contains(m.Status, "release") {
+		t.Fatalf("status = %q, want it to name the branch", m.Status)
+	}
+
+	if cmd := testhelpers.Press(m, "enter"); cmd != nil {
+		t.Fatal("expected a second checkout to wait for the first")
+	}
+}
+
+func TestDescribeCheckout(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		outcome    domain.CheckoutOutcome
+		want       string
+		wantFailed bool
+	}{
+		{name: "switched", outcome: domain.CheckoutOutcome{Branch: "main"}, want: "Switched to main"},
+		{name: "already on", outcome: domain.CheckoutOutcome{Branch: "main", AlreadyOn: true}, want: "Already on main"},
+		{name: "skipped", outcome: domain.CheckoutOutcome{Skipped: true, SkipReason: "uncommitted changes"}, want: "Skipped: uncommitted changes", wantFailed: true},
+		{
+			name:       "failed",
+			outcome:    domain.CheckoutOutcome{Branch: "x", CommandOutcome: domain.CommandOutcome{ExitCode: 1, FailureReason: "boom"}},
+			want:       "Could not switch to x: boom",
+			wantFailed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, failed := describeCheckout(tt.outcome)
+			if got != tt.want || failed != tt.wantFailed {
+				t.Fatalf("describeCheckout() = %q, %v; want %q, %v", got, failed, tt.want, tt.wantFailed)
+			}
+		})
+	}
+}
+
+func TestUpdate_EscapeReturnsBackCommand(t *testing.T) {
+	t.Parallel()
+
+	m := loadedModel(t)
+	cmd := testhelpers.Press(m, "esc")
+	if cmd == nil {
+		t.Fatal("expected back command on escape")
+	}
+	if _, ok := cmd().(BackToRepoListMsg); !ok {
+		t.Fatal("expected BackToRepoListMsg")
+	}
+}
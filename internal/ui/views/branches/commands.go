@@ -0,0 +1,35 @@
+package branches
+
+import (
+	"fresh/internal/domain"
+	"fresh/internal/git"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func performBranchLoad(repo domain.Repository) tea.Cmd {
+	return func() tea.Msg {
+		msg := BranchesLoadedMsg{RepoPath: repo.Path}
+		git.Parallel(
+			func() { msg.Local = git.ListBranchStatuses(repo.Path) },
+			func() { msg.RemoteBranches = git.ListRemoteBranches(repo.Path) },
+			func() { msg.DefaultBranch = git.DefaultBranch(repo.Path) },
+		)
+		return msg
+	}
+}
+
+func performCheckout(repoPath string, branch string) tea.Cmd {
+	return func() tea.Msg {
+		return CheckoutCompleteMsg{
+			RepoPath: repoPath,
+			Outcome:  git.CheckoutBranch(repoPath, branch, nil),
+		}
+	}
+}
+
+func backToRepoList() tea.Cmd {
+	return func() tea.Msg {
+		return BackToRepoListMsg{}
+	}
+}
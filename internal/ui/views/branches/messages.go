@@ -0,0 +1,19 @@
+package branches
+
+import "fresh/internal/domain"
+
+type BackToRepoListMsg struct{}
+
+type BranchesLoadedMsg struct {
+	RepoPath       string
+	Local          []domain.BranchStatus
+	RemoteBranches []string
+	DefaultBranch  string
+}
+
+// CheckoutCompleteMsg reports a branch switch made from the branch view. The
+// main model also uses it to refresh the repository's row.
+type CheckoutCompleteMsg struct {
+	RepoPath string
+	Outcome  domain.CheckoutOutcome
+}
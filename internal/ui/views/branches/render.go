@@ -0,0 +1,86 @@
+package branches
+
+import (
+	"fmt"
+	"strings"
+
+	"fresh/internal/domain"
+	"fresh/internal/ui/views/common"
+
+	"charm.land/lipgloss/v2"
+)
+
+var (
+	sectionStyle  = common.TableHeaderStyle
+	branchStyle   = lipgloss.NewStyle().Foreground(common.TextBranch)
+	upstreamStyle = lipgloss.NewStyle().Foreground(common.TextSecondary)
+	currentStyle  = lipgloss.NewStyle().Foreground(common.Green)
+	tagStyle      = lipgloss.NewStyle().Foreground(common.SubtleGray)
+)
+
+const branchNameWidth = 36
+
+func (m *Model) renderBranches() string {
+	var lines []string
+
+	lines = append(lines, sectionStyle.Render(fmt.Sprintf(" Local branches (%d)", len(m.Local))))
+	if len(m.Local) == 0 {
+		lines = append(lines, common.TextGrey.Render("  No local branches"))
+	}
+	for i, branch := range m.Local {
+		lines = append(lines, m.renderLocalBranch(branch, i == m.Cursor))
+	}
+
+	lines = append(lines, "")
+	lines = append(lines, sectionStyle.Render(fmt.Sprintf(" Remote branches (%d)", len(m.Remote))))
+	if len(m.Remote) == 0 {
+		lines = append(lines, common.TextGrey.Render("  Every remote branch is already checked out locally"))
+	}
+	for i, branch := range m.Remote {
+		selected := len(m.Local)+i == m.Cursor
+		lines = append(lines, buildSelector(selected)+" "+
+			common.RenderTruncatedText(branch.Name, branchNameWidth, nameStyle(selected))+
+			upstreamStyle.Render(branch.Ref))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) renderLocalBranch(branch domain.BranchStatus, selected bool) string {
+	current := " "
+	if branch.IsCurrent {
+		current = currentStyle.Render("*")
+	}
+
+	var details []string
+	if branch.Upstream != "" {
+		details = append(details, upstreamStyle.Render(branch.Upstream))
+	}
+	switch {
+	case branch.UpstreamGone:
+		details = append(details, common.PullOutputWarn.Render("upstream gone"))
+	case branch.Ahead > 0 || branch.Behind > 0:
+		details = append(details, common.TextBlue.Render(fmt.Sprintf("%s %d %s %d", common.IconAhead, branch.Ahead, common.IconBehind, branch.Behind)))
+	}
+	if branch.Name == m.DefaultBranch {
+		details = append(details, tagStyle.Render("default"))
+	}
+
+	return buildSelector(selected) + current + common.RenderTruncatedText(branch.Name, branchNameWidth, nameStyle(selected)) +
+		strings.Join(details, "  ")
+}
+
+func nameStyle(selected bool) lipgloss.Style {
+	if selected {
+		return branchStyle.Bold(true)
+	}
+	return branchStyle
+}
+
+func buildSelector(selected bool) string {
+	style := common.SelectorStyle.Width(2)
+	if selected {
+		return style.Render(common.IconSelector)
+	}
+	return style.Render(" ")
+}
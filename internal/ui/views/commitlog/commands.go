@@ -0,0 +1,42 @@
+package commitlog
+
+import (
+	"fresh/internal/domain"
+	"fresh/internal/git"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func performCommitLogLoad(repo domain.Repository) tea.Cmd {
+	return func() tea.Msg {
+		log := git.GetCommitLog(repo.Path)
+		return CommitLogLoadedMsg{
+			RepoPath: repo.Path,
+			Upstream: log.Upstream,
+			Incoming: log.Incoming,
+			Outgoing: log.Outgoing,
+		}
+	}
+}
+
+func performCommitDiffLoad(repoPath string, hash string, full bool) tea.Cmd {
+	return func() tea.Msg {
+		load := git.CommitDiffStat
+		if full {
+			load = git.CommitDiff
+		}
+
+		content, err := load(repoPath, hash)
+		msg := CommitDiffLoadedMsg{RepoPath: repoPath, Hash: hash, Full: full, Content: content}
+		if err != nil {
+			msg.Error = err.Error()
+		}
+		return msg
+	}
+}
+
+func backToRepoList() tea.Cmd {
+	return func() tea.Msg {
+		return BackToRepoListMsg{}
+	}
+}
@@ -0,0 +1,323 @@
+package commitlog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fresh/internal/domain"
+	"fresh/internal/ui/views/common"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/spinner"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+)
+
+type commitLogKeyMap struct {
+	toggleDiff key.Binding
+	diffUp     key.Binding
+	diffDown   key.Binding
+	refresh    key.Binding
+	back       key.Binding
+}
+
+func newCommitLogKeyMap() *commitLogKeyMap {
+	return &commitLogKeyMap{
+		toggleDiff: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "toggle full diff"),
+		),
+		diffUp: key.NewBinding(
+			key.WithKeys("pgup", "ctrl+u"),
+			key.WithHelp("pgup", "scroll diff up"),
+		),
+		diffDown: key.NewBinding(
+			key.WithKeys("pgdown", "ctrl+d"),
+			key.WithHelp("pgdn", "scroll diff down"),
+		),
+		refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh log"),
+		),
+		back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back to repositories"),
+		),
+	}
+}
+
+type Direction int
+
+const (
+	Incoming Direction = iota
+	Outgoing
+)
+
+type diffKey struct {
+	hash string
+	full bool
+}
+
+type diffResult struct {
+	content string
+	err     string
+}
+
+// chromeHeight covers the title, section headers, diff header, spacing and
+// footer around the commit list and the diff pane.
+const chromeHeight = 12
+
+type Model struct {
+	Repo          domain.Repository
+	Upstream      string
+	Incoming      []domain.Commit
+	Outgoing      []domain.Commit
+	Cursor        int
+	ListOffset    int
+	ShowFullDiff  bool
+	Keys          *commitLogKeyMap
+	Loading       bool
+	Spinner       spinner.Model
+	diffs         map[diffKey]diffResult
+	diff          viewport.Model
+	width, height int
+}
+
+func New(repo domain.Repository) *Model {
+	return &Model{
+		Repo:    repo,
+		Keys:    newCommitLogKeyMap(),
+		Loading: true,
+		Spinner: common.NewPullRequestSpinner(),
+		diffs:   make(map[diffKey]diffResult),
+		diff:    viewport.New(),
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.diff.SetWidth(width)
+	m.diff.SetHeight(m.diffHeight())
+	m.ensureCursorVisible()
+}
+
+func (m *Model) Init() tea.Cmd {
+	return tea.Batch(performCommitLogLoad(m.Repo), m.Spinner.Tick)
+}
+
+func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, m.Keys.back) || msg.Code == 27:
+			return m, backToRepoList()
+		case key.Matches(msg, m.Keys.refresh):
+			m.Loading = true
+			m.diffs = make(map[diffKey]diffResult)
+			return m, tea.Batch(performCommitLogLoad(m.Repo), m.Spinner.Tick)
+		case key.Matches(msg, m.Keys.toggleDiff):
+			m.ShowFullDiff = !m.ShowFullDiff
+			return m, m.selectCommit()
+		case key.Matches(msg, m.Keys.diffUp):
+			m.diff.HalfPageUp()
+		case key.Matches(msg, m.Keys.diffDown):
+			m.diff.HalfPageDown()
+		case msg.String() == "up", msg.String() == "k":
+			return m, m.moveCursor(-1)
+		case msg.String() == "down", msg.String() == "j":
+			return m, m.moveCursor(1)
+		}
+
+	case CommitLogLoadedMsg:
+		if msg.RepoPath != m.Repo.Path {
+			return m, nil
+		}
+		m.Loading = false
+		m.Upstream = msg.Upstream
+		m.Incoming = msg.Incoming
+		m.Outgoing = msg.Outgoing
+		m.Cursor = min(m.Cursor, max(0, m.commitCount()-1))
+		m.ensureCursorVisible()
+		return m, m.selectCommit()
+
+	case CommitDiffLoadedMsg:
+		if msg.RepoPath != m.Repo.Path {
+			return m, nil
+		}
+		m.diffs[diffKey{hash: msg.Hash, full: msg.Full}] = diffResult{content: msg.Content, err: msg.Error}
+		if commit, ok := m.selectedCommit(); ok && commit.Hash == msg.Hash && msg.Full == m.ShowFullDiff {
+			m.refreshDiff()
+		}
+
+	case spinner.TickMsg:
+		if m.Loading {
+			var cmd tea.Cmd
+			m.Spinner, cmd = m.Spinner.Update(msg)
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Model) commitCount() int {
+	return len(m.Incoming) + len(m.Outgoing)
+}
+
+func (m *Model) selectedCommit() (domain.Commit, bool) {
+	switch {
+	case m.Cursor < 0 || m.Cursor >= m.commitCount():
+		return domain.Commit{}, false
+	case m.Cursor < len(m.Incoming):
+		return m.Incoming[m.Cursor], true
+	default:
+		return m.Outgoing[m.Cursor-len(m.Incoming)], true
+	}
+}
+
+func (m *Model) moveCursor(delta int) tea.Cmd {
+	next := max(0, min(m.Cursor+delta, m.commitCount()-1))
+	if next == m.Cursor {
+		return nil
+	}
+	m.Cursor = next
+	m.ensureCursorVisible()
+	return m.selectCommit()
+}
+
+// selectCommit shows the cached diff for the commit under the cursor, or
+// starts loading it.
+func (m *Model) selectCommit() tea.Cmd {
+	m.refreshDiff()
+	commit, ok := m.selectedCommit()
+	if !ok {
+		return nil
+	}
+	if _, cached := m.diffs[diffKey{hash: commit.Hash, full: m.ShowFullDiff}]; cached {
+		return nil
+	}
+	return performCommitDiffLoad(m.Repo.Path, commit.Hash, m.ShowFullDiff)
+}
+
+func (m *Model) refreshDiff() {
+	m.diff.SetContent(m.diffContent())
+	m.diff.GotoTop()
+}
+
+func (m *Model) diffContent() string {
+	commit, ok := m.selectedCommit()
+	if !ok {
+		return ""
+	}
+	result, cached := m.diffs[diffKey{hash: commit.Hash, full: m.ShowFullDiff}]
+	switch {
+	case !cached:
+		return common.TextGrey.Render("Loading...")
+	case result.err != "":
+		return common.PullOutputError.Render(result.err)
+	default:
+		return colorizeDiff(result.content)
+	}
+}
+
+// listHeight is the number of commit rows shown at once; the diff pane gets
+// the rest. It returns 0 when the terminal height is unknown.
+func (m *Model) listHeight() int {
+	if m.height <= 0 {
+		return 0
+	}
+	available := max(2, m.height-chromeHeight)
+	return max(1, min(m.commitCount(), available/3))
+}
+
+func (m *Model) diffHeight() int {
+	if m.height <= 0 {
+		return 0
+	}
+	return max(1, m.height-chromeHeight-m.listHeight())
+}
+
+func (m *Model) ensureCursorVisible() {
+	rows := m.listHeight()
+	if rows <= 0 {
+		m.ListOffset = 0
+		return
+	}
+	if m.Cursor < m.ListOffset {
+		m.ListOffset = m.Cursor
+	}
+	if m.Cursor >= m.ListOffset+rows {
+		m.ListOffset = m.Cursor - rows + 1
+	}
+	m.ListOffset = max(0, min(m.ListOffset, m.commitCount()-rows))
+	m.diff.SetHeight(m.diffHeight())
+}
+
+func (m *Model) View() string {
+	var s strings.Builder
+	now := time.Now()
+
+	title := fmt.Sprintf("\nCommits for %s\n", m.Repo.Name)
+	s.WriteString(common.HeaderStyle.Render(title))
+	s.WriteString("\n")
+
+	switch {
+	case m.Loading && m.Incoming == nil && m.Outgoing == nil && m.Upstream == "":
+		s.WriteString(common.TextGrey.Render(m.Spinner.View() + " Loading commits..."))
+	case m.Upstream == "":
+		s.WriteString(common.TextGrey.Render("  No upstream branch is configured, so there is nothing to compare against"))
+	case m.commitCount() == 0:
+		s.WriteString(common.TextGrey.Render("  Up to date with " + m.Upstream))
+	default:
+		s.WriteString(m.renderCommitList(now))
+		s.WriteString("\n\n")
+		s.WriteString(m.renderDiffPane())
+	}
+
+	s.WriteString("\n\n")
+	s.WriteString(m.buildFooter())
+	return s.String()
+}
+
+func (m *Model) renderDiffPane() string {
+	commit, _ := m.selectedCommit()
+	mode := "Diff stat"
+	if m.ShowFullDiff {
+		mode = "Diff"
+	}
+	header := common.TableHeaderStyle.Render(fmt.Sprintf(" %s for %s", mode, commit.ShortHash))
+	if m.height <= 0 {
+		return header + "\n" + m.diffContent()
+	}
+	return header + "\n" + m.diff.View()
+}
+
+func (m *Model) buildFooter() string {
+	diffToggle := "d full diff"
+	if m.ShowFullDiff {
+		diffToggle = "d diff stat"
+	}
+	hotkeys := []string{
+		"↑/↓ select commit",
+		"pgup/pgdn scroll diff",
+		diffToggle,
+		"r refresh",
+		"esc back",
+		"q quit",
+	}
+	if m.height > 0 && m.diff.TotalLineCount() > m.diff.VisibleLineCount() {
+		hotkeys = append([]string{fmt.Sprintf("diff %d%%", int(m.diff.ScrollPercent()*100))}, hotkeys...)
+	}
+
+	style := common.FooterStyle
+	if m.width > 0 {
+		style = style.Width(m.width)
+	}
+	return style.Render(strings.Join(hotkeys, "  •  "))
+}
@@ -0,0 +1,138 @@
+package commitlog
+
+import (
+	"strings"
+	"testing"
+
+	"fresh/internal/domain"
+	"fresh/internal/testhelpers"
+)
+
+func loadedModel(t *testing.T) *Model {
+	t.Helper()
+
+	m := New(domain.Repository{Name: "demo", Path: "/tmp/demo"})
+	m.Update(CommitLogLoadedMsg{
+		RepoPath: "/tmp/demo",
+		Upstream: "origin/main",
+		Incoming: []domain.Commit{
+			{Hash: "aaa", ShortHash: "aaa1111", Subject: "Upstream fix", Author: "Ada"},
+			{Hash: "bbb", ShortHash: "bbb2222", Subject: "Upstream feature", Author: "Grace"},
+		},
+		Outgoing: []domain.Commit{
+			{Hash: "ccc", ShortHash: "ccc3333", Subject: "Local work", Author: "Me"},
+		},
+	})
+	return m
+}
+
+func TestView_ShowsIncomingAndOutgoingSections(t *testing.T) {
+	t.Parallel()
+
+	view := loadedModel(t).View()
+	for _, want := range []string{"Incoming from origin/main (2)", "Outgoing to origin/main (1)", "Upstream fix", "Local work"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
+
+func TestUpdate_CursorMovesAcrossSections(t *testing.T) {
+	t.Parallel()
+
+	m := loadedModel(t)
+	testhelpers.Press(m, "j")
+	testhelpers.Press(m, "j")
+
+	commit, ok := m.selectedCommit()
+	if !ok || commit.Hash != "ccc" {
+		t.Fatalf("selected = %+v, want outgoing commit ccc", commit)
+	}
+
+	testhelpers.Press(m, "j")
+	if m.Cursor != 2 {
+		t.Fatalf("cursor = %d, want clamped at 2", m.Cursor)
+	}
+}
+
+func TestUpdate_DiffIsCachedPerCommitAndMode(t *testing.T) {
+	t.Parallel()
+
+	m := loadedModel(t)
+	m.Update(CommitDiffLoadedMsg{RepoPath: "/tmp/demo", Hash: "aaa", Content: " main.go | 2 +-"})
+
+	if !strings.Contains(m.diffContent(), "main.go | 2 +-") {
+		t.Fatalf("diff content = %q, want stat", m.diffContent())
+	}
+	if cmd := m.selectCommit(); cmd != nil {
+		t.Fatal("expected cached diff stat not to reload")
+	}
+
+	if cmd := testhelpers.Press(m, "d"); cmd == nil {
+		t.Fatal("expected full diff to load when toggled")
+	}
+	if !strings.Contains(m.diffContent(), "Loading") {
+		t.Fatal("expected loading placeholder for uncached full diff")
+	}
+}
+
+func TestUpdate_IgnoresDiffForUnselectedCommit(t *testing.T) {
+	t.Parallel()
+
+	m := loadedModel(t)
+	m.Update(CommitDiffLoadedMsg{RepoPath: "/tmp/demo", Hash: "bbb", Content: "other.go | 1 +"})
+
+	if strings.Contains(m.diffContent(), "other.go") {
+		t.Fatal("expected diff for another commit not to replace the pane")
+	}
+}
+
+func TestView_NoUpstream(t *testing.T) {
+	t.Parallel()
+
+	m := New(domain.Repository{Name: "demo", Path: "/tmp/demo"})
+	m.Update(CommitLogLoadedMsg{RepoPath: "/tmp/demo"})
+
+	if !strings.Contains(m.View(), "No upstream branch") {
+		t.Fatal("expected no-upstream message")
+	}
+}
+
+func TestColorizeDiff_KeepsText(t *testing.T) {
+	t.Parallel()
+
+	diff := "diff --git a/x b/x\n@@ -1 +1 @@\n-old\n+new\n context"
+	got := colorizeDiff(diff)
+	for _, want := range []string{"-old", "+new", " context", "@@ -1 +1 @@"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("colorized diff missing %q", want)
+		}
+	}
+}
+
+func TestUpdate_EscapeReturnsBackCommand(t *testing.T) {
+	t.Parallel()
+
+	m := loadedModel(t)
+	cmd := testhelpers.Press(m, "esc")
+	if cmd == nil {
+		t.Fatal("expected back command on escape")
+	}
+	if _, ok := cmd().(BackToRepoListMsg); !ok {
+		t.Fatal("expected BackToRepoListMsg")
+	}
+}
+
+func TestListHeight_LeavesRoomForDiff(t *testing.T) {
+	t.Parallel()
+
+	m := loadedModel(t)
+	m.SetSize(100, 30)
+
+	if m.listHeight() < 1 || m.diffHeight() < 1 {
+		t.Fatalf("list/diff heights = %d/%d, want both positive", m.listHeight(), m.diffHeight())
+	}
+	if m.listHeight()+m.diffHeight()+chromeHeight > 30 {
+		t.Fatal("expected list and diff to fit within the terminal height")
+	}
+}
@@ -0,0 +1,20 @@
+package commitlog
+
+import "fresh/internal/domain"
+
+type BackToRepoListMsg struct{}
+
+type CommitLogLoadedMsg struct {
+	RepoPath string
+	Upstream string
+	Incoming []domain.Commit
+	Outgoing []domain.Commit
+}
+
+type CommitDiffLoadedMsg struct {
+	RepoPath string
+	Hash     string
+	Full     bool
+	Content  string
+	Error    string
+}
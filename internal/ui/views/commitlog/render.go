@@ -0,0 +1,107 @@
+package commitlog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fresh/internal/domain"
+	"fresh/internal/ui/views/common"
+
+	"charm.land/lipgloss/v2"
+)
+
+const (
+	hashWidth   = 9
+	authorWidth = 18
+	ageWidth    = 10
+	// minSubjectWidth applies when the terminal width is unknown or narrow.
+	minSubjectWidth = 30
+	rowOverhead     = 12
+)
+
+var (
+	sectionStyle = common.TableHeaderStyle
+	hashStyle    = lipgloss.NewStyle().Foreground(common.Yellow)
+	subjectStyle = lipgloss.NewStyle().Foreground(common.TextPrimary)
+	authorStyle  = lipgloss.NewStyle().Foreground(common.TextSecondary)
+	ageStyle     = lipgloss.NewStyle().Foreground(common.SubtleGray)
+	addedStyle   = lipgloss.NewStyle().Foreground(common.Green)
+	removedStyle = lipgloss.NewStyle().Foreground(common.Red)
+	hunkStyle    = lipgloss.NewStyle().Foreground(common.Blue)
+	fileStyle    = lipgloss.NewStyle().Foreground(common.TextPrimary).Bold(true)
+)
+
+// renderCommitList draws the incoming and outgoing sections, showing only
+// the window of rows starting at ListOffset when the height is known.
+func (m *Model) renderCommitList(now time.Time) string {
+	start, end := 0, m.commitCount()
+	if rows := m.listHeight(); rows > 0 {
+		start = m.ListOffset
+		end = min(end, start+rows)
+	}
+
+	var lines []string
+	incomingEnd := len(m.Incoming)
+
+	if start < incomingEnd || len(m.Outgoing) == 0 {
+		lines = append(lines, sectionStyle.Render(fmt.Sprintf(" %s Incoming from %s (%d)", common.IconBehind, m.Upstream, len(m.Incoming))))
+		if len(m.Incoming) == 0 {
+			lines = append(lines, common.TextGrey.Render("  Nothing to pull"))
+		}
+	}
+	for i := start; i < min(end, incomingEnd); i++ {
+		lines = append(lines, m.renderCommit(m.Incoming[i], i == m.Cursor, now))
+	}
+
+	if end > incomingEnd || len(m.Incoming) == 0 {
+		lines = append(lines, sectionStyle.Render(fmt.Sprintf(" %s Outgoing to %s (%d)", common.IconAhead, m.Upstream, len(m.Outgoing))))
+		if len(m.Outgoing) == 0 {
+			lines = append(lines, common.TextGrey.Render("  Nothing to push"))
+		}
+	}
+	for i := max(start, incomingEnd); i < end; i++ {
+		lines = append(lines, m.renderCommit(m.Outgoing[i-incomingEnd], i == m.Cursor, now))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) renderCommit(commit domain.Commit, selected bool, now time.Time) string {
+	selector := common.SelectorStyle.Width(2).Render(" ")
+	subject := subjectStyle
+	if selected {
+		selector = common.SelectorStyle.Width(2).Render(common.IconSelector)
+		subject = subject.Bold(true)
+	}
+
+	subjectWidth := minSubjectWidth
+	if m.width > 0 {
+		subjectWidth = max(minSubjectWidth, m.width-hashWidth-authorWidth-ageWidth-rowOverhead)
+	}
+
+	return strings.Join([]string{
+		selector,
+		common.RenderTruncatedText(commit.ShortHash, hashWidth, hashStyle),
+		common.RenderTruncatedText(commit.Subject, subjectWidth, subject),
+		common.RenderTruncatedText(commit.Author, authorWidth, authorStyle),
+		common.RenderTruncatedText(common.FormatRelativeTime(commit.Date, now), ageWidth, ageStyle),
+	}, " ")
+}
+
+func colorizeDiff(diff string) string {
+	lines := strings.Split(diff, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"), strings.HasPrefix(line, "diff --git"):
+			lines[i] = fileStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = hunkStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = addedStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = removedStyle.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -201,17 +201,21 @@ func FormatPullProgress(spinnerView string, lastLine string, width int) string {
 }
 
 const (
-	IconClean        = "\uF00C"
-	IconDirty        = "\uF071"
-	IconWarning      = "\uF071"
-	IconUntracked    = "?"
-	IconDiverged     = "⊘"
-	IconRemoteError  = "\U000F04E7"
-	IconBehind       = "\uF063"
-	IconAhead        = "\uF062"
-	IconPullRequests = "\uF407"
-	IconSynced       = "\U000F12D6"
-	IconSelector     = "▶"
+	IconClean          = "\uF00C"
+	IconDirty          = "\uF071"
+	IconWarning        = "\uF071"
+	IconUntracked      = "?"
+	IconDiverged       = "⊘"
+	IconRemoteError    = "\U000F04E7"
+	IconBehind         = "\uF063"
+	IconAhead          = "\uF062"
+	IconPullRequests   = "\uF407"
+	IconReviewRequest  = "\uF06E"
+	IconSynced         = "\U000F12D6"
+	IconSelector       = "▶"
+	IconGroupExpanded  = "▾"
+	IconGroupCollapsed = "▸"
+	IconMarked         = "●"
 )
 
 const (
@@ -223,6 +227,14 @@ var SelectorStyle = lipgloss.NewStyle().
 	Foreground(Blue).
 	Bold(true)
 
+var MarkedStyle = lipgloss.NewStyle().
+	Foreground(Yellow).
+	Bold(true)
+
+var GroupHeaderStyle = lipgloss.NewStyle().
+	Foreground(TextSecondary).
+	Bold(true)
+
 var HeaderStyle = lipgloss.NewStyle().
 	Foreground(Blue)
 
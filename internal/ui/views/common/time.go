@@ -0,0 +1,29 @@
+package common
+
+import (
+	"fmt"
+	"time"
+)
+
+// FormatRelativeTime renders t as a short age such as "5m ago" or "3d ago".
+func FormatRelativeTime(t time.Time, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	age := now.Sub(t)
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age/time.Minute))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age/time.Hour))
+	case age < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(age/(24*time.Hour)))
+	case age < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo ago", int(age/(30*24*time.Hour)))
+	default:
+		return fmt.Sprintf("%dy ago", int(age/(365*24*time.Hour)))
+	}
+}
@@ -0,0 +1,30 @@
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatRelativeTime(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{at: time.Time{}, want: "-"},
+		{at: now.Add(-30 * time.Second), want: "just now"},
+		{at: now.Add(-5 * time.Minute), want: "5m ago"},
+		{at: now.Add(-3 * time.Hour), want: "3h ago"},
+		{at: now.Add(-4 * 24 * time.Hour), want: "4d ago"},
+		{at: now.Add(-65 * 24 * time.Hour), want: "2mo ago"},
+		{at: now.Add(-800 * 24 * time.Hour), want: "2y ago"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatRelativeTime(tt.at, now); got != tt.want {
+			t.Errorf("FormatRelativeTime(%v) = %q, want %q", tt.at, got, tt.want)
+		}
+	}
+}
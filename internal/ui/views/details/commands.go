@@ -0,0 +1,23 @@
+package details
+
+import (
+	"fresh/internal/domain"
+	"fresh/internal/git"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func performDetailsLoad(repo domain.Repository) tea.Cmd {
+	return func() tea.Msg {
+		return DetailsLoadedMsg{
+			RepoPath: repo.Path,
+			Details:  git.GetRepositoryDetails(repo.Path),
+		}
+	}
+}
+
+func backToRepoList() tea.Cmd {
+	return func() tea.Msg {
+		return BackToRepoListMsg{}
+	}
+}
@@ -0,0 +1,142 @@
+package details
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fresh/internal/domain"
+	"fresh/internal/ui/views/common"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/spinner"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+)
+
+type detailsKeyMap struct {
+	refresh key.Binding
+	back    key.Binding
+}
+
+func newDetailsKeyMap() *detailsKeyMap {
+	return &detailsKeyMap{
+		refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh details"),
+		),
+		back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back to repositories"),
+		),
+	}
+}
+
+// chromeHeight covers the title above and the footer below the scrollable body.
+const chromeHeight = 6
+
+type Model struct {
+	Repo          domain.Repository
+	Details       domain.RepositoryDetails
+	Keys          *detailsKeyMap
+	Loading       bool
+	Spinner       spinner.Model
+	body          viewport.Model
+	width, height int
+}
+
+func New(repo domain.Repository) *Model {
+	return &Model{
+		Repo:    repo,
+		Keys:    newDetailsKeyMap(),
+		Loading: true,
+		Spinner: common.NewPullRequestSpinner(),
+		body:    viewport.New(),
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.body.SetWidth(width)
+	m.body.SetHeight(max(1, height-chromeHeight))
+	m.refreshBody()
+}
+
+func (m *Model) Init() tea.Cmd {
+	return tea.Batch(performDetailsLoad(m.Repo), m.Spinner.Tick)
+}
+
+func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, m.Keys.back) || msg.Code == 27:
+			return m, backToRepoList()
+		case key.Matches(msg, m.Keys.refresh):
+			m.Loading = true
+			return m, tea.Batch(performDetailsLoad(m.Repo), m.Spinner.Tick)
+		}
+
+		var cmd tea.Cmd
+		m.body, cmd = m.body.Update(msg)
+		return m, cmd
+
+	case DetailsLoadedMsg:
+		if msg.RepoPath != m.Repo.Path {
+			return m, nil
+		}
+		m.Loading = false
+		m.Details = msg.Details
+		m.refreshBody()
+
+	case spinner.TickMsg:
+		if m.Loading {
+			var cmd tea.Cmd
+			m.Spinner, cmd = m.Spinner.Update(msg)
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Model) refreshBody() {
+	m.body.SetContent(renderDetails(m.Repo, m.Details, time.Now()))
+}
+
+func (m *Model) View() string {
+	var s strings.Builder
+	title := fmt.Sprintf("\nRepository %s\n", m.Repo.Name)
+	s.WriteString(common.HeaderStyle.Render(title))
+	s.WriteString("\n")
+
+	if m.Loading && m.Details.Remotes == nil && m.Details.LocalBranches == nil {
+		s.WriteString(common.TextGrey.Render(m.Spinner.View() + " Loading repository details..."))
+	} else if m.height > 0 {
+		s.WriteString(m.body.View())
+	} else {
+		s.WriteString(renderDetails(m.Repo, m.Details, time.Now()))
+	}
+
+	s.WriteString("\n\n")
+	s.WriteString(m.buildFooter())
+	return s.String()
+}
+
+func (m *Model) buildFooter() string {
+	hotkeys := []string{
+		"↑/↓ scroll",
+		"r refresh",
+		"esc back",
+		"q quit",
+	}
+	if m.height > 0 && m.body.TotalLineCount() > m.body.VisibleLineCount() {
+		hotkeys = append([]string{fmt.Sprintf("%d%%", int(m.body.ScrollPercent()*100))}, hotkeys...)
+	}
+	return common.FooterStyle.Render(strings.Join(hotkeys, "  •  "))
+}
@@ -0,0 +1,69 @@
+package details
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"fresh/internal/domain"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestUpdate_DetailsLoadedMsgRendersSections(t *testing.T) {
+	t.Parallel()
+
+	repo := domain.Repository{
+		Name:     "demo",
+		Path:     "/tmp/demo",
+		Branches: domain.Branches{Current: domain.OnBranch{Name: "main"}, Merged: []string{"old-feature"}},
+	}
+	m := New(repo)
+
+	m.Update(DetailsLoadedMsg{
+		RepoPath: repo.Path,
+		Details: domain.RepositoryDetails{
+			Remotes:       []domain.Remote{{Name: "origin", URL: "git@github.com:octo/demo.git"}},
+			Upstream:      "origin/main",
+			LocalBranches: []domain.BranchStatus{{Name: "main", Upstream: "origin/main", Behind: 2, IsCurrent: true}},
+			Stashes:       []domain.StashEntry{{Ref: "stash@{0}", Branch: "main", Message: "wip", CreatedAt: time.Now()}},
+			RecentCommits: []domain.Commit{{ShortHash: "abc1234", Subject: "Initial commit", Author: "Ada"}},
+			ChangedFiles:  []domain.ChangedFile{{Path: "main.go", IndexCode: ' ', WorkCode: 'M', Kind: domain.ChangeUnstaged}},
+		},
+	})
+
+	if m.Loading {
+		t.Fatal("expected loading to stop")
+	}
+
+	view := m.View()
+	for _, want := range []string{"/tmp/demo", "origin/main", "git@github.com:octo/demo.git", "stash@{0}", "Initial commit", "main.go", "old-feature"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
+
+func TestUpdate_IgnoresDetailsForOtherRepository(t *testing.T) {
+	t.Parallel()
+
+	m := New(domain.Repository{Name: "demo", Path: "/tmp/demo"})
+	m.Update(DetailsLoadedMsg{RepoPath: "/tmp/other"})
+
+	if !m.Loading {
+		t.Fatal("expected details for another repository to be ignored")
+	}
+}
+
+func TestUpdate_EscapeReturnsBackCommand(t *testing.T) {
+	t.Parallel()
+
+	m := New(domain.Repository{Name: "demo", Path: "/tmp/demo"})
+	_, cmd := m.Update(tea.KeyPressMsg{Code: 27})
+	if cmd == nil {
+		t.Fatal("expected back command on escape")
+	}
+	if _, ok := cmd().(BackToRepoListMsg); !ok {
+		t.Fatal("expected BackToRepoListMsg")
+	}
+}
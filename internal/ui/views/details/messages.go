@@ -0,0 +1,10 @@
+package details
+
+import "fresh/internal/domain"
+
+type BackToRepoListMsg struct{}
+
+type DetailsLoadedMsg struct {
+	RepoPath string
+	Details  domain.RepositoryDetails
+}
@@ -0,0 +1,172 @@
+package details
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fresh/internal/domain"
+	"fresh/internal/ui/views/common"
+
+	"charm.land/lipgloss/v2"
+)
+
+var (
+	sectionStyle = common.TableHeaderStyle.PaddingTop(1)
+	labelStyle   = lipgloss.NewStyle().Foreground(common.TextSecondary).Width(12)
+	valueStyle   = lipgloss.NewStyle().Foreground(common.TextPrimary)
+	branchStyle  = lipgloss.NewStyle().Foreground(common.TextBranch)
+	hashStyle    = lipgloss.NewStyle().Foreground(common.Yellow)
+)
+
+func renderDetails(repo domain.Repository, details domain.RepositoryDetails, now time.Time) string {
+	var lines []string
+	add := func(line string) { lines = append(lines, "  "+line) }
+	section := func(title string, count int) {
+		lines = append(lines, sectionStyle.Render(fmt.Sprintf(" %s (%d)", title, count)))
+	}
+	empty := func(text string) { add(common.TextGrey.Render(text)) }
+
+	lines = append(lines, sectionStyle.Render(" Overview"))
+	add(labelStyle.Render("Path") + valueStyle.Render(repo.Path))
+	add(labelStyle.Render("Branch") + renderCurrentBranch(repo.Branches.Current))
+	add(labelStyle.Render("Upstream") + valueOrDash(details.Upstream))
+	add(labelStyle.Render("Last commit") + valueStyle.Render(common.FormatRelativeTime(repo.LastCommitAt, now)))
+
+	section("Remotes", len(details.Remotes))
+	if len(details.Remotes) == 0 {
+		empty("No remotes configured")
+	}
+	for _, remote := range details.Remotes {
+		add(labelStyle.Render(remote.Name) + valueStyle.Render(remote.URL))
+	}
+
+	section("Local branches", len(details.LocalBranches))
+	merged := make(map[string]bool, len(repo.Branches.Merged))
+	for _, name := range repo.Branches.Merged {
+		merged[name] = true
+	}
+	for _, branch := range details.LocalBranches {
+		add(renderBranchStatus(branch, merged[branch.Name]))
+	}
+
+	section("Stashes", len(details.Stashes))
+	if len(details.Stashes) == 0 {
+		empty("No stashes")
+	}
+	for _, stash := range details.Stashes {
+		add(fmt.Sprintf("%s  %s  %s  %s",
+			hashStyle.Render(stash.Ref),
+			branchStyle.Render(stash.Branch),
+			valueStyle.Render(stash.Message),
+			common.TextGrey.Render(common.FormatRelativeTime(stash.CreatedAt, now)),
+		))
+	}
+
+	section("Recent commits", len(details.RecentCommits))
+	if len(details.RecentCommits) == 0 {
+		empty("No commits")
+	}
+	for _, commit := range details.RecentCommits {
+		add(fmt.Sprintf("%s  %s  %s",
+			hashStyle.Render(commit.ShortHash),
+			valueStyle.Render(commit.Subject),
+			common.TextGrey.Render(commit.Author+", "+common.FormatRelativeTime(commit.Date, now)),
+		))
+	}
+
+	section("Changed files", len(details.ChangedFiles))
+	if len(details.ChangedFiles) == 0 {
+		empty("Working tree clean")
+	}
+	for _, kind := range []domain.ChangeKind{domain.ChangeConflicted, domain.ChangeStaged, domain.ChangeUnstaged, domain.ChangeUntracked} {
+		files := filterChangedFiles(details.ChangedFiles, kind)
+		if len(files) == 0 {
+			continue
+		}
+		add(labelStyle.Render(strings.ToUpper(string(kind[:1]))+string(kind[1:])) + common.TextGrey.Render(fmt.Sprintf("%d", len(files))))
+		for _, file := range files {
+			add("  " + renderChangedFile(file))
+		}
+	}
+
+	section("Merged branches", len(repo.Branches.Merged))
+	if len(repo.Branches.Merged) == 0 {
+		empty("No merged branches to prune")
+	}
+	for _, name := range repo.Branches.Merged {
+		add(branchStyle.Render(name))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func renderCurrentBranch(branch domain.Branch) string {
+	switch b := branch.(type) {
+	case domain.OnBranch:
+		return branchStyle.Render(b.Name)
+	case domain.DetachedHead:
+		return common.TextGrey.Render(common.BranchHead + " (detached)")
+	default:
+		return common.TextGrey.Render("-")
+	}
+}
+
+func renderBranchStatus(branch domain.BranchStatus, merged bool) string {
+	marker := "  "
+	if branch.IsCurrent {
+		marker = common.SelectorStyle.Render("*") + " "
+	}
+
+	line := marker + branchStyle.Render(branch.Name)
+	switch {
+	case branch.Upstream == "":
+		line += common.TextGrey.Render("  no upstream")
+	case branch.UpstreamGone:
+		line += common.TextGrey.Render("  "+branch.Upstream) + common.RemoteStatusErrorText.Render(" gone")
+	default:
+		line += common.TextGrey.Render("  " + branch.Upstream)
+		if branch.Ahead > 0 || branch.Behind > 0 {
+			line += "  " + common.TextBlue.Render(fmt.Sprintf("%s %d %s %d", common.IconAhead, branch.Ahead, common.IconBehind, branch.Behind))
+		}
+	}
+
+	if merged {
+		line += common.TextGreen.Render("  merged")
+	}
+	return line
+}
+
+func renderChangedFile(file domain.ChangedFile) string {
+	code := string([]byte{file.IndexCode, file.WorkCode})
+	path := file.Path
+	if file.OrigPath != "" {
+		path = file.OrigPath + " → " + file.Path
+	}
+
+	style := common.LocalStatusDirtyItem
+	switch file.Kind {
+	case domain.ChangeConflicted, domain.ChangeUntracked:
+		style = common.LocalStatusUntrackedItem
+	case domain.ChangeStaged:
+		style = common.TextGreen
+	}
+	return style.Render(code) + " " + valueStyle.Render(path)
+}
+
+func filterChangedFiles(files []domain.ChangedFile, kind domain.ChangeKind) []domain.ChangedFile {
+	var filtered []domain.ChangedFile
+	for _, file := range files {
+		if file.Kind == kind {
+			filtered = append(filtered, file)
+		}
+	}
+	return filtered
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return common.TextGrey.Render("-")
+	}
+	return valueStyle.Render(value)
+}
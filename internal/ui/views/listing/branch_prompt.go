@@ -0,0 +1,94 @@
+package listing
+
+import (
+	"fmt"
+	"strings"
+
+	"fresh/internal/ui/views/common"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+// branchPromptMode is what the branch prompt does with the name on enter.
+type branchPromptMode int
+
+const (
+	branchPromptCheckout branchPromptMode = iota
+	branchPromptCreate
+)
+
+func newBranchInput() textinput.Model {
+	return textinput.New()
+}
+
+func (m *Model) startBranchPrompt(mode branchPromptMode) tea.Cmd {
+	m.PromptingBranch = true
+	m.BranchPromptMode = mode
+	m.BranchInput.SetValue("")
+	switch mode {
+	case branchPromptCreate:
+		m.BranchInput.Prompt = "new branch: "
+		m.BranchInput.Placeholder = "created from each repository's updated default branch"
+	default:
+		m.BranchInput.Prompt = "checkout branch: "
+		m.BranchInput.Placeholder = "name of a local or remote branch"
+	}
+	return m.BranchInput.Focus()
+}
+
+func (m *Model) closeBranchPrompt() {
+	m.PromptingBranch = false
+	m.BranchInput.Blur()
+	m.BranchInput.SetValue("")
+}
+
+func (m *Model) updateBranchPrompt(msg tea.KeyPressMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.closeBranchPrompt()
+		return nil
+	case "tab":
+		if m.BranchPromptMode == branchPromptCreate {
+			m.PushNewBranch = !m.PushNewBranch
+		}
+		return nil
+	case "enter":
+		branch := strings.TrimSpace(m.BranchInput.Value())
+		mode := m.BranchPromptMode
+		m.closeBranchPrompt()
+		if branch == "" {
+			return nil
+		}
+		if mode == branchPromptCreate {
+			return m.startBulkBranchCreate(branch, m.PushNewBranch)
+		}
+		return m.startBulkCheckout(branch)
+	}
+
+	var cmd tea.Cmd
+	m.BranchInput, cmd = m.BranchInput.Update(msg)
+	return cmd
+}
+
+func (m *Model) buildBranchPromptBar() string {
+	if !m.PromptingBranch {
+		return ""
+	}
+	status := fmt.Sprintf("in %d repositories", len(m.targetIndexes()))
+	if m.BranchPromptMode == branchPromptCreate {
+		push := "[ ]"
+		if m.PushNewBranch {
+			push = "[x]"
+		}
+		status += "  •  tab " + push + " push with upstream"
+	}
+	line := m.BranchInput.View() + "  " + common.TextGrey.Render(status)
+	return lipgloss.NewStyle().PaddingLeft(1).MaxHeight(1).Render(line)
+}
+
+func (m *Model) buildSwitchAllConfirmBar() string {
+	line := fmt.Sprintf("Press m again to switch all %d repositories to their default branch", len(m.targetIndexes()))
+	return lipgloss.NewStyle().PaddingLeft(1).MaxHeight(1).Render(common.PullOutputWarn.Render(line))
+}
@@ -1,6 +1,7 @@
 package listing
 
 import (
+	"fresh/internal/browser"
 	"fresh/internal/config"
 	"fresh/internal/domain"
 	"fresh/internal/git"
@@ -8,9 +9,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 )
 
-var cfg = config.DefaultConfig()
-
-func performInitialRefresh(index int, existingRepo domain.Repository) tea.Cmd {
+func performInitialRefresh(cfg *config.Config, index int, existingRepo domain.Repository) tea.Cmd {
 	return func() tea.Msg {
 		repo := git.RefreshRepository(existingRepo.Path, cfg, git.RefreshRepositoryOptions{
 			Mode:     git.RefreshModeFetchRemoteOnly,
@@ -24,7 +23,7 @@ func performInitialRefresh(index int, existingRepo domain.Repository) tea.Cmd {
 	}
 }
 
-func performRefresh(index int, repoPath string) tea.Cmd {
+func performRefresh(cfg *config.Config, index int, repoPath string) tea.Cmd {
 	return func() tea.Msg {
 		repo := git.RefreshRepository(repoPath, cfg, git.RefreshRepositoryOptions{
 			Mode: git.RefreshModeFetchAndBuild,
@@ -37,9 +36,10 @@ func performRefresh(index int, repoPath string) tea.Cmd {
 	}
 }
 
-func performPull(index int, repoPath string) tea.Cmd {
+func performPull(cfg *config.Config, index int, repoPath string) tea.Cmd {
 	return func() tea.Msg {
 		return startStreamedRepoCommand(
+			cfg,
 			index,
 			repoPath,
 			func(lineCallback func(string)) domain.CommandOutcome {
@@ -66,9 +66,10 @@ func listenForPullProgress(state pullWorkState) tea.Cmd {
 	})
 }
 
-func performPrune(index int, repoPath string, branches []string) tea.Cmd {
+func performPrune(cfg *config.Config, index int, repoPath string, branches []string) tea.Cmd {
 	return func() tea.Msg {
 		return startStreamedRepoCommand(
+			cfg,
 			index,
 			repoPath,
 			func(lineCallback func(string)) domain.PruneOutcome {
@@ -95,15 +96,141 @@ func listenForPruneProgress(state pruneWorkState) tea.Cmd {
 	})
 }
 
-func performPullRequestSync(repos []domain.Repository, trigger PullRequestSyncTrigger, generation uint64) tea.Cmd {
+// performCheckout switches the repository to branch, or to its default
+// branch when branch is empty.
+func performCheckout(cfg *config.Config, index int, repoPath string, branch string) tea.Cmd {
+	return func() tea.Msg {
+		return startStreamedRepoCommand(
+			cfg,
+			index,
+			repoPath,
+			func(lineCallback func(string)) domain.CheckoutOutcome {
+				if branch == "" {
+					return git.SwitchToDefaultBranch(repoPath, lineCallback)
+				}
+				return git.CheckoutBranch(repoPath, branch, lineCallback)
+			},
+			func(index int, repo domain.Repository, outcome domain.CheckoutOutcome) checkoutCompleteMsg {
+				return checkoutCompleteMsg{
+					Index:   index,
+					outcome: outcome,
+					Repo:    repo,
+				}
+			},
+		)
+	}
+}
+
+func listenForCheckoutProgress(state checkoutWorkState) tea.Cmd {
+	return listenForStreamedProgress(state, func(index int, line string, next *checkoutWorkState) tea.Msg {
+		return checkoutLineMsg{
+			Index: index,
+			line:  line,
+			state: next,
+		}
+	})
+}
+
+func performBranchCreate(cfg *config.Config, index int, repoPath string, branch string, push bool) tea.Cmd {
+	return func() tea.Msg {
+		return startStreamedRepoCommand(
+			cfg,
+			index,
+			repoPath,
+			func(lineCallback func(string)) domain.BranchCreateOutcome {
+				return git.CreateBranchFromDefault(repoPath, branch, push, lineCallback)
+			},
+			func(index int, repo domain.Repository, outcome domain.BranchCreateOutcome) branchCreateCompleteMsg {
+				return branchCreateCompleteMsg{
+					Index:   index,
+					outcome: outcome,
+					Repo:    repo,
+				}
+			},
+		)
+	}
+}
+
+func listenForBranchCreateProgress(state branchCreateWorkState) tea.Cmd {
+	return listenForStreamedProgress(state, func(index int, line string, next *branchCreateWorkState) tea.Msg {
+		return branchCreateLineMsg{
+			Index: index,
+			line:  line,
+			state: next,
+		}
+	})
+}
+
+func performRebase(cfg *config.Config, index int, repoPath string) tea.Cmd {
+	return func() tea.Msg {
+		return startStreamedRepoCommand(
+			cfg,
+			index,
+			repoPath,
+			func(lineCallback func(string)) domain.RebaseOutcome {
+				return git.RebaseOntoDefault(repoPath, lineCallback)
+			},
+			func(index int, repo domain.Repository, outcome domain.RebaseOutcome) rebaseCompleteMsg {
+				return rebaseCompleteMsg{
+					Index:   index,
+					outcome: outcome,
+					Repo:    repo,
+				}
+			},
+		)
+	}
+}
+
+func listenForRebaseProgress(state rebaseWorkState) tea.Cmd {
+	return listenForStreamedProgress(state, func(index int, line string, next *rebaseWorkState) tea.Msg {
+		return rebaseLineMsg{
+			Index: index,
+			line:  line,
+			state: next,
+		}
+	})
+}
+
+func performForkSync(cfg *config.Config, index int, repoPath string) tea.Cmd {
+	return func() tea.Msg {
+		return startStreamedRepoCommand(
+			cfg,
+			index,
+			repoPath,
+			func(lineCallback func(string)) domain.ForkSyncOutcome {
+				return git.SyncFork(repoPath, lineCallback)
+			},
+			func(index int, repo domain.Repository, outcome domain.ForkSyncOutcome) forkSyncCompleteMsg {
+				return forkSyncCompleteMsg{
+					Index:   index,
+					outcome: outcome,
+					Repo:    repo,
+				}
+			},
+		)
+	}
+}
+
+func listenForForkSyncProgress(state forkSyncWorkState) tea.Cmd {
+	return listenForStreamedProgress(state, func(index int, line string, next *forkSyncWorkState) tea.Msg {
+		return forkSyncLineMsg{
+			Index: index,
+			line:  line,
+			state: next,
+		}
+	})
+}
+
+func performPullRequestSync(providers *git.ProviderRegistry, repos []domain.Repository, trigger PullRequestSyncTrigger, generation uint64) tea.Cmd {
 	snapshot := append([]domain.Repository(nil), repos...)
 
 	return func() tea.Msg {
-		sync := git.GetPullRequestSync(snapshot)
+		sync := providers.GetPullRequestSync(snapshot)
 		return PullRequestStatesUpdatedMsg{
 			Generation: generation,
 			States:     sync.States,
 			Tracked:    sync.Tracked,
+			RateLimit:  sync.RateLimit,
 			Trigger:    trigger,
 		}
 	}
@@ -114,3 +241,37 @@ func openPullRequestsView(repo domain.Repository) tea.Cmd {
 		return OpenPullRequestsMsg{Repo: repo}
 	}
 }
+
+func openRepositoryDetails(repo domain.Repository) tea.Cmd {
+	return func() tea.Msg {
+		return OpenRepositoryDetailsMsg{Repo: repo}
+	}
+}
+
+func openRepositoryInBrowser(cfg *config.Config, providers *git.ProviderRegistry, repo domain.Repository) tea.Cmd {
+	return func() tea.Msg {
+		url, err := providers.RepositoryWebURL(repo)
+		if err == nil {
+			err = browser.Open(cfg.Browser, url)
+		}
+		return browserOpenedMsg{RepoPath: repo.Path, Err: err}
+	}
+}
+
+func openStashesView(repo domain.Repository) tea.Cmd {
+	return func() tea.Msg {
+		return OpenStashesMsg{Repo: repo}
+	}
+}
+
+func openCommitLogView(repo domain.Repository) tea.Cmd {
+	return func() tea.Msg {
+		return OpenCommitLogMsg{Repo: repo}
+	}
+}
+
+func openBranchesView(repo domain.Repository) tea.Cmd {
+	return func() tea.Msg {
+		return OpenBranchesMsg{Repo: repo}
+	}
+}
@@ -0,0 +1,96 @@
+package listing
+
+import (
+	"fmt"
+	"strings"
+
+	"fresh/internal/repofilter"
+	"fresh/internal/ui/views/common"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+func newFilterInput() textinput.Model {
+	input := textinput.New()
+	input.Prompt = "/ "
+	input.Placeholder = "name, path or branch  •  is:dirty is:behind has:pr-blocked"
+	return input
+}
+
+// IsCapturingInput reports whether key presses are being typed into the
+// filter or branch prompt rather than interpreted as hotkeys.
+func (m *Model) IsCapturingInput() bool {
+	return m.Filtering || m.PromptingBranch
+}
+
+func (m *Model) hasActiveFilter() bool {
+	return !m.FilterQuery.IsEmpty()
+}
+
+func (m *Model) startFiltering() tea.Cmd {
+	m.Filtering = true
+	m.FilterInput.SetValue(m.FilterQuery.Raw)
+	m.FilterInput.CursorEnd()
+	return m.FilterInput.Focus()
+}
+
+func (m *Model) clearFilter() {
+	m.Filtering = false
+	m.FilterInput.Blur()
+	m.FilterInput.SetValue("")
+	m.FilterQuery = repofilter.Query{}
+	m.rebuildRows()
+}
+
+func (m *Model) updateFilterInput(msg tea.KeyPressMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		m.clearFilter()
+		return nil
+	case "enter":
+		m.Filtering = false
+		m.FilterInput.Blur()
+		return nil
+	case "up":
+		m.moveCursor(-1)
+		return nil
+	case "down":
+		m.moveCursor(1)
+		return nil
+	}
+
+	var cmd tea.Cmd
+	m.FilterInput, cmd = m.FilterInput.Update(msg)
+	if m.FilterInput.Value() != m.FilterQuery.Raw {
+		m.FilterQuery = repofilter.Parse(m.FilterInput.Value())
+		m.rebuildRows()
+	}
+	return cmd
+}
+
+func (m *Model) showFilterBar() bool {
+	return m.Filtering || m.FilterQuery.Raw != ""
+}
+
+func (m *Model) buildFilterBar() string {
+	if !m.showFilterBar() {
+		return ""
+	}
+
+	var bar string
+	if m.Filtering {
+		bar = m.FilterInput.View()
+	} else {
+		bar = common.TextBlue.Render("/ ") + lipgloss.NewStyle().Foreground(common.TextPrimary).Render(m.FilterQuery.Raw)
+	}
+
+	status := fmt.Sprintf("%d of %d match", len(m.matched), len(m.Repositories))
+	if len(m.FilterQuery.Unknown) > 0 {
+		status += "  •  unknown " + strings.Join(m.FilterQuery.Unknown, ", ")
+	}
+
+	line := bar + "  " + common.TextGrey.Render(status)
+	return lipgloss.NewStyle().PaddingLeft(1).MaxHeight(1).Render(line)
+}
@@ -0,0 +1,81 @@
+package listing
+
+import (
+	"strings"
+	"testing"
+
+	"fresh/internal/domain"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func typeFilter(m *Model, text string) {
+	m.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	for _, r := range text {
+		m.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+}
+
+func TestFilterNarrowsVisibleRowsLive(t *testing.T) {
+	m := New([]domain.Repository{
+		makeTestRepository("api"),
+		makeTestRepository("web"),
+		makeTestRepository("worker"),
+	})
+
+	typeFilter(m, "we")
+
+	if !m.Filtering {
+		t.Fatal("expected filter input to be active")
+	}
+	if len(m.rows) != 2 {
+		t.Fatalf("visible rows = %d, want 2", len(m.rows))
+	}
+	repo, ok := m.selectedRepository()
+	if !ok || repo.Name != "web" {
+		t.Fatalf("selected = %q, want web", repo.Name)
+	}
+	if !strings.Contains(m.View(), "2 of 3 match") {
+		t.Fatal("expected match count in filter bar")
+	}
+}
+
+func TestFilterEnterKeepsFilterAndEscClears(t *testing.T) {
+	m := New([]domain.Repository{
+		makeTestRepository("api"),
+		makeTestRepository("web"),
+	})
+
+	typeFilter(m, "web")
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if m.Filtering {
+		t.Fatal("expected enter to leave filter input")
+	}
+	if len(m.rows) != 1 {
+		t.Fatalf("visible rows = %d, want 1", len(m.rows))
+	}
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.hasActiveFilter() || len(m.rows) != 2 {
+		t.Fatalf("expected esc to clear filter, visible = %d", len(m.rows))
+	}
+}
+
+func TestPullAllOnlyTargetsFilteredRepositories(t *testing.T) {
+	m := New([]domain.Repository{
+		newTestRepository("api").LocalState(domain.DirtyLocalState{Modified: 1}).RemoteState(domain.Behind{Count: 1}).Build(),
+		newTestRepository("web").RemoteState(domain.Behind{Count: 1}).Build(),
+	})
+
+	typeFilter(m, "is:dirty")
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m.Update(tea.KeyPressMsg{Code: 'p', Text: "p"})
+
+	if _, ok := m.Repositories[0].Activity.(*domain.PullingActivity); !ok {
+		t.Fatalf("api activity = %T, want *domain.PullingActivity", m.Repositories[0].Activity)
+	}
+	if _, ok := m.Repositories[1].Activity.(*domain.PullingActivity); ok {
+		t.Fatal("did not expect filtered-out repository to be pulled")
+	}
+}
@@ -73,6 +73,12 @@ func collectStatusInfoMessages(repo domain.Repository) []InfoMessage {
 		appendMessage(InfoMessage{Text: summary, Tone: InfoTonePullRequestSummary, Pinned: pinned})
 	}
 
+	// Review requests wait on the user, so they stay pinned like blocked
+	// pull requests.
+	if summary := buildReviewRequestSummary(repo.PullRequests); summary != "" {
+		appendMessage(InfoMessage{Text: summary, Tone: InfoTonePullRequestSummary, Pinned: true})
+	}
+
 	mergedCount := len(repo.Branches.Merged)
 	if mergedCount > 0 {
 		mergedText := "branches"
@@ -99,6 +105,9 @@ func collectStatusInfoMessages(repo domain.Repository) []InfoMessage {
 	if _, ok := repo.RemoteState.(domain.Diverged); ok {
 		appendMessage(InfoMessage{Text: common.StatusDiverged, Tone: InfoToneWarn})
 	}
+	if fork, ok := repo.Fork.(domain.ForkStatus); ok && fork.Behind > 0 {
+		appendMessage(InfoMessage{Text: fmt.Sprintf("fork %d behind upstream/%s", fork.Behind, fork.Branch), Tone: InfoToneWarn})
+	}
 
 	return messages
 }
@@ -111,6 +120,14 @@ func collectActiveActivityInfoMessage(repo domain.Repository, infoWidth int) Inf
 		return formatActiveProgressInfoMessage(activity.Complete, activity.Spinner.View(), activity.GetLastLine(), infoWidth)
 	case *domain.PruningActivity:
 		return formatActiveProgressInfoMessage(activity.Complete, activity.Spinner.View(), activity.GetLastLine(), infoWidth)
+	case *domain.SwitchingActivity:
+		return formatActiveProgressInfoMessage(activity.Complete, activity.Spinner.View(), activity.GetLastLine(), infoWidth)
+	case *domain.CreatingBranchActivity:
+		return formatActiveProgressInfoMessage(activity.Complete, activity.Spinner.View(), activity.GetLastLine(), infoWidth)
+	case *domain.RebasingActivity:
+		return formatActiveProgressInfoMessage(activity.Complete, activity.Spinner.View(), activity.GetLastLine(), infoWidth)
+	case *domain.SyncingForkActivity:
+		return formatActiveProgressInfoMessage(activity.Complete, activity.Spinner.View(), activity.GetLastLine(), infoWidth)
 	default:
 		return InfoMessageResult{}
 	}
@@ -216,6 +233,152 @@ func buildPruneCompletionInfoMessage(activity domain.PruningActivity) InfoMessag
 	}
 }
 
+func buildCheckoutCompletionInfoMessage(activity domain.SwitchingActivity) InfoMessageResult {
+	if !activity.Complete {
+		return InfoMessageResult{}
+	}
+
+	switch {
+	case activity.Skipped:
+		return InfoMessageResult{
+			Message: InfoMessage{Text: "Skipped: " + activity.SkipReason, Tone: InfoToneWarn},
+			OK:      true,
+		}
+	case !activity.Outcome.IsSuccess():
+		reason := textutil.FirstNonEmptyTrimmed(activity.Outcome.FailureReason, activity.GetLastLine(), "checkout failed")
+		return InfoMessageResult{
+			Message: InfoMessage{Text: fmt.Sprintf("Checkout failed: %s", sanitizePullFailureReason(reason)), Tone: InfoToneError},
+			OK:      true,
+		}
+	case activity.AlreadyOn:
+		return InfoMessageResult{
+			Message: InfoMessage{Text: "Already on " + activity.Branch, Tone: InfoTonePrimary},
+			OK:      true,
+		}
+	default:
+		return InfoMessageResult{
+			Message: InfoMessage{Text: "Switched to " + activity.Branch, Tone: InfoToneSuccess},
+			OK:      true,
+		}
+	}
+}
+
+func buildBranchCreateCompletionInfoMessage(activity domain.CreatingBranchActivity) InfoMessageResult {
+	if !activity.Complete {
+		return InfoMessageResult{}
+	}
+
+	switch {
+	case activity.Skipped:
+		return InfoMessageResult{
+			Message: InfoMessage{Text: "Skipped: " + activity.SkipReason, Tone: InfoToneWarn},
+			OK:      true,
+		}
+	case !activity.Outcome.IsSuccess():
+		reason := sanitizePullFailureReason(textutil.FirstNonEmptyTrimmed(activity.Outcome.FailureReason, activity.GetLastLine(), "branch creation failed"))
+		if activity.StartPoint != "" {
+			return InfoMessageResult{
+				Message: InfoMessage{Text: fmt.Sprintf("Created %s, push failed: %s", activity.Branch, reason), Tone: InfoToneWarn},
+				OK:      true,
+			}
+		}
+		return InfoMessageResult{
+			Message: InfoMessage{Text: fmt.Sprintf("Create branch failed: %s", reason), Tone: InfoToneError},
+			OK:      true,
+		}
+	case activity.Pushed:
+		return InfoMessageResult{
+			Message: InfoMessage{Text: fmt.Sprintf("Created %s from %s and pushed", activity.Branch, activity.StartPoint), Tone: InfoToneSuccess},
+			OK:      true,
+		}
+	default:
+		return InfoMessageResult{
+			Message: InfoMessage{Text: fmt.Sprintf("Created %s from %s", activity.Branch, activity.StartPoint), Tone: InfoToneSuccess},
+			OK:      true,
+		}
+	}
+}
+
+// buildRebaseCompletionInfoMessage pins conflict reports so the repository
+// keeps showing which files clashed after the rebase was aborted.
+func buildRebaseCompletionInfoMessage(activity domain.RebasingActivity) InfoMessageResult {
+	if !activity.Complete {
+		return InfoMessageResult{}
+	}
+
+	switch {
+	case activity.Skipped:
+		return InfoMessageResult{
+			Message: InfoMessage{Text: "Skipped: " + activity.SkipReason, Tone: InfoToneWarn},
+			OK:      true,
+		}
+	case len(activity.ConflictedFiles) > 0:
+		return InfoMessageResult{
+			Message: InfoMessage{
+				Text:   fmt.Sprintf("Rebase conflicts: %s (aborted)", strings.Join(activity.ConflictedFiles, ", ")),
+				Tone:   InfoToneError,
+				Pinned: true,
+			},
+			OK: true,
+		}
+	case !activity.Outcome.IsSuccess():
+		reason := sanitizePullFailureReason(textutil.FirstNonEmptyTrimmed(activity.Outcome.FailureReason, activity.GetLastLine(), "rebase failed"))
+		return InfoMessageResult{
+			Message: InfoMessage{Text: "Rebase failed: " + reason, Tone: InfoToneError},
+			OK:      true,
+		}
+	case activity.UpToDate:
+		return InfoMessageResult{
+			Message: InfoMessage{Text: "Already based on " + activity.Onto, Tone: InfoTonePrimary},
+			OK:      true,
+		}
+	default:
+		return InfoMessageResult{
+			Message: InfoMessage{Text: fmt.Sprintf("Rebased %s onto %s", activity.Branch, activity.Onto), Tone: InfoToneSuccess},
+			OK:      true,
+		}
+	}
+}
+
+func buildForkSyncCompletionInfoMessage(activity domain.SyncingForkActivity) InfoMessageResult {
+	if !activity.Complete {
+		return InfoMessageResult{}
+	}
+
+	switch {
+	case activity.Skipped:
+		return InfoMessageResult{
+			Message: InfoMessage{Text: "Skipped: " + activity.SkipReason, Tone: InfoToneWarn},
+			OK:      true,
+		}
+	case !activity.Outcome.IsSuccess():
+		reason := sanitizePullFailureReason(textutil.FirstNonEmptyTrimmed(activity.Outcome.FailureReason, activity.GetLastLine(), "fork sync failed"))
+		return InfoMessageResult{
+			Message: InfoMessage{Text: "Fork sync failed: " + reason, Tone: InfoToneError},
+			OK:      true,
+		}
+	case activity.UpToDate:
+		return InfoMessageResult{
+			Message: InfoMessage{Text: fmt.Sprintf("Fork %s already matches upstream", activity.Branch), Tone: InfoTonePrimary},
+			OK:      true,
+		}
+	case activity.Behind > 0:
+		commitText := "commits"
+		if activity.Behind == 1 {
+			commitText = "commit"
+		}
+		return InfoMessageResult{
+			Message: InfoMessage{Text: fmt.Sprintf("Synced fork %s with upstream (%d %s)", activity.Branch, activity.Behind, commitText), Tone: InfoToneSuccess},
+			OK:      true,
+		}
+	default:
+		return InfoMessageResult{
+			Message: InfoMessage{Text: fmt.Sprintf("Synced fork %s with upstream", activity.Branch), Tone: InfoToneSuccess},
+			OK:      true,
+		}
+	}
+}
+
 func renderInfoMessage(msg InfoMessage, infoWidth int) string {
 	infoWidth = normalizeInfoWidth(infoWidth)
 
@@ -21,7 +21,7 @@ const (
 
 // Fixed column widths
 const (
-	SelectorWidth  = 2
+	SelectorWidth  = 3 // cursor, bulk-action mark and a gap before the name
 	LocalWidth     = 15
 	RemoteWidth    = 11
 	PRAlertWidth   = 2
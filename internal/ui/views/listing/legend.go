@@ -33,6 +33,7 @@ func RenderLegend(show bool) string {
 		{common.IconBehind, "Behind", common.TextBlue},
 		{common.IconSynced, "Synced", common.TextSubtleGreen},
 		{common.IconRemoteError, "Error Fetching", common.RemoteStatusErrorText},
+		{common.IconReviewRequest, "Review Requested", common.LocalStatusDirtyItem},
 	}
 
 	process := func(items []item) []string {
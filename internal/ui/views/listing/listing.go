@@ -1,9 +1,13 @@
 package listing
 
 import (
+	"fmt"
+	"fresh/internal/config"
 	"fresh/internal/domain"
+	"fresh/internal/git"
 	"fresh/internal/notifications"
 	"fresh/internal/pullrequests"
+	"fresh/internal/repofilter"
 	"fresh/internal/ui/views/common"
 	"sort"
 	"strings"
@@ -11,6 +15,7 @@ import (
 
 	"charm.land/bubbles/v2/key"
 	"charm.land/bubbles/v2/spinner"
+	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
 )
 
@@ -20,7 +25,28 @@ type listKeyMap struct {
 	pullAll      key.Binding
 	pruneAll     key.Binding
 	openPRs      key.Binding
+	openDetails  key.Binding
+	openStashes  key.Binding
+	openLog      key.Binding
+	openBranches key.Binding
+	openWeb      key.Binding
+	toggleMark   key.Binding
+	checkoutAll  key.Binding
+	switchAll    key.Binding
+	createBranch key.Binding
+	rebaseAll    key.Binding
+	syncForks    key.Binding
 	toggleLegend key.Binding
+	filter       key.Binding
+	clearFilter  key.Binding
+	cycleSort    key.Binding
+	reverseSort  key.Binding
+	cycleGroup   key.Binding
+	toggleGroup  key.Binding
+	pageUp       key.Binding
+	pageDown     key.Binding
+	home         key.Binding
+	end          key.Binding
 }
 
 func newListKeyMap() *listKeyMap {
@@ -45,16 +71,119 @@ func newListKeyMap() *listKeyMap {
 			key.WithKeys("enter"),
 			key.WithHelp("enter", "view pull requests"),
 		),
+		openDetails: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "repository details"),
+		),
+		openStashes: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "manage stashes"),
+		),
+		openLog: key.NewBinding(
+			key.WithKeys("l"),
+			key.WithHelp("l", "incoming and outgoing commits"),
+		),
+		openBranches: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "switch branch"),
+		),
+		openWeb: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open in browser"),
+		),
+		toggleMark: key.NewBinding(
+			key.WithKeys("space"),
+			key.WithHelp("space", "mark for bulk actions"),
+		),
+		checkoutAll: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "check out a branch everywhere it exists"),
+		),
+		switchAll: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "switch to default branch"),
+		),
+		createBranch: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "create a branch from the default branch"),
+		),
+		rebaseAll: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "rebase onto the default branch"),
+		),
+		syncForks: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "sync forks with upstream"),
+		),
 		toggleLegend: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "toggle legend"),
 		),
+		filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		clearFilter: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "clear filter"),
+		),
+		cycleSort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "cycle sort key"),
+		),
+		reverseSort: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "reverse sort"),
+		),
+		cycleGroup: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "cycle grouping"),
+		),
+		toggleGroup: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "collapse or expand group"),
+		),
+		pageUp: key.NewBinding(
+			key.WithKeys("pgup", "ctrl+u"),
+			key.WithHelp("pgup", "page up"),
+		),
+		pageDown: key.NewBinding(
+			key.WithKeys("pgdown", "ctrl+d"),
+			key.WithHelp("pgdn", "page down"),
+		),
+		home: key.NewBinding(
+			key.WithKeys("home", "g"),
+			key.WithHelp("home", "jump to first"),
+		),
+		end: key.NewBinding(
+			key.WithKeys("end", "G"),
+			key.WithHelp("end", "jump to last"),
+		),
 	}
 }
 
 type Model struct {
 	Repositories     []domain.Repository
 	Cursor           int
+	ScrollOffset     int
+	Filtering        bool
+	FilterInput      textinput.Model
+	FilterQuery      repofilter.Query
+	Marked           map[string]bool
+	PromptingBranch  bool
+	BranchPromptMode branchPromptMode
+	PushNewBranch    bool
+	// PendingSwitchAll is set by a first m with nothing marked, which would
+	// switch every visible repository.
+	PendingSwitchAll bool
+	RebaseConflicts  map[string][]string
+	BranchInput      textinput.Model
+	SortKey          SortKey
+	SortReversed     bool
+	GroupMode        GroupMode
+	CollapsedGroups  map[string]bool
+	matched          []int
+	rows             []displayRow
 	Keys             *listKeyMap
 	layout           ColumnLayout
 	width, height    int
@@ -74,8 +203,14 @@ type Model struct {
 	WatchBackoff     int
 	WatchEvery       time.Duration
 	WatchMaxEvery    time.Duration
-	prCoordinator    *pullrequests.NotificationCoordinator
-	notifier         *notifications.Notifier
+	// PRRateLimit is the API budget the last pull request sync reported.
+	PRRateLimit git.RateLimit
+	// Providers syncs the pull request columns.
+	Providers *git.ProviderRegistry
+	// Config is the loaded configuration refreshes and bulk actions use.
+	Config        *config.Config
+	prCoordinator *pullrequests.NotificationCoordinator
+	notifier      *notifications.Notifier
 }
 
 func New(repos []domain.Repository) *Model {
@@ -91,9 +226,17 @@ func NewWithNotifier(repos []domain.Repository, notifier *notifications.Notifier
 		repos[i].Activity = &domain.IdleActivity{}
 	}
 
-	return &Model{
+	cfg := config.DefaultConfig()
+	m := &Model{
 		Repositories:     repos,
 		Cursor:           0,
+		FilterInput:      newFilterInput(),
+		Marked:           make(map[string]bool),
+		BranchInput:      newBranchInput(),
+		RebaseConflicts:  make(map[string][]string),
+		SortKey:          SortByName,
+		GroupMode:        GroupNone,
+		CollapsedGroups:  make(map[string]bool),
 		Keys:             newListKeyMap(),
 		layout:           calculateColumnLayout(repos, 0),
 		ShowLegend:       false,
@@ -111,15 +254,20 @@ func NewWithNotifier(repos []domain.Repository, notifier *notifications.Notifier
 		WatchBackoff:     0,
 		WatchEvery:       defaultWatchInterval,
 		WatchMaxEvery:    defaultWatchMaxInterval,
+		Providers:        git.DefaultProviders(cfg),
+		Config:           cfg,
 		prCoordinator:    pullrequests.NewNotificationCoordinator(nil),
 		notifier:         notifier,
 	}
+	m.rebuildRows()
+	return m
 }
 
 func (m *Model) SetSize(width, height int) {
 	m.width = width
 	m.height = height
 	m.layout = calculateColumnLayout(m.Repositories, width)
+	m.ensureCursorVisible()
 }
 
 func (m *Model) Init() tea.Cmd {
@@ -136,7 +284,7 @@ func (m *Model) Init() tea.Cmd {
 		repo.Activity = &domain.RefreshingActivity{
 			Spinner: common.NewRefreshSpinner(),
 		}
-		cmds = append(cmds, performInitialRefresh(i, *repo))
+		cmds = append(cmds, performInitialRefresh(m.Config, i, *repo))
 		cmds = append(cmds, repo.Activity.(*domain.RefreshingActivity).Spinner.Tick)
 	}
 	return tea.Batch(cmds...)
@@ -149,7 +297,73 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyPressMsg:
+		if m.PromptingBranch {
+			return m, m.updateBranchPrompt(msg)
+		}
+		if m.Filtering {
+			cmd := m.updateFilterInput(msg)
+			m.ensureCursorVisible()
+			return m, cmd
+		}
+
+		// Switching every visible repository needs m twice in a row;
+		// anything else cancels.
+		pendingSwitchAll := m.PendingSwitchAll
+		m.PendingSwitchAll = false
+
 		switch {
+		case key.Matches(msg, m.Keys.filter):
+			return m, m.startFiltering()
+
+		case key.Matches(msg, m.Keys.clearFilter) && m.hasActiveFilter():
+			m.clearFilter()
+			return m, nil
+
+		case key.Matches(msg, m.Keys.clearFilter) && m.markedCount() > 0:
+			m.clearMarks()
+			return m, nil
+
+		case key.Matches(msg, m.Keys.toggleMark):
+			m.toggleMark()
+			return m, nil
+
+		case key.Matches(msg, m.Keys.checkoutAll):
+			return m, m.startBranchPrompt(branchPromptCheckout)
+
+		case key.Matches(msg, m.Keys.createBranch):
+			return m, m.startBranchPrompt(branchPromptCreate)
+
+		case key.Matches(msg, m.Keys.rebaseAll):
+			return m, m.startBulkRebase()
+
+		case key.Matches(msg, m.Keys.syncForks):
+			return m, m.startBulkForkSync()
+
+		case key.Matches(msg, m.Keys.switchAll):
+			if m.markedCount() == 0 && !pendingSwitchAll {
+				m.PendingSwitchAll = true
+				return m, nil
+			}
+			return m, m.startBulkCheckout("")
+
+		case key.Matches(msg, m.Keys.cycleSort):
+			m.cycleSortKey()
+			return m, nil
+
+		case key.Matches(msg, m.Keys.reverseSort):
+			m.toggleSortDirection()
+			return m, nil
+
+		case key.Matches(msg, m.Keys.cycleGroup):
+			m.cycleGroupMode()
+			return m, nil
+
+		case key.Matches(msg, m.Keys.toggleGroup):
+			if row, ok := m.selectedRow(); ok && m.GroupMode != GroupNone {
+				m.toggleGroupCollapsed(row.group)
+			}
+			return m, nil
+
 		case key.Matches(msg, m.Keys.refresh):
 			return m, m.startRefreshCycle(pullRequestSyncManual)
 
@@ -158,13 +372,13 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 
 		case key.Matches(msg, m.Keys.pullAll):
 			var cmds []tea.Cmd
-			for i := range m.Repositories {
+			for _, i := range m.targetIndexes() {
 				repo := &m.Repositories[i]
 				if !repo.IsBusy() && repo.CanPull() {
 					repo.Activity = &domain.PullingActivity{
 						Spinner: common.NewPullSpinner(),
 					}
-					cmds = append(cmds, performPull(i, repo.Path))
+					cmds = append(cmds, performPull(m.Config, i, repo.Path))
 					cmds = append(cmds, repo.Activity.(*domain.PullingActivity).Spinner.Tick)
 				}
 			}
@@ -172,38 +386,88 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 
 		case key.Matches(msg, m.Keys.pruneAll):
 			var cmds []tea.Cmd
-			for i := range m.Repositories {
+			for _, i := range m.targetIndexes() {
 				repo := &m.Repositories[i]
 				if !repo.IsBusy() && len(repo.Branches.Merged) > 0 {
 					repo.Activity = &domain.PruningActivity{
 						Spinner: common.NewPullSpinner(),
 					}
-					cmds = append(cmds, performPrune(i, repo.Path, repo.Branches.Merged))
+					cmds = append(cmds, performPrune(m.Config, i, repo.Path, repo.Branches.Merged))
 					cmds = append(cmds, repo.Activity.(*domain.PruningActivity).Spinner.Tick)
 				}
 			}
 			return m, tea.Batch(cmds...)
 
 		case key.Matches(msg, m.Keys.openPRs) || msg.String() == "enter" || msg.Code == '\r':
-			if len(m.Repositories) == 0 || m.Cursor < 0 || m.Cursor >= len(m.Repositories) {
+			if row, ok := m.selectedRow(); ok && row.isGroupHeader() {
+				m.toggleGroupCollapsed(row.group)
+				return m, nil
+			}
+			repo, ok := m.selectedRepository()
+			if !ok {
 				return m, nil
 			}
-			return m, openPullRequestsView(m.Repositories[m.Cursor])
+			return m, openPullRequestsView(repo)
+
+		case key.Matches(msg, m.Keys.openDetails):
+			repo, ok := m.selectedRepository()
+			if !ok {
+				return m, nil
+			}
+			return m, openRepositoryDetails(repo)
+
+		case key.Matches(msg, m.Keys.openStashes):
+			repo, ok := m.selectedRepository()
+			if !ok {
+				return m, nil
+			}
+			return m, openStashesView(repo)
+
+		case key.Matches(msg, m.Keys.openLog):
+			repo, ok := m.selectedRepository()
+			if !ok {
+				return m, nil
+			}
+			return m, openCommitLogView(repo)
+
+		case key.Matches(msg, m.Keys.openBranches):
+			repo, ok := m.selectedRepository()
+			if !ok {
+				return m, nil
+			}
+			return m, openBranchesView(repo)
+
+		case key.Matches(msg, m.Keys.openWeb):
+			repo, ok := m.selectedRepository()
+			if !ok {
+				return m, nil
+			}
+			return m, openRepositoryInBrowser(m.Config, m.Providers, repo)
 
 		case key.Matches(msg, m.Keys.toggleLegend):
 			m.ShowLegend = !m.ShowLegend
+			m.ensureCursorVisible()
 			return m, nil
 
 		case msg.String() == "up", msg.String() == "k":
-			if m.Cursor > 0 {
-				m.Cursor--
-			}
+			m.moveCursor(-1)
 
 		case msg.String() == "down", msg.String() == "j":
-			if m.Cursor < len(m.Repositories)-1 {
-				m.Cursor++
-			}
+			m.moveCursor(1)
+
+		case key.Matches(msg, m.Keys.pageUp):
+			m.moveCursor(-m.pageSize())
+
+		case key.Matches(msg, m.Keys.pageDown):
+			m.moveCursor(m.pageSize())
+
+		case key.Matches(msg, m.Keys.home):
+			m.moveCursor(-len(m.rows))
+
+		case key.Matches(msg, m.Keys.end):
+			m.moveCursor(len(m.rows))
 		}
+		m.ensureCursorVisible()
 
 	case RepoUpdatedMsg:
 		m.applyRepoUpdate(msg.Index, msg.Repo, func(repo *domain.Repository, activity domain.Activity) {
@@ -223,6 +487,7 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 		}
 
 		m.applyPullRequestStates(msg.States)
+		m.PRRateLimit = msg.RateLimit
 		syncFailed := hasPullRequestSyncError(msg.States)
 		if !syncFailed {
 			m.applyPullRequestWatchlist(msg.Tracked, msg.Trigger == pullRequestSyncStartup)
@@ -293,6 +558,123 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 			}
 		})
 
+	case checkoutWorkState:
+		return m, listenForCheckoutProgress(msg)
+
+	case checkoutLineMsg:
+		if msg.Index < len(m.Repositories) {
+			repo := &m.Repositories[msg.Index]
+			if switching, ok := repo.Activity.(*domain.SwitchingActivity); ok {
+				switching.AddLine(msg.line)
+			}
+		}
+		if msg.state != nil {
+			return m, listenForCheckoutProgress(*msg.state)
+		}
+
+	case checkoutCompleteMsg:
+		m.finalizeRepoActivity(msg.Index, msg.Repo, func(activity domain.Activity) ActivityFinalizeResult {
+			switching, ok := activity.(*domain.SwitchingActivity)
+			if !ok {
+				return ActivityFinalizeResult{}
+			}
+			switching.MarkComplete(msg.outcome)
+			return ActivityFinalizeResult{
+				Completed: true,
+				Info:      buildCheckoutCompletionInfoMessage(*switching),
+			}
+		})
+
+	case branchCreateWorkState:
+		return m, listenForBranchCreateProgress(msg)
+
+	case branchCreateLineMsg:
+		if msg.Index < len(m.Repositories) {
+			repo := &m.Repositories[msg.Index]
+			if creating, ok := repo.Activity.(*domain.CreatingBranchActivity); ok {
+				creating.AddLine(msg.line)
+			}
+		}
+		if msg.state != nil {
+			return m, listenForBranchCreateProgress(*msg.state)
+		}
+
+	case branchCreateCompleteMsg:
+		m.finalizeRepoActivity(msg.Index, msg.Repo, func(activity domain.Activity) ActivityFinalizeResult {
+			creating, ok := activity.(*domain.CreatingBranchActivity)
+			if !ok {
+				return ActivityFinalizeResult{}
+			}
+			creating.MarkComplete(msg.outcome)
+			return ActivityFinalizeResult{
+				Completed: true,
+				Info:      buildBranchCreateCompletionInfoMessage(*creating),
+			}
+		})
+
+	case rebaseWorkState:
+		return m, listenForRebaseProgress(msg)
+
+	case rebaseLineMsg:
+		if msg.Index < len(m.Repositories) {
+			repo := &m.Repositories[msg.Index]
+			if rebasing, ok := repo.Activity.(*domain.RebasingActivity); ok {
+				rebasing.AddLine(msg.line)
+			}
+		}
+		if msg.state != nil {
+			return m, listenForRebaseProgress(*msg.state)
+		}
+
+	case rebaseCompleteMsg:
+		if len(msg.outcome.ConflictedFiles) > 0 {
+			m.RebaseConflicts[msg.Repo.Path] = msg.outcome.ConflictedFiles
+		}
+		m.finalizeRepoActivity(msg.Index, msg.Repo, func(activity domain.Activity) ActivityFinalizeResult {
+			rebasing, ok := activity.(*domain.RebasingActivity)
+			if !ok {
+				return ActivityFinalizeResult{}
+			}
+			rebasing.MarkComplete(msg.outcome)
+			return ActivityFinalizeResult{
+				Completed: true,
+				Info:      buildRebaseCompletionInfoMessage(*rebasing),
+			}
+		})
+
+	case forkSyncWorkState:
+		return m, listenForForkSyncProgress(msg)
+
+	case forkSyncLineMsg:
+		if msg.Index < len(m.Repositories) {
+			repo := &m.Repositories[msg.Index]
+			if syncing, ok := repo.Activity.(*domain.SyncingForkActivity); ok {
+				syncing.AddLine(msg.line)
+			}
+		}
+		if msg.state != nil {
+			return m, listenForForkSyncProgress(*msg.state)
+		}
+
+	case forkSyncCompleteMsg:
+		m.finalizeRepoActivity(msg.Index, msg.Repo, func(activity domain.Activity) ActivityFinalizeResult {
+			syncing, ok := activity.(*domain.SyncingForkActivity)
+			if !ok {
+				return ActivityFinalizeResult{}
+			}
+			syncing.MarkComplete(msg.outcome)
+			return ActivityFinalizeResult{
+				Completed: true,
+				Info:      buildForkSyncCompletionInfoMessage(*syncing),
+			}
+		})
+
+	case browserOpenedMsg:
+		if msg.Err != nil {
+			m.storeRecentActivityInfo(msg.RepoPath, InfoMessage{Text: "Open in browser failed: " + msg.Err.Error(), Tone: InfoToneError})
+		}
+		return m, nil
+
 	case infoRotateTickMsg:
 		m.InfoPhase++
 		m.pruneExpiredRecentActivityInfo(time.Now())
@@ -335,6 +717,30 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 					activity.Spinner, cmd = activity.Spinner.Update(msg)
 					cmds = append(cmds, cmd)
 				}
+			case *domain.SwitchingActivity:
+				if !activity.Complete {
+					var cmd tea.Cmd
+					activity.Spinner, cmd = activity.Spinner.Update(msg)
+					cmds = append(cmds, cmd)
+				}
+			case *domain.CreatingBranchActivity:
+				if !activity.Complete {
+					var cmd tea.Cmd
+					activity.Spinner, cmd = activity.Spinner.Update(msg)
+					cmds = append(cmds, cmd)
+				}
+			case *domain.RebasingActivity:
+				if !activity.Complete {
+					var cmd tea.Cmd
+					activity.Spinner, cmd = activity.Spinner.Update(msg)
+					cmds = append(cmds, cmd)
+				}
+			case *domain.SyncingForkActivity:
+				if !activity.Complete {
+					var cmd tea.Cmd
+					activity.Spinner, cmd = activity.Spinner.Update(msg)
+					cmds = append(cmds, cmd)
+				}
 			}
 		}
 		if len(cmds) > 0 {
@@ -344,6 +750,96 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 	return m, nil
 }
 
+// startBulkCheckout switches every target repository to branch, or to its
+// default branch when branch is empty. Busy repositories are left alone.
+func (m *Model) startBulkCheckout(branch string) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, i := range m.targetIndexes() {
+		repo := &m.Repositories[i]
+		if repo.IsBusy() {
+			continue
+		}
+		switching := &domain.SwitchingActivity{
+			Spinner: common.NewPullSpinner(),
+			Branch:  branch,
+		}
+		repo.Activity = switching
+		cmds = append(cmds, performCheckout(m.Config, i, repo.Path, branch))
+		cmds = append(cmds, switching.Spinner.Tick)
+	}
+	return tea.Batch(cmds...)
+}
+
+// startBulkBranchCreate creates branch from the updated default branch in
+// every target repository, optionally pushing it with upstream tracking.
+func (m *Model) startBulkBranchCreate(branch string, push bool) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, i := range m.targetIndexes() {
+		repo := &m.Repositories[i]
+		if repo.IsBusy() {
+			continue
+		}
+		creating := &domain.CreatingBranchActivity{
+			Spinner: common.NewPullSpinner(),
+			Branch:  branch,
+		}
+		repo.Activity = creating
+		cmds = append(cmds, performBranchCreate(m.Config, i, repo.Path, branch, push))
+		cmds = append(cmds, creating.Spinner.Tick)
+	}
+	return tea.Batch(cmds...)
+}
+
+// startBulkRebase rebases the current branch of every target repository
+// onto its updated default branch. Any conflict report left by an earlier
+// rebase of the same repository is cleared first.
+func (m *Model) startBulkRebase() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, i := range m.targetIndexes() {
+		repo := &m.Repositories[i]
+		if repo.IsBusy() {
+			continue
+		}
+		m.clearRebaseConflicts(repo.Path)
+		rebasing := &domain.RebasingActivity{
+			Spinner: common.NewPullSpinner(),
+		}
+		repo.Activity = rebasing
+		cmds = append(cmds, performRebase(m.Config, i, repo.Path))
+		cmds = append(cmds, rebasing.Spinner.Tick)
+	}
+	return tea.Batch(cmds...)
+}
+
+// startBulkForkSync syncs every target repository laid out as a fork. Other
+// repositories are left alone rather than reported as skipped, so syncing
+// with nothing marked only touches the forks.
+func (m *Model) startBulkForkSync() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, i := range m.targetIndexes() {
+		repo := &m.Repositories[i]
+		if repo.IsBusy() || !isFork(*repo) {
+			continue
+		}
+		syncing := &domain.SyncingForkActivity{
+			Spinner: common.NewPullSpinner(),
+		}
+		repo.Activity = syncing
+		cmds = append(cmds, performForkSync(m.Config, i, repo.Path))
+		cmds = append(cmds, syncing.Spinner.Tick)
+	}
+	return tea.Batch(cmds...)
+}
+
+func isFork(repo domain.Repository) bool {
+	switch repo.Fork.(type) {
+	case domain.ForkStatus, domain.ForkError:
+		return true
+	default:
+		return false
+	}
+}
+
 func (m *Model) applyPullRequestStates(states map[string]domain.PullRequestState) {
 	if len(states) == 0 {
 		return
@@ -355,6 +851,8 @@ func (m *Model) applyPullRequestStates(states map[string]domain.PullRequestState
 			repo.PullRequests = state
 		}
 	}
+
+	m.rebuildRows()
 }
 
 func (m *Model) applyRepoUpdate(index int, next domain.Repository, onUpdate func(*domain.Repository, domain.Activity)) {
@@ -373,6 +871,23 @@ func (m *Model) applyRepoUpdate(index int, next domain.Repository, onUpdate func
 	if onUpdate != nil {
 		onUpdate(repo, activity)
 	}
+
+	m.rebuildRows()
+}
+
+// RefreshRepository re-reads a single repository, for example after another
+// view has changed its stashes or working tree.
+func (m *Model) RefreshRepository(path string) tea.Cmd {
+	for i := range m.Repositories {
+		repo := &m.Repositories[i]
+		if repo.Path != path || repo.IsBusy() {
+			continue
+		}
+		refreshing := &domain.RefreshingActivity{Spinner: common.NewRefreshSpinner()}
+		repo.Activity = refreshing
+		return tea.Batch(performRefresh(m.Config, i, repo.Path), refreshing.Spinner.Tick)
+	}
+	return nil
 }
 
 func (m *Model) applyPullRequestWatchlist(tracked []pullrequests.Snapshot, seed bool) {
@@ -400,7 +915,25 @@ func (m *Model) View() string {
 		BlockedSpinner:       m.BlockedSpinner.View(),
 		ReadySpinner:         m.ReadySpinner.View(),
 	}
-	s.WriteString(GenerateTable(m.Repositories, m.Cursor, m.layout, runtime))
+	if m.showFilterBar() {
+		s.WriteString(m.buildFilterBar())
+		s.WriteString("\n")
+	}
+	if m.PromptingBranch {
+		s.WriteString(m.buildBranchPromptBar())
+		s.WriteString("\n")
+	}
+	if m.PendingSwitchAll {
+		s.WriteString(m.buildSwitchAllConfirmBar())
+		s.WriteString("\n")
+	}
+
+	start, end := m.visibleRange()
+	s.WriteString(GenerateTable(m.visibleTableRows(start, end), m.Cursor-start, m.layout, runtime))
+	if len(m.rows) == 0 {
+		s.WriteString("\n")
+		s.WriteString(common.TextGrey.Render("  No repositories match the filter"))
+	}
 	s.WriteString("\n\n")
 
 	s.WriteString(m.buildFooter())
@@ -413,23 +946,71 @@ func (m *Model) View() string {
 }
 
 func (m *Model) buildFooter() string {
+	return m.renderFooter(m.buildPositionIndicator())
+}
+
+func (m *Model) renderFooter(position string) string {
 	watchStatus := "w watch off"
 	if m.WatchEnabled {
 		watchStatus = "w watch on (" + m.currentWatchInterval().String() + ")"
 	}
 
+	sortStatus := "s sort: " + m.SortKey.String()
+	if m.SortReversed {
+		sortStatus += " (reversed)"
+	}
+
+	groupStatus := "v group: " + m.GroupMode.String()
+	if m.GroupMode != GroupNone {
+		groupStatus += " (tab folds)"
+	}
+
 	hotkeys := []string{
 		"↑/↓ navigate",
+		"pgup/pgdn page",
+		"home/end jump",
+		"/ filter",
+		sortStatus,
+		groupStatus,
 		"enter view PRs",
+		"i details",
+		"z stashes",
+		"l commits",
+		"c branches",
+		"o open in browser",
+		"space mark",
+		"m switch to default",
+		"C check out branch",
+		"n new branch",
+		"R rebase onto default",
+		"F sync forks",
 		"r refresh",
 		watchStatus,
+	}
+	if budget := m.buildRateLimitStatus(); budget != "" {
+		hotkeys = append(hotkeys, budget)
+	}
+	hotkeys = append(hotkeys,
 		"p pull all updates",
 		"b prune merged branches",
 		"? toggle legend",
 		"q quit",
+	)
+	if conflicts := len(m.RebaseConflicts); conflicts > 0 {
+		hotkeys = append([]string{common.PullOutputWarn.Render(fmt.Sprintf("%d with rebase conflicts", conflicts))}, hotkeys...)
+	}
+	if marked := m.markedCount(); marked > 0 {
+		hotkeys = append([]string{fmt.Sprintf("%d marked (esc clears)", marked)}, hotkeys...)
+	}
+	if position != "" {
+		hotkeys = append([]string{position}, hotkeys...)
 	}
 	footerText := strings.Join(hotkeys, "  •  ")
-	return common.FooterStyle.Render(footerText)
+	style := common.FooterStyle
+	if m.width > 0 {
+		style = style.Width(m.width)
+	}
+	return style.Render(footerText)
 }
 
 func (m *Model) storeRecentActivityInfo(repoPath string, message InfoMessage) {
@@ -437,11 +1018,34 @@ func (m *Model) storeRecentActivityInfo(repoPath string, message InfoMessage) {
 		return
 	}
 
-	now := time.Now()
-	expiresAt := now.Add(m.ActivityTTL)
+	// Pinned messages report something the user still has to act on, so
+	// they stay until cleared rather than expiring with the activity TTL.
+	var expiresAt time.Time
+	if !message.Pinned {
+		expiresAt = time.Now().Add(m.ActivityTTL)
+	}
 	m.RecentInfo[repoPath] = append(m.RecentInfo[repoPath], TimedInfoMessage{Message: message, ExpiresAt: expiresAt})
 }
 
+// clearRebaseConflicts forgets the conflicts reported by an earlier rebase
+// of the repository, along with its pinned info message.
+func (m *Model) clearRebaseConflicts(repoPath string) {
+	delete(m.RebaseConflicts, repoPath)
+
+	items := m.RecentInfo[repoPath]
+	filtered := items[:0]
+	for _, item := range items {
+		if !item.Message.Pinned {
+			filtered = append(filtered, item)
+		}
+	}
+	if len(filtered) == 0 {
+		delete(m.RecentInfo, repoPath)
+		return
+	}
+	m.RecentInfo[repoPath] = filtered
+}
+
 func (m *Model) pruneExpiredRecentActivityInfo(now time.Time) {
 	for repoPath, items := range m.RecentInfo {
 		filtered := items[:0]
@@ -1,6 +1,7 @@
 package listing
 
 import (
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -50,6 +51,22 @@ func TestStoreAndPruneRecentActivityInfo(t *testing.T) {
 	}
 }
 
+func TestUpdate_BrowserOpenFailureIsReported(t *testing.T) {
+	t.Parallel()
+
+	m := New(nil)
+	m.Update(browserOpenedMsg{RepoPath: "/tmp/repo"})
+	if len(m.RecentInfo["/tmp/repo"]) != 0 {
+		t.Fatalf("recent info = %+v, want nothing after opening", m.RecentInfo["/tmp/repo"])
+	}
+
+	m.Update(browserOpenedMsg{RepoPath: "/tmp/repo", Err: errors.New("xdg-open not found")})
+	items := m.RecentInfo["/tmp/repo"]
+	if len(items) != 1 || items[0].Message.Tone != InfoToneError || !strings.Contains(items[0].Message.Text, "xdg-open not found") {
+		t.Fatalf("recent info = %+v, want the failure as an error", items)
+	}
+}
+
 func TestBuildInfo_CompletedActivityRequiresRecentInfo(t *testing.T) {
 	t.Parallel()
 
@@ -128,3 +145,23 @@ func TestBuildInfo_NoUpstreamAndDetachedAreSubtleTone(t *testing.T) {
 		})
 	}
 }
+
+func TestRefreshRepository_MarksOnlyMatchingRepository(t *testing.T) {
+	t.Parallel()
+
+	m := New([]domain.Repository{makeTestRepository("repo-a"), makeTestRepository("repo-b")})
+	target := m.Repositories[1].Path
+
+	if cmd := m.RefreshRepository(target); cmd == nil {
+		t.Fatal("expected refresh command for known repository")
+	}
+	if _, ok := m.Repositories[1].Activity.(*domain.RefreshingActivity); !ok {
+		t.Fatalf("activity = %T, want RefreshingActivity", m.Repositories[1].Activity)
+	}
+	if _, ok := m.Repositories[0].Activity.(*domain.IdleActivity); !ok {
+		t.Fatalf("other activity = %T, want IdleActivity", m.Repositories[0].Activity)
+	}
+	if cmd := m.RefreshRepository("/does/not/exist"); cmd != nil {
+		t.Fatal("expected no command for unknown repository")
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"time"
 
 	"fresh/internal/domain"
+	"fresh/internal/git"
 	"fresh/internal/pullrequests"
 
+	"charm.land/bubbles/v2/spinner"
 	tea "charm.land/bubbletea/v2"
 )
 
@@ -26,6 +28,7 @@ type PullRequestStatesUpdatedMsg struct {
 	Generation uint64
 	States     map[string]domain.PullRequestState
 	Tracked    []pullrequests.Snapshot
+	RateLimit  git.RateLimit
 	Trigger    PullRequestSyncTrigger
 }
 
@@ -33,6 +36,29 @@ type OpenPullRequestsMsg struct {
 	Repo domain.Repository
 }
 
+type OpenRepositoryDetailsMsg struct {
+	Repo domain.Repository
+}
+
+type OpenStashesMsg struct {
+	Repo domain.Repository
+}
+
+type OpenCommitLogMsg struct {
+	Repo domain.Repository
+}
+
+type OpenBranchesMsg struct {
+	Repo domain.Repository
+}
+
+// browserOpenedMsg reports whether the repository's web page could be
+// opened.
+type browserOpenedMsg struct {
+	RepoPath string
+	Err      error
+}
+
 type pullLineMsg struct {
 	Index int
 	line  string
@@ -57,8 +83,74 @@ type pruneCompleteMsg struct {
 	Repo    domain.Repository
 }
 
+type checkoutLineMsg struct {
+	Index int
+	line  string
+	state *checkoutWorkState
+}
+
+type checkoutCompleteMsg struct {
+	Index   int
+	outcome domain.CheckoutOutcome
+	Repo    domain.Repository
+}
+
+type branchCreateLineMsg struct {
+	Index int
+	line  string
+	state *branchCreateWorkState
+}
+
+type branchCreateCompleteMsg struct {
+	Index   int
+	outcome domain.BranchCreateOutcome
+	Repo    domain.Repository
+}
+
+type rebaseLineMsg struct {
+	Index int
+	line  string
+	state *rebaseWorkState
+}
+
+type rebaseCompleteMsg struct {
+	Index   int
+	outcome domain.RebaseOutcome
+	Repo    domain.Repository
+}
+
+type forkSyncLineMsg struct {
+	Index int
+	line  string
+	state *forkSyncWorkState
+}
+
+type forkSyncCompleteMsg struct {
+	Index   int
+	outcome domain.ForkSyncOutcome
+	Repo    domain.Repository
+}
+
 type infoRotateTickMsg struct{}
 
+// IsBackgroundMsg reports whether msg belongs to work the list started, such
+// as a streamed pull, a refresh or a pull request sync, or keeps one of its
+// tick loops going. These must reach the list while another view is in
+// front or the work never completes.
+func IsBackgroundMsg(msg tea.Msg) bool {
+	switch msg.(type) {
+	case RepoUpdatedMsg, PullRequestStatesUpdatedMsg, watchTickMsg, infoRotateTickMsg, spinner.TickMsg, browserOpenedMsg,
+		pullWorkState, pullLineMsg, pullCompleteMsg,
+		pruneWorkState, pruneLineMsg, pruneCompleteMsg,
+		checkoutWorkState, checkoutLineMsg, checkoutCompleteMsg,
+		branchCreateWorkState, branchCreateLineMsg, branchCreateCompleteMsg,
+		rebaseWorkState, rebaseLineMsg, rebaseCompleteMsg,
+		forkSyncWorkState, forkSyncLineMsg, forkSyncCompleteMsg:
+		return true
+	}
+	return false
+}
+
 var scheduleTick = tea.Tick
 
 func scheduleInfoRotateTick(interval time.Duration) tea.Cmd {
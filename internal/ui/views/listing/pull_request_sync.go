@@ -8,7 +8,7 @@ func (m *Model) startPullRequestSync(trigger PullRequestSyncTrigger) tea.Cmd {
 	generation := m.PRSyncGeneration
 
 	return tea.Batch(
-		performPullRequestSync(m.Repositories, trigger, generation),
+		performPullRequestSync(m.Providers, m.Repositories, trigger, generation),
 		m.PRSyncSpinner.Tick,
 	)
 }
@@ -0,0 +1,350 @@
+package listing
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"fresh/internal/domain"
+	"fresh/internal/git"
+)
+
+type SortKey int
+
+const (
+	SortByName SortKey = iota
+	SortByLastCommit
+	SortByBehind
+	SortByDirty
+	SortByPullRequests
+	SortByPath
+	sortKeyCount
+)
+
+func (k SortKey) String() string {
+	switch k {
+	case SortByLastCommit:
+		return "last commit"
+	case SortByBehind:
+		return "behind"
+	case SortByDirty:
+		return "dirty"
+	case SortByPullRequests:
+		return "PR activity"
+	case SortByPath:
+		return "path"
+	default:
+		return "name"
+	}
+}
+
+func (k SortKey) next() SortKey {
+	return (k + 1) % sortKeyCount
+}
+
+type GroupMode int
+
+const (
+	GroupNone GroupMode = iota
+	GroupByDirectory
+	GroupByOwner
+	groupModeCount
+)
+
+func (g GroupMode) String() string {
+	switch g {
+	case GroupByDirectory:
+		return "directory"
+	case GroupByOwner:
+		return "owner"
+	default:
+		return "none"
+	}
+}
+
+func (g GroupMode) next() GroupMode {
+	return (g + 1) % groupModeCount
+}
+
+// displayRow is one line of the listing table: either a repository, addressed
+// by its index in Model.Repositories, or the header of a group.
+type displayRow struct {
+	index int
+	group string
+}
+
+func (r displayRow) isGroupHeader() bool {
+	return r.index < 0
+}
+
+// TableRow is what GenerateTable renders: a repository or a group header.
+type TableRow struct {
+	Repo   domain.Repository
+	Group  *GroupHeader
+	Marked bool
+}
+
+type GroupHeader struct {
+	Label     string
+	Count     int
+	Collapsed bool
+}
+
+func repositoryRows(repos []domain.Repository) []TableRow {
+	rows := make([]TableRow, len(repos))
+	for i, repo := range repos {
+		rows[i] = TableRow{Repo: repo}
+	}
+	return rows
+}
+
+// rebuildRows applies the filter, sort order and grouping to produce the
+// rows on screen, keeping the cursor on the same repository or group.
+func (m *Model) rebuildRows() {
+	selected, hadSelection := m.selectedRow()
+
+	matched := make([]int, 0, len(m.Repositories))
+	for i, repo := range m.Repositories {
+		if m.FilterQuery.Matches(repo) {
+			matched = append(matched, i)
+		}
+	}
+	m.sortIndexes(matched)
+	m.matched = matched
+	m.rows = m.groupRows(matched)
+
+	m.Cursor = 0
+	if hadSelection {
+		for row, candidate := range m.rows {
+			if m.sameRow(candidate, selected) {
+				m.Cursor = row
+				break
+			}
+		}
+	}
+	if len(m.rows) > 0 {
+		m.Cursor = m.nearestStop(m.Cursor, 1)
+	}
+	m.ensureCursorVisible()
+}
+
+func (m *Model) sameRow(a, b displayRow) bool {
+	if a.isGroupHeader() || b.isGroupHeader() {
+		return a.isGroupHeader() && b.isGroupHeader() && a.group == b.group
+	}
+	return m.Repositories[a.index].Path == m.Repositories[b.index].Path
+}
+
+func (m *Model) sortIndexes(indexes []int) {
+	less := sortLess(m.SortKey)
+	sort.SliceStable(indexes, func(i, j int) bool {
+		left, right := m.Repositories[indexes[i]], m.Repositories[indexes[j]]
+		if m.SortReversed {
+			left, right = right, left
+		}
+		if less(left, right) {
+			return true
+		}
+		if less(right, left) {
+			return false
+		}
+		return strings.ToLower(left.Name) < strings.ToLower(right.Name)
+	})
+}
+
+// sortLess orders repositories so the most interesting ones come first for
+// every key except name and path, which sort alphabetically.
+func sortLess(key SortKey) func(a, b domain.Repository) bool {
+	switch key {
+	case SortByLastCommit:
+		return func(a, b domain.Repository) bool { return a.LastCommitAt.After(b.LastCommitAt) }
+	case SortByBehind:
+		return func(a, b domain.Repository) bool { return behindCount(a) > behindCount(b) }
+	case SortByDirty:
+		return func(a, b domain.Repository) bool { return dirtyCount(a) > dirtyCount(b) }
+	case SortByPullRequests:
+		return func(a, b domain.Repository) bool {
+			left, right := a.PullRequestCount(), b.PullRequestCount()
+			if left.MyOpen != right.MyOpen {
+				return left.MyOpen > right.MyOpen
+			}
+			if left.ReviewRequested != right.ReviewRequested {
+				return left.ReviewRequested > right.ReviewRequested
+			}
+			return left.Open > right.Open
+		}
+	case SortByPath:
+		return func(a, b domain.Repository) bool { return a.Path < b.Path }
+	default:
+		return func(a, b domain.Repository) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) }
+	}
+}
+
+func behindCount(repo domain.Repository) int {
+	switch s := repo.RemoteState.(type) {
+	case domain.Behind:
+		return s.Count
+	case domain.Diverged:
+		return s.BehindCount
+	default:
+		return 0
+	}
+}
+
+func dirtyCount(repo domain.Repository) int {
+	if s, ok := repo.LocalState.(domain.DirtyLocalState); ok {
+		return s.Added + s.Modified + s.Deleted + s.Untracked
+	}
+	return 0
+}
+
+func (m *Model) groupRows(indexes []int) []displayRow {
+	if m.GroupMode == GroupNone {
+		rows := make([]displayRow, len(indexes))
+		for i, index := range indexes {
+			rows[i] = displayRow{index: index}
+		}
+		return rows
+	}
+
+	var order []string
+	members := make(map[string][]int)
+	for _, index := range indexes {
+		key := m.groupKey(m.Repositories[index])
+		if _, ok := members[key]; !ok {
+			order = append(order, key)
+		}
+		members[key] = append(members[key], index)
+	}
+	sort.Strings(order)
+
+	rows := make([]displayRow, 0, len(indexes)+len(order))
+	for _, key := range order {
+		rows = append(rows, displayRow{index: -1, group: key})
+		if m.CollapsedGroups[key] {
+			continue
+		}
+		for _, index := range members[key] {
+			rows = append(rows, displayRow{index: index, group: key})
+		}
+	}
+	return rows
+}
+
+func (m *Model) groupKey(repo domain.Repository) string {
+	switch m.GroupMode {
+	case GroupByDirectory:
+		return displayDirectory(filepath.Dir(repo.Path))
+	case GroupByOwner:
+		if location, ok := git.ResolveRemoteURL(m.Config, repo.RemoteURL); ok {
+			return location.Host + "/" + location.Owner
+		}
+		return "(no remote)"
+	default:
+		return ""
+	}
+}
+
+func displayDirectory(dir string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return dir
+	}
+	if dir == home {
+		return "~"
+	}
+	if rel, err := filepath.Rel(home, dir); err == nil && !strings.HasPrefix(rel, "..") {
+		return filepath.Join("~", rel)
+	}
+	return dir
+}
+
+func (m *Model) toggleGroupCollapsed(group string) {
+	if m.CollapsedGroups == nil {
+		m.CollapsedGroups = make(map[string]bool)
+	}
+	m.CollapsedGroups[group] = !m.CollapsedGroups[group]
+	m.rebuildRows()
+	if !m.CollapsedGroups[group] {
+		return
+	}
+	for row, candidate := range m.rows {
+		if candidate.isGroupHeader() && candidate.group == group {
+			m.Cursor = row
+			m.ensureCursorVisible()
+			return
+		}
+	}
+}
+
+func (m *Model) cycleSortKey() {
+	m.SortKey = m.SortKey.next()
+	m.SortReversed = false
+	m.rebuildRows()
+}
+
+func (m *Model) toggleSortDirection() {
+	m.SortReversed = !m.SortReversed
+	m.rebuildRows()
+}
+
+func (m *Model) cycleGroupMode() {
+	m.GroupMode = m.GroupMode.next()
+	m.rebuildRows()
+}
+
+func (m *Model) selectedRow() (displayRow, bool) {
+	if m.Cursor < 0 || m.Cursor >= len(m.rows) {
+		return displayRow{}, false
+	}
+	return m.rows[m.Cursor], true
+}
+
+// selectedRepository returns the repository under the cursor, if the cursor
+// is not on a group header.
+func (m *Model) selectedRepository() (domain.Repository, bool) {
+	index, ok := m.selectedIndex()
+	if !ok {
+		return domain.Repository{}, false
+	}
+	return m.Repositories[index], true
+}
+
+func (m *Model) selectedIndex() (int, bool) {
+	row, ok := m.selectedRow()
+	if !ok || row.isGroupHeader() || row.index >= len(m.Repositories) {
+		return 0, false
+	}
+	return row.index, true
+}
+
+// visibleIndexes returns the repository indexes bulk actions should target:
+// every repository matching the filter, including those in collapsed groups.
+func (m *Model) visibleIndexes() []int {
+	return append([]int(nil), m.matched...)
+}
+
+func (m *Model) visibleTableRows(start, end int) []TableRow {
+	counts := make(map[string]int)
+	if m.GroupMode != GroupNone {
+		for _, index := range m.matched {
+			counts[m.groupKey(m.Repositories[index])]++
+		}
+	}
+
+	rows := make([]TableRow, 0, end-start)
+	for _, row := range m.rows[start:end] {
+		if row.isGroupHeader() {
+			rows = append(rows, TableRow{Group: &GroupHeader{
+				Label:     row.group,
+				Count:     counts[row.group],
+				Collapsed: m.CollapsedGroups[row.group],
+			}})
+			continue
+		}
+		repo := m.Repositories[row.index]
+		rows = append(rows, TableRow{Repo: repo, Marked: m.Marked[repo.Path]})
+	}
+	return rows
+}
@@ -0,0 +1,112 @@
+package listing
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"fresh/internal/domain"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func visibleNames(m *Model) []string {
+	names := make([]string, 0, len(m.rows))
+	for _, row := range m.rows {
+		if row.isGroupHeader() {
+			names = append(names, "["+row.group+"]")
+			continue
+		}
+		names = append(names, m.Repositories[row.index].Name)
+	}
+	return names
+}
+
+func TestSortKeysOrderRows(t *testing.T) {
+	now := time.Now()
+	m := New([]domain.Repository{
+		newTestRepository("alpha").Path("/z/alpha").LastCommitAt(now.Add(-time.Hour)).RemoteState(domain.Behind{Count: 1}).Build(),
+		newTestRepository("bravo").Path("/a/bravo").LastCommitAt(now).LocalState(domain.DirtyLocalState{Modified: 3}).Build(),
+		newTestRepository("charlie").Path("/m/charlie").LastCommitAt(now.Add(-2 * time.Hour)).RemoteState(domain.Diverged{AheadCount: 1, BehindCount: 5}).PullRequests(domain.PullRequestCount{Open: 2, MyOpen: 1}).Build(),
+	})
+
+	tests := []struct {
+		key  SortKey
+		want string
+	}{
+		{key: SortByName, want: "alpha,bravo,charlie"},
+		{key: SortByLastCommit, want: "bravo,alpha,charlie"},
+		{key: SortByBehind, want: "charlie,alpha,bravo"},
+		{key: SortByDirty, want: "bravo,alpha,charlie"},
+		{key: SortByPullRequests, want: "charlie,alpha,bravo"},
+		{key: SortByPath, want: "bravo,charlie,alpha"},
+	}
+
+	for _, tt := range tests {
+		m.SortKey = tt.key
+		m.rebuildRows()
+		if got := strings.Join(visibleNames(m), ","); got != tt.want {
+			t.Errorf("sort %s = %s, want %s", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestCycleSortKeyAndReverse(t *testing.T) {
+	m := New([]domain.Repository{makeTestRepository("alpha"), makeTestRepository("bravo")})
+
+	m.Update(tea.KeyPressMsg{Code: 'S', Text: "S"})
+	if got := strings.Join(visibleNames(m), ","); got != "bravo,alpha" {
+		t.Fatalf("reversed order = %s, want bravo,alpha", got)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: 's', Text: "s"})
+	if m.SortKey != SortByLastCommit || m.SortReversed {
+		t.Fatalf("sort = %s reversed=%v, want last commit not reversed", m.SortKey, m.SortReversed)
+	}
+	if !strings.Contains(m.buildFooter(), "sort: last commit") {
+		t.Fatal("expected footer to show sort key")
+	}
+}
+
+func TestGroupByDirectoryWithCollapsibleHeaders(t *testing.T) {
+	m := New([]domain.Repository{
+		newTestRepository("api").Path("/src/work/api").Build(),
+		newTestRepository("web").Path("/src/work/web").Build(),
+		newTestRepository("dotfiles").Path("/src/home/dotfiles").Build(),
+	})
+
+	m.Update(tea.KeyPressMsg{Code: 'v', Text: "v"})
+	if got := strings.Join(visibleNames(m), ","); got != "[/src/home],dotfiles,[/src/work],api,web" {
+		t.Fatalf("grouped rows = %s", got)
+	}
+
+	m.Cursor = 2
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if got := strings.Join(visibleNames(m), ","); got != "[/src/home],dotfiles,[/src/work]" {
+		t.Fatalf("collapsed rows = %s", got)
+	}
+	if row, ok := m.selectedRow(); !ok || !row.isGroupHeader() || row.group != "/src/work" {
+		t.Fatal("expected cursor to stay on collapsed group header")
+	}
+	if len(m.visibleIndexes()) != 3 {
+		t.Fatalf("bulk targets = %d, want 3 including collapsed group", len(m.visibleIndexes()))
+	}
+	if !strings.Contains(m.View(), "/src/work (2)") {
+		t.Fatal("expected group header with count in table")
+	}
+}
+
+func TestGroupByOwner(t *testing.T) {
+	m := New([]domain.Repository{
+		newTestRepository("api").RemoteURL("git@github.com:acme/api.git").Build(),
+		newTestRepository("tool").RemoteURL("https://gitlab.com/ops/infra/tool").Build(),
+		newTestRepository("scratch").Build(),
+	})
+	m.GroupMode = GroupByOwner
+	m.rebuildRows()
+
+	want := "[(no remote)],scratch,[github.com/acme],api,[gitlab.com/ops/infra],tool"
+	if got := strings.Join(visibleNames(m), ","); got != want {
+		t.Fatalf("owner groups = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,70 @@
+package listing
+
+// Marked repositories are the target of bulk actions. With nothing marked,
+// bulk actions fall back to every repository matching the filter.
+
+func (m *Model) toggleMark() {
+	repo, ok := m.selectedRepository()
+	if !ok {
+		if row, isRow := m.selectedRow(); isRow && row.isGroupHeader() {
+			m.toggleGroupMarks(row.group)
+		}
+		return
+	}
+	if m.Marked[repo.Path] {
+		delete(m.Marked, repo.Path)
+		return
+	}
+	m.Marked[repo.Path] = true
+}
+
+// toggleGroupMarks marks every matching repository in the group, or clears
+// them all when the group is already fully marked.
+func (m *Model) toggleGroupMarks(group string) {
+	var members []string
+	allMarked := true
+	for _, index := range m.matched {
+		repo := m.Repositories[index]
+		if m.groupKey(repo) != group {
+			continue
+		}
+		members = append(members, repo.Path)
+		allMarked = allMarked && m.Marked[repo.Path]
+	}
+	for _, path := range members {
+		if allMarked {
+			delete(m.Marked, path)
+		} else {
+			m.Marked[path] = true
+		}
+	}
+}
+
+func (m *Model) clearMarks() {
+	m.Marked = make(map[string]bool)
+}
+
+func (m *Model) markedCount() int {
+	count := 0
+	for _, repo := range m.Repositories {
+		if m.Marked[repo.Path] {
+			count++
+		}
+	}
+	return count
+}
+
+// targetIndexes returns the repositories a bulk action should run against.
+func (m *Model) targetIndexes() []int {
+	if m.markedCount() == 0 {
+		return m.visibleIndexes()
+	}
+
+	var indexes []int
+	for i, repo := range m.Repositories {
+		if m.Marked[repo.Path] {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
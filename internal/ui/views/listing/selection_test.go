@@ -0,0 +1,461 @@
+package listing
+
+import (
+	"strings"
+	"testing"
+
+	"fresh/internal/domain"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func pressSpace(m *Model) {
+	m.Update(tea.KeyPressMsg{Code: tea.KeySpace, Text: " "})
+}
+
+func TestTargetIndexes_PrefersMarkedRepositories(t *testing.T) {
+	t.Parallel()
+
+	m := New([]domain.Repository{
+		makeTestRepository("api"),
+		makeTestRepository("web"),
+		makeTestRepository("worker"),
+	})
+
+	if got := len(m.targetIndexes()); got != 3 {
+		t.Fatalf("targets without marks = %d, want 3", got)
+	}
+
+	m.moveCursor(1)
+	pressSpace(m)
+
+	targets := m.targetIndexes()
+	if len(targets) != 1 || m.Repositories[targets[0]].Name != "web" {
+		t.Fatalf("targets = %v, want only web", targets)
+	}
+
+	pressSpace(m)
+	if m.markedCount() != 0 {
+		t.Fatal("expected second space to unmark")
+	}
+}
+
+func TestEscapeClearsMarksWhenNoFilter(t *testing.T) {
+	t.Parallel()
+
+	m := New([]domain.Repository{makeTestRepository("api"), makeTestRepository("web")})
+	pressSpace(m)
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.markedCount() != 0 {
+		t.Fatal("expected esc to clear marks")
+	}
+}
+
+func TestToggleMarkOnGroupHeaderMarksMembers(t *testing.T) {
+	t.Parallel()
+
+	m := New([]domain.Repository{
+		newTestRepository("api").Path("/src/a/api").Build(),
+		newTestRepository("web").Path("/src/a/web").Build(),
+		newTestRepository("cli").Path("/src/b/cli").Build(),
+	})
+	m.cycleGroupMode()
+	m.Cursor = 0
+
+	pressSpace(m)
+	if m.markedCount() != 2 {
+		t.Fatalf("marked = %d, want the 2 repositories in the first group", m.markedCount())
+	}
+
+	pressSpace(m)
+	if m.markedCount() != 0 {
+		t.Fatal("expected toggling a fully marked group to clear it")
+	}
+}
+
+func TestSwitchToDefaultStartsActivityOnTargets(t *testing.T) {
+	t.Parallel()
+
+	m := New([]domain.Repository{makeTestRepository("api"), makeTestRepository("web")})
+	pressSpace(m)
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: 'm', Text: "m"})
+	if cmd == nil {
+		t.Fatal("expected checkout commands")
+	}
+	if _, ok := m.Repositories[0].Activity.(*domain.SwitchingActivity); !ok {
+		t.Fatalf("marked activity = %T, want SwitchingActivity", m.Repositories[0].Activity)
+	}
+	if _, ok := m.Repositories[1].Activity.(*domain.IdleActivity); !ok {
+		t.Fatalf("unmarked activity = %T, want IdleActivity", m.Repositories[1].Activity)
+	}
+}
+
+func TestSwitchToDefaultWithoutMarksAsksFirst(t *testing.T) {
+	t.Parallel()
+
+	m := New([]domain.Repository{makeTestRepository("api"), makeTestRepository("web")})
+
+	if _, cmd := m.Update(tea.KeyPressMsg{Code: 'm', Text: "m"}); cmd != nil {
+		t.Fatal("expected the first m without marks only to ask for confirmation")
+	}
+	if !strings.Contains(m.View(), "Press m again to switch all 2 repositories") {
+		t.Fatalf("view = %q, want the confirmation shown", m.View())
+	}
+
+	m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if _, cmd := m.Update(tea.KeyPressMsg{Code: 'm', Text: "m"}); cmd != nil || !m.PendingSwitchAll {
+		t.Fatal("expected another key to cancel the confirmation")
+	}
+
+	if _, cmd := m.Update(tea.KeyPressMsg{Code: 'm', Text: "m"}); cmd == nil {
+		t.Fatal("expected m twice in a row to start the checkouts")
+	}
+	for _, repo := range m.Repositories {
+		if _, ok := repo.Activity.(*domain.SwitchingActivity); !ok {
+			t.Fatalf("activity = %T, want SwitchingActivity", repo.Activity)
+		}
+	}
+}
+
+func TestBranchPromptCapturesInputAndStartsCheckout(t *testing.T) {
+	t.Parallel()
+
+	m := New([]domain.Repository{makeTestRepository("api"), makeTestRepository("web")})
+	m.Update(tea.KeyPressMsg{Code: 'C', Text: "C"})
+	if !m.IsCapturingInput() {
+		t.Fatal("expected branch prompt to capture input")
+	}
+
+	for _, r := range "release" {
+		m.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	if m.SortKey != SortByName {
+		t.Fatal("expected typed keys not to trigger hotkeys")
+	}
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil || m.PromptingBranch {
+		t.Fatal("expected enter to close the prompt and start the checkout")
+	}
+	for _, repo := range m.Repositories {
+		switching, ok := repo.Activity.(*domain.SwitchingActivity)
+		if !ok || switching.Branch != "release" {
+			t.Fatalf("activity = %#v, want switching to release", repo.Activity)
+		}
+	}
+}
+
+func TestCheckoutCompletionInfoMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		outcome domain.CheckoutOutcome
+		want    string
+		tone    InfoTone
+	}{
+		{name: "switched", outcome: domain.CheckoutOutcome{Branch: "main"}, want: "Switched to main", tone: InfoToneSuccess},
+		{name: "already", outcome: domain.CheckoutOutcome{Branch: "main", AlreadyOn: true}, want: "Already on main", tone: InfoTonePrimary},
+		{name: "dirty", outcome: domain.CheckoutOutcome{Skipped: true, SkipReason: "uncommitted changes"}, want: "Skipped: uncommitted changes", tone: InfoToneWarn},
+		{
+			name:    "failed",
+			outcome: domain.CheckoutOutcome{Branch: "main", CommandOutcome: domain.CommandOutcome{ExitCode: 1, FailureReason: "error: pathspec"}},
+			want:    "Checkout failed: pathspec",
+			tone:    InfoToneError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			activity := domain.SwitchingActivity{}
+			activity.MarkComplete(tt.outcome)
+			got := buildCheckoutCompletionInfoMessage(activity)
+			if !got.OK || got.Message.Text != tt.want || got.Message.Tone != tt.tone {
+				t.Fatalf("info = %+v, want %q tone %d", got, tt.want, tt.tone)
+			}
+		})
+	}
+}
+
+func TestNewBranchPromptTogglesPushAndStartsCreate(t *testing.T) {
+	t.Parallel()
+
+	m := New([]domain.Repository{makeTestRepository("api"), makeTestRepository("web")})
+	m.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	if !m.PromptingBranch || m.BranchPromptMode != branchPromptCreate {
+		t.Fatal("expected n to open the new branch prompt")
+	}
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if !m.PushNewBranch {
+		t.Fatal("expected tab to enable pushing the new branch")
+	}
+	if !strings.Contains(m.View(), "[x] push with upstream") {
+		t.Fatal("expected prompt bar to show the push option")
+	}
+
+	for _, r := range "feat/shared" {
+		m.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected branch creation commands")
+	}
+	for _, repo := range m.Repositories {
+		creating, ok := repo.Activity.(*domain.CreatingBranchActivity)
+		if !ok || creating.Branch != "feat/shared" {
+			t.Fatalf("activity = %#v, want creating feat/shared", repo.Activity)
+		}
+	}
+}
+
+func TestBranchCreateCompletionInfoMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		outcome domain.BranchCreateOutcome
+		want    string
+		tone    InfoTone
+	}{
+		{
+			name:    "created",
+			outcome: domain.BranchCreateOutcome{Branch: "feat", StartPoint: "origin/main"},
+			want:    "Created feat from origin/main",
+			tone:    InfoToneSuccess,
+		},
+		{
+			name:    "created and pushed",
+			outcome: domain.BranchCreateOutcome{Branch: "feat", StartPoint: "origin/main", Pushed: true},
+			want:    "Created feat from origin/main and pushed",
+			tone:    InfoToneSuccess,
+		},
+		{
+			name:    "exists",
+			outcome: domain.BranchCreateOutcome{Branch: "feat", Skipped: true, SkipReason: "branch feat already exists"},
+			want:    "Skipped: branch feat already exists",
+			tone:    InfoToneWarn,
+		},
+		{
+			name: "push failed",
+			outcome: domain.BranchCreateOutcome{
+				Branch:         "feat",
+				StartPoint:     "origin/main",
+				CommandOutcome: domain.CommandOutcome{ExitCode: 1, FailureReason: "error: permission denied"},
+			},
+			want: "Created feat, push failed: permission denied",
+			tone: InfoToneWarn,
+		},
+		{
+			name:    "fetch failed",
+			outcome: domain.BranchCreateOutcome{Branch: "feat", CommandOutcome: domain.CommandOutcome{ExitCode: 128, FailureReason: "fatal: no network"}},
+			want:    "Create branch failed: fatal: no network",
+			tone:    InfoToneError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			activity := domain.CreatingBranchActivity{Branch: "feat"}
+			activity.MarkComplete(tt.outcome)
+			got := buildBranchCreateCompletionInfoMessage(activity)
+			if !got.OK || got.Message.Text != tt.want || got.Message.Tone != tt.tone {
+				t.Fatalf("info = %+v, want %q tone %d", got, tt.want, tt.tone)
+			}
+		})
+	}
+}
+
+func TestRebaseStartsActivityAndClearsEarlierConflicts(t *testing.T) {
+	t.Parallel()
+
+	m := New([]domain.Repository{makeTestRepository("api"), makeTestRepository("web")})
+	path := m.Repositories[0].Path
+	m.RebaseConflicts[path] = []string{"main.go"}
+	m.storeRecentActivityInfo(path, InfoMessage{Text: "Rebase conflicts: main.go (aborted)", Tone: InfoToneError, Pinned: true})
+	pressSpace(m)
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: 'R', Text: "R"})
+	if cmd == nil {
+		t.Fatal("expected rebase commands")
+	}
+	if _, ok := m.Repositories[0].Activity.(*domain.RebasingActivity); !ok {
+		t.Fatalf("marked activity = %T, want RebasingActivity", m.Repositories[0].Activity)
+	}
+	if _, ok := m.Repositories[1].Activity.(*domain.IdleActivity); !ok {
+		t.Fatalf("unmarked activity = %T, want IdleActivity", m.Repositories[1].Activity)
+	}
+	if len(m.RebaseConflicts) != 0 || len(m.RecentInfo[path]) != 0 {
+		t.Fatalf("earlier conflicts not cleared: %v %v", m.RebaseConflicts, m.RecentInfo[path])
+	}
+}
+
+func TestRebaseConflictsArePinnedUntilCleared(t *testing.T) {
+	t.Parallel()
+
+	m := New([]domain.Repository{makeTestRepository("api")})
+	repo := m.Repositories[0]
+	repo.Activity = &domain.RebasingActivity{}
+	m.Repositories[0] = repo
+
+	m.Update(rebaseCompleteMsg{
+		Index: 0,
+		Repo:  repo,
+		outcome: domain.RebaseOutcome{
+			CommandOutcome:  domain.CommandOutcome{ExitCode: 1, FailureReason: "conflicts in 2 files"},
+			Branch:          "feat",
+			Onto:            "origin/main",
+			ConflictedFiles: []string{"a.go", "b.go"},
+		},
+	})
+
+	if got := m.RebaseConflicts[repo.Path]; len(got) != 2 {
+		t.Fatalf("conflicts = %v, want 2 files", got)
+	}
+	items := m.RecentInfo[repo.Path]
+	if len(items) != 1 || !items[0].ExpiresAt.IsZero() || !items[0].Message.Pinned {
+		t.Fatalf("recent info = %+v, want one pinned message without expiry", items)
+	}
+	if footer := m.buildFooter(); !strings.Contains(footer, "1 with rebase conflicts") {
+		t.Fatalf("footer missing conflict count: %q", footer)
+	}
+}
+
+func TestRebaseCompletionInfoMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		outcome domain.RebaseOutcome
+		want    string
+		tone    InfoTone
+		pinned  bool
+	}{
+		{
+			name:    "rebased",
+			outcome: domain.RebaseOutcome{Branch: "feat", Onto: "origin/main"},
+			want:    "Rebased feat onto origin/main",
+			tone:    InfoToneSuccess,
+		},
+		{
+			name:    "up to date",
+			outcome: domain.RebaseOutcome{Branch: "feat", Onto: "origin/main", UpToDate: true},
+			want:    "Already based on origin/main",
+			tone:    InfoTonePrimary,
+		},
+		{
+			name:    "on default branch",
+			outcome: domain.RebaseOutcome{Skipped: true, SkipReason: "already on main"},
+			want:    "Skipped: already on main",
+			tone:    InfoToneWarn,
+		},
+		{
+			name: "conflicts",
+			outcome: domain.RebaseOutcome{
+				CommandOutcome:  domain.CommandOutcome{ExitCode: 1, FailureReason: "conflicts in 2 files"},
+				Branch:          "feat",
+				Onto:            "origin/main",
+				ConflictedFiles: []string{"a.go", "b.go"},
+			},
+			want:   "Rebase conflicts: a.go, b.go (aborted)",
+			tone:   InfoToneError,
+			pinned: true,
+		},
+		{
+			name:    "fetch failed",
+			outcome: domain.RebaseOutcome{CommandOutcome: domain.CommandOutcome{ExitCode: 128, FailureReason: "fatal: no network"}},
+			want:    "Rebase failed: fatal: no network",
+			tone:    InfoToneError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			activity := domain.RebasingActivity{}
+			activity.MarkComplete(tt.outcome)
+			got := buildRebaseCompletionInfoMessage(activity)
+			if !got.OK || got.Message.Text != tt.want || got.Message.Tone != tt.tone || got.Message.Pinned != tt.pinned {
+				t.Fatalf("info = %+v, want %q tone %d pinned %v", got, tt.want, tt.tone, tt.pinned)
+			}
+		})
+	}
+}
+
+func TestForkSyncStartsOnlyOnForks(t *testing.T) {
+	t.Parallel()
+
+	m := New([]domain.Repository{
+		newTestRepository("api").Fork(domain.ForkStatus{Branch: "main", Behind: 2}).Build(),
+		newTestRepository("web").Fork(domain.NotFork{}).Build(),
+	})
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: 'F', Text: "F"})
+	if cmd == nil {
+		t.Fatal("expected fork sync commands")
+	}
+	if _, ok := m.Repositories[0].Activity.(*domain.SyncingForkActivity); !ok {
+		t.Fatalf("fork activity = %T, want SyncingForkActivity", m.Repositories[0].Activity)
+	}
+	if _, ok := m.Repositories[1].Activity.(*domain.IdleActivity); !ok {
+		t.Fatalf("non-fork activity = %T, want IdleActivity", m.Repositories[1].Activity)
+	}
+}
+
+func TestForkSyncCompletionInfoMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		outcome domain.ForkSyncOutcome
+		want    string
+		tone    InfoTone
+	}{
+		{
+			name:    "synced",
+			outcome: domain.ForkSyncOutcome{Branch: "main", Behind: 3, Pushed: true},
+			want:    "Synced fork main with upstream (3 commits)",
+			tone:    InfoToneSuccess,
+		},
+		{
+			name:    "synced one commit",
+			outcome: domain.ForkSyncOutcome{Branch: "main", Behind: 1, Pushed: true},
+			want:    "Synced fork main with upstream (1 commit)",
+			tone:    InfoToneSuccess,
+		},
+		{
+			name:    "up to date",
+			outcome: domain.ForkSyncOutcome{Branch: "main", UpToDate: true},
+			want:    "Fork main already matches upstream",
+			tone:    InfoTonePrimary,
+		},
+		{
+			name:    "dirty",
+			outcome: domain.ForkSyncOutcome{Skipped: true, SkipReason: "uncommitted changes on main"},
+			want:    "Skipped: uncommitted changes on main",
+			tone:    InfoToneWarn,
+		},
+		{
+			name:    "push rejected",
+			outcome: domain.ForkSyncOutcome{Branch: "main", CommandOutcome: domain.CommandOutcome{ExitCode: 1, FailureReason: "non-fast-forward"}},
+			want:    "Fork sync failed: non-fast-forward",
+			tone:    InfoToneError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			activity := domain.SyncingForkActivity{}
+			activity.MarkComplete(tt.outcome)
+			got := buildForkSyncCompletionInfoMessage(activity)
+			if !got.OK || got.Message.Text != tt.want || got.Message.Tone != tt.tone {
+				t.Fatalf("info = %+v, want %q tone %d", got, tt.want, tt.tone)
+			}
+		})
+	}
+}
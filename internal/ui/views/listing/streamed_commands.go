@@ -1,6 +1,7 @@
 package listing
 
 import (
+	"fresh/internal/config"
 	"fresh/internal/domain"
 	"fresh/internal/git"
 
@@ -15,8 +16,13 @@ type streamedWorkState[T any] struct {
 
 type pullWorkState = streamedWorkState[pullCompleteMsg]
 type pruneWorkState = streamedWorkState[pruneCompleteMsg]
+type checkoutWorkState = streamedWorkState[checkoutCompleteMsg]
+type branchCreateWorkState = streamedWorkState[branchCreateCompleteMsg]
+type rebaseWorkState = streamedWorkState[rebaseCompleteMsg]
+type forkSyncWorkState = streamedWorkState[forkSyncCompleteMsg]
 
 func startStreamedRepoCommand[R any, M any](
+	cfg *config.Config,
 	index int,
 	repoPath string,
 	execute func(lineCallback func(string)) R,
@@ -11,13 +11,17 @@ import (
 	"charm.land/lipgloss/v2/table"
 )
 
-func GenerateTable(repositories []domain.Repository, cursor int, layout ColumnLayout, runtime InfoRuntime) string {
+func GenerateTable(tableRows []TableRow, cursor int, layout ColumnLayout, runtime InfoRuntime) string {
 	headers := []string{"", "󰉋 Repo", " Branch", " Local", "󰓦 Remote", common.IconPullRequests + " PR", "", ""}
 
-	rows := make([][]string, len(repositories))
-	for i, repo := range repositories {
+	rows := make([][]string, len(tableRows))
+	for i, row := range tableRows {
 		isSelected := i == cursor
-		rows[i] = repositoryToRow(repo, isSelected, layout, runtime)
+		if row.Group != nil {
+			rows[i] = groupHeaderToRow(*row.Group, isSelected, layout, len(headers))
+			continue
+		}
+		rows[i] = repositoryToRow(row.Repo, isSelected, row.Marked, layout, runtime)
 	}
 
 	t := table.New().
@@ -35,8 +39,8 @@ func GenerateTable(repositories []domain.Repository, cursor int, layout ColumnLa
 	return t.Render()
 }
 
-func repositoryToRow(repo domain.Repository, isSelected bool, layout ColumnLayout, runtime InfoRuntime) []string {
-	selector := buildSelector(isSelected)
+func repositoryToRow(repo domain.Repository, isSelected bool, marked bool, layout ColumnLayout, runtime InfoRuntime) []string {
+	selector := buildSelector(isSelected, marked)
 	projectName := buildProjectName(repo.Name, isSelected, layout.ProjectWidth)
 	branchName := buildBranchName(repo.Branches.Current, layout.BranchWidth)
 	localCol := buildLocalStatus(repo.LocalState)
@@ -57,6 +61,24 @@ func repositoryToRow(repo domain.Repository, isSelected bool, layout ColumnLayou
 	}
 }
 
+func groupHeaderToRow(group GroupHeader, isSelected bool, layout ColumnLayout, columns int) []string {
+	icon := common.IconGroupExpanded
+	if group.Collapsed {
+		icon = common.IconGroupCollapsed
+	}
+
+	style := common.GroupHeaderStyle
+	if isSelected {
+		style = style.Foreground(common.TextPrimary)
+	}
+
+	label := fmt.Sprintf("%s %s (%d)", icon, group.Label, group.Count)
+	row := make([]string, columns)
+	row[0] = buildSelector(isSelected, false)
+	row[1] = common.RenderTruncatedText(label, layout.ProjectWidth, style)
+	return row
+}
+
 func buildPullRequestAlert(state domain.PullRequestState, runtime InfoRuntime) string {
 	baseStyle := lipgloss.NewStyle().
 		Width(PRAlertWidth).
@@ -104,12 +126,18 @@ func buildPullRequestAlert(state domain.PullRequestState, runtime InfoRuntime) s
 	return baseStyle.Render("")
 }
 
-func buildSelector(isSelected bool) string {
-	style := common.SelectorStyle.Width(SelectorWidth)
+// buildSelector renders the cursor in the first cell and the bulk-action mark
+// in the second, leaving the third blank as the baseline "▶ " did.
+func buildSelector(isSelected bool, marked bool) string {
+	cursor := " "
 	if isSelected {
-		return style.Render(common.IconSelector)
+		cursor = common.IconSelector
+	}
+	mark := " "
+	if marked {
+		mark = common.MarkedStyle.Render(common.IconMarked)
 	}
-	return style.Render(" ")
+	return common.SelectorStyle.Width(SelectorWidth).Render(cursor + mark)
 }
 
 func buildProjectName(name string, isSelected bool, width int) string {
@@ -226,15 +254,29 @@ func buildPullRequestStatus(state domain.PullRequestState, runtime InfoRuntime)
 
 	switch s := state.(type) {
 	case domain.PullRequestCount:
-		if s.Open <= 0 {
+		if s.Open <= 0 && s.ReviewRequested <= 0 {
 			return baseStyle.Render("")
 		}
-		if s.MyOpen > 0 {
-			count := lipgloss.NewStyle().Foreground(common.Blue).Render(fmt.Sprintf("%d", s.Open))
-			mine := lipgloss.NewStyle().Foreground(common.TextPrimary).Render("(*)")
-			return baseStyle.Render(count + mine)
+		var status strings.Builder
+		if s.Open > 0 {
+			open := fmt.Sprintf("%d", s.Open)
+			if s.Partial {
+				// A search limit was hit, so there may be more.
+				open += "+"
+			}
+			status.WriteString(lipgloss.NewStyle().Foreground(common.Blue).Render(open))
+			if s.MyOpen > 0 {
+				status.WriteString(lipgloss.NewStyle().Foreground(common.TextPrimary).Render("(*)"))
+			}
 		}
-		return baseStyle.Foreground(common.Blue).Render(fmt.Sprintf("%d", s.Open))
+		if s.ReviewRequested > 0 {
+			if status.Len() > 0 {
+				status.WriteString(" ")
+			}
+			badge := fmt.Sprintf("%s%d", common.IconReviewRequest, s.ReviewRequested)
+			status.WriteString(lipgloss.NewStyle().Foreground(common.Yellow).Render(badge))
+		}
+		return baseStyle.Render(status.String())
 	case domain.PullRequestError:
 		return baseStyle.Foreground(common.SubtleRed).Render(common.IconRemoteError)
 	default:
@@ -311,3 +353,14 @@ func buildMyPullRequestSummary(state domain.PullRequestState) (string, bool) {
 
 	return "My PRs: " + strings.Join(parts, ", "), hasPinned
 }
+
+func buildReviewRequestSummary(state domain.PullRequestState) string {
+	s, ok := state.(domain.PullRequestCount)
+	if !ok || s.ReviewRequested <= 0 {
+		return ""
+	}
+	if s.ReviewRequested == 1 {
+		return "1 review requested"
+	}
+	return fmt.Sprintf("%d reviews requested", s.ReviewRequested)
+}
@@ -21,26 +21,44 @@ func TestBuildSelector(t *testing.T) {
 	tests := []struct {
 		name       string
 		isSelected bool
-		want       string
+		marked     bool
+		want       []string
 	}{
 		{
 			name:       "selected shows selector icon",
 			isSelected: true,
-			want:       common.IconSelector,
+			want:       []string{common.IconSelector},
 		},
 		{
 			name:       "not selected shows space",
 			isSelected: false,
-			want:       " ",
+			want:       []string{" "},
+		},
+		{
+			name:       "marked shows mark icon",
+			isSelected: false,
+			marked:     true,
+			want:       []string{common.IconMarked},
+		},
+		{
+			name:       "selected and marked shows both",
+			isSelected: true,
+			marked:     true,
+			want:       []string{common.IconSelector, common.IconMarked},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got := buildSelector(tt.isSelected)
-			if !strings.Contains(got, tt.want) {
-				t.Errorf("buildSelector(%v) = %q, want it to contain %q", tt.isSelected, got, tt.want)
+			got := buildSelector(tt.isSelected, tt.marked)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("buildSelector(%v, %v) = %q, want it to contain %q", tt.isSelected, tt.marked, got, want)
+				}
+			}
+			if width := lipgloss.Width(got); width != SelectorWidth {
+				t.Errorf("buildSelector(%v, %v) width = %d, want %d", tt.isSelected, tt.marked, width, SelectorWidth)
 			}
 		})
 	}
@@ -315,6 +333,21 @@ func TestBuildPullRequestStatus(t *testing.T) {
 			state:    domain.PullRequestCount{Open: 2, MyOpen: 1},
 			contains: []string{"2", "(*)"},
 		},
+		{
+			name:     "partial count is marked with a plus",
+			state:    domain.PullRequestCount{Open: 1000, Partial: true},
+			contains: []string{"1000+"},
+		},
+		{
+			name:     "review requests show a badge",
+			state:    domain.PullRequestCount{Open: 4, MyOpen: 1, ReviewRequested: 2},
+			contains: []string{"4", "(*)", common.IconReviewRequest + "2"},
+		},
+		{
+			name:     "review requests show without an open count",
+			state:    domain.PullRequestCount{ReviewRequested: 1},
+			contains: []string{common.IconReviewRequest + "1"},
+		},
 		{
 			name:     "unavailable pull requests show dash",
 			state:    domain.PullRequestUnavailable{},
@@ -494,6 +527,26 @@ func TestBuildMyPullRequestSummary(t *testing.T) {
 	}
 }
 
+func TestBuildReviewRequestSummary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		state domain.PullRequestState
+		want  string
+	}{
+		{state: domain.PullRequestCount{Open: 3}, want: ""},
+		{state: domain.PullRequestCount{ReviewRequested: 1}, want: "1 review requested"},
+		{state: domain.PullRequestCount{ReviewRequested: 3}, want: "3 reviews requested"},
+		{state: domain.PullRequestUnavailable{}, want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := buildReviewRequestSummary(tt.state); got != tt.want {
+			t.Errorf("buildReviewRequestSummary(%+v) = %q, want %q", tt.state, got, tt.want)
+		}
+	}
+}
+
 func TestBuildMyPullRequestSummary_BlockedIsPinned(t *testing.T) {
 	t.Parallel()
 
@@ -700,7 +753,7 @@ func TestRepositoryToRow(t *testing.T) {
 		}).
 		Build()
 
-	row := repositoryToRow(repo, true, ColumnLayout{ProjectWidth: 30, BranchWidth: 20, InfoWidth: InfoWidth}, InfoRuntime{})
+	row := repositoryToRow(repo, true, false, ColumnLayout{ProjectWidth: 30, BranchWidth: 20, InfoWidth: InfoWidth}, InfoRuntime{})
 
 	if len(row) != 8 {
 		t.Fatalf("repositoryToRow returned %d columns, want 8", len(row))
@@ -750,7 +803,7 @@ func TestRepositoryToRow_NotSelected(t *testing.T) {
 		CurrentBranch(domain.OnBranch{Name: "develop"}).
 		Build()
 
-	row := repositoryToRow(repo, false, ColumnLayout{ProjectWidth: 30, BranchWidth: 20, InfoWidth: InfoWidth}, InfoRuntime{})
+	row := repositoryToRow(repo, false, false, ColumnLayout{ProjectWidth: 30, BranchWidth: 20, InfoWidth: InfoWidth}, InfoRuntime{})
 
 	// Selector should NOT have the icon
 	if strings.Contains(row[0], common.IconSelector) {
@@ -0,0 +1,183 @@
+package listing
+
+import (
+	"fmt"
+
+	"fresh/internal/ui/views/common"
+
+	"charm.land/lipgloss/v2"
+)
+
+const (
+	// headerHeight is the number of lines FormatHeader occupies above the table.
+	headerHeight = 2
+	// tableChromeHeight covers the hidden top border, header row, header
+	// separator and bottom border rendered around the table rows.
+	tableChromeHeight = 4
+	// footerSpacing covers the blank lines written between table, footer and legend.
+	footerSpacing = 2
+	// filterBarHeight is the single line the filter bar adds above the table.
+	filterBarHeight = 1
+	// emptyFilterHeight is the notice rendered when nothing matches the filter.
+	emptyFilterHeight = 1
+	minVisibleRows    = 1
+)
+
+// visibleRowCapacity returns how many repository rows fit on screen. A zero
+// height means the terminal size is unknown and every row is rendered.
+func (m *Model) visibleRowCapacity() int {
+	total := len(m.rows)
+	if m.height <= 0 {
+		return total
+	}
+
+	chrome := headerHeight + tableChromeHeight + footerSpacing +
+		lipgloss.Height(m.renderFooter(widestPositionIndicator(len(m.matched)))) +
+		lipgloss.Height(RenderLegend(m.ShowLegend))
+	if m.showFilterBar() {
+		chrome += filterBarHeight
+	}
+	if m.PromptingBranch || m.PendingSwitchAll {
+		chrome += filterBarHeight
+	}
+	if total == 0 {
+		chrome += emptyFilterHeight
+	}
+
+	capacity := m.height - chrome
+	if capacity < minVisibleRows {
+		capacity = minVisibleRows
+	}
+	if capacity > total {
+		capacity = total
+	}
+	return capacity
+}
+
+// visibleRange returns the half-open range of repository indexes to render.
+func (m *Model) visibleRange() (start, end int) {
+	total := len(m.rows)
+	capacity := m.visibleRowCapacity()
+	if capacity >= total {
+		return 0, total
+	}
+
+	start = clampScrollOffset(m.ScrollOffset, m.Cursor, capacity, total)
+	return start, start + capacity
+}
+
+// ensureCursorVisible adjusts ScrollOffset so the cursor stays inside the viewport.
+func (m *Model) ensureCursorVisible() {
+	total := len(m.rows)
+	capacity := m.visibleRowCapacity()
+	if capacity >= total {
+		m.ScrollOffset = 0
+		return
+	}
+
+	m.ScrollOffset = clampScrollOffset(m.ScrollOffset, m.Cursor, capacity, total)
+}
+
+func clampScrollOffset(offset, cursor, capacity, total int) int {
+	if cursor < offset {
+		offset = cursor
+	}
+	if cursor >= offset+capacity {
+		offset = cursor - capacity + 1
+	}
+	return common.Clamp(offset, 0, max(0, total-capacity))
+}
+
+// moveCursor moves the cursor by delta rows, passing over the headers of
+// expanded groups so every stop is a repository or a collapsed group.
+func (m *Model) moveCursor(delta int) {
+	if len(m.rows) == 0 {
+		m.Cursor = 0
+		return
+	}
+
+	m.Cursor = common.Clamp(m.Cursor+delta, 0, len(m.rows)-1)
+	step := 1
+	if delta < 0 {
+		step = -1
+	}
+	m.Cursor = m.nearestStop(m.Cursor, step)
+}
+
+// nearestStop returns the first row from row onwards in direction step the
+// cursor may rest on, looking the other way when there is none.
+func (m *Model) nearestStop(row, step int) int {
+	for i := row; i >= 0 && i < len(m.rows); i += step {
+		if m.isCursorStop(m.rows[i]) {
+			return i
+		}
+	}
+	for i := row; i >= 0 && i < len(m.rows); i -= step {
+		if m.isCursorStop(m.rows[i]) {
+			return i
+		}
+	}
+	return row
+}
+
+// isCursorStop reports whether the cursor may rest on row. An expanded
+// group's header is skipped since its repositories follow it; a collapsed
+// one is the only row left for its group.
+func (m *Model) isCursorStop(row displayRow) bool {
+	return !row.isGroupHeader() || m.CollapsedGroups[row.group]
+}
+
+func (m *Model) pageSize() int {
+	return max(1, m.visibleRowCapacity())
+}
+
+// buildPositionIndicator counts repositories rather than rows, so group
+// headers do not inflate it and collapsed groups still count their members.
+func (m *Model) buildPositionIndicator() string {
+	total := len(m.matched)
+	if total == 0 || len(m.rows) == 0 {
+		return ""
+	}
+
+	before := m.repositoriesBefore()
+	position := min(before[m.Cursor]+1, total)
+	start, end := m.visibleRange()
+	if start == 0 && end == len(m.rows) {
+		return fmt.Sprintf("%d/%d", position, total)
+	}
+	return formatScrolledPosition(position, total, min(before[start]+1, total), before[end])
+}
+
+// repositoriesBefore returns, for each row and one past the last, how many
+// repositories the rows above it stand for: one per repository row and the
+// member count of each collapsed group.
+func (m *Model) repositoriesBefore() []int {
+	collapsed := make(map[string]int)
+	if m.GroupMode != GroupNone {
+		for _, index := range m.matched {
+			if key := m.groupKey(m.Repositories[index]); m.CollapsedGroups[key] {
+				collapsed[key]++
+			}
+		}
+	}
+
+	before := make([]int, len(m.rows)+1)
+	for i, row := range m.rows {
+		count := 1
+		if row.isGroupHeader() {
+			count = collapsed[row.group]
+		}
+		before[i+1] = before[i] + count
+	}
+	return before
+}
+
+// widestPositionIndicator is used when measuring the footer so the viewport
+// height does not depend on the indicator it is about to render.
+func widestPositionIndicator(total int) string {
+	return formatScrolledPosition(total, total, total, total)
+}
+
+func formatScrolledPosition(position, total, first, last int) string {
+	return fmt.Sprintf("%d/%d (rows %d-%d)", position, total, first, last)
+}
@@ -0,0 +1,157 @@
+package listing
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"fresh/internal/domain"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+func makeManyTestRepositories(count int) []domain.Repository {
+	repos := make([]domain.Repository, count)
+	for i := range repos {
+		repos[i] = newTestRepository(fmt.Sprintf("repo-%03d", i)).
+			PullRequests(domain.PullRequestUnavailable{}).
+			Build()
+	}
+	return repos
+}
+
+func TestViewFitsTerminalHeightWithManyRepositories(t *testing.T) {
+	m := New(makeManyTestRepositories(150))
+	m.SetSize(160, 30)
+
+	view := m.View()
+	if got := lipgloss.Height(view); got > 30 {
+		t.Fatalf("view height = %d, want <= 30", got)
+	}
+	if !strings.Contains(view, "repo-000") {
+		t.Fatal("expected first repository to be visible")
+	}
+	if strings.Contains(view, "repo-149") {
+		t.Fatal("did not expect last repository to be visible before scrolling")
+	}
+}
+
+func TestCursorMovementScrollsViewport(t *testing.T) {
+	m := New(makeManyTestRepositories(150))
+	m.SetSize(160, 30)
+	capacity := m.visibleRowCapacity()
+
+	for i := 0; i < capacity; i++ {
+		m.Update(tea.KeyPressMsg{Code: 'j'})
+	}
+
+	if m.Cursor != capacity {
+		t.Fatalf("cursor = %d, want %d", m.Cursor, capacity)
+	}
+	if m.ScrollOffset != 1 {
+		t.Fatalf("scroll offset = %d, want 1", m.ScrollOffset)
+	}
+	if !strings.Contains(m.View(), fmt.Sprintf("repo-%03d", capacity)) {
+		t.Fatal("expected cursor row to be visible after scrolling")
+	}
+}
+
+func TestPageAndJumpNavigation(t *testing.T) {
+	m := New(makeManyTestRepositories(150))
+	m.SetSize(160, 30)
+	page := m.pageSize()
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyPgDown})
+	if m.Cursor != page {
+		t.Fatalf("cursor after pgdown = %d, want %d", m.Cursor, page)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnd})
+	if m.Cursor != 149 {
+		t.Fatalf("cursor after end = %d, want 149", m.Cursor)
+	}
+	if start, end := m.visibleRange(); end != 150 || start != 150-m.visibleRowCapacity() {
+		t.Fatalf("visible range = %d-%d, want range ending at 150", start, end)
+	}
+	if !strings.Contains(m.View(), "repo-149") {
+		t.Fatal("expected last repository to be visible after end")
+	}
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyPgUp})
+	if m.Cursor != 149-page {
+		t.Fatalf("cursor after pgup = %d, want %d", m.Cursor, 149-page)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyHome})
+	if m.Cursor != 0 || m.ScrollOffset != 0 {
+		t.Fatalf("cursor/offset after home = %d/%d, want 0/0", m.Cursor, m.ScrollOffset)
+	}
+}
+
+func TestFooterShowsPositionIndicator(t *testing.T) {
+	m := New(makeManyTestRepositories(150))
+	m.SetSize(400, 30)
+
+	if footer := m.buildFooter(); !strings.Contains(footer, "1/150") {
+		t.Fatalf("footer = %q, want position indicator", footer)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnd})
+	if footer := m.buildFooter(); !strings.Contains(footer, "150/150") {
+		t.Fatalf("footer = %q, want position indicator at end", footer)
+	}
+}
+
+func TestVisibleRowCapacityWithoutHeightRendersEverything(t *testing.T) {
+	m := New(makeManyTestRepositories(20))
+
+	if got := m.visibleRowCapacity(); got != 20 {
+		t.Fatalf("visibleRowCapacity() = %d, want 20", got)
+	}
+}
+
+func TestGroupedNavigationSkipsExpandedHeaders(t *testing.T) {
+	m := New([]domain.Repository{
+		newTestRepository("dotfiles").Path("/src/home/dotfiles").Build(),
+		newTestRepository("api").Path("/src/work/api").Build(),
+		newTestRepository("web").Path("/src/work/web").Build(),
+	})
+	m.SetSize(400, 40)
+	m.Update(tea.KeyPressMsg{Code: 'v', Text: "v"})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyHome})
+
+	if repo, ok := m.selectedRepository(); !ok || repo.Name != "dotfiles" {
+		t.Fatal("expected home to land on the first repository, not its header")
+	}
+	if footer := m.buildFooter(); !strings.Contains(footer, "1/3") {
+		t.Fatalf("footer = %q, want 1/3 counting repositories only", footer)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	if repo, ok := m.selectedRepository(); !ok || repo.Name != "api" {
+		t.Fatal("expected down to skip the /src/work header")
+	}
+	if footer := m.buildFooter(); !strings.Contains(footer, "2/3") {
+		t.Fatalf("footer = %q, want 2/3", footer)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if row, ok := m.selectedRow(); !ok || !row.isGroupHeader() || row.group != "/src/work" {
+		t.Fatal("expected tab to collapse the group and select its header")
+	}
+	if footer := m.buildFooter(); !strings.Contains(footer, "2/3") {
+		t.Fatalf("footer = %q, want the collapsed group to count its members", footer)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnd})
+	if row, ok := m.selectedRow(); !ok || !row.isGroupHeader() {
+		t.Fatal("expected end to stop on the collapsed header")
+	}
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if repo, ok := m.selectedRepository(); !ok || repo.Name != "api" {
+		t.Fatal("expected expanding the group to select its first repository")
+	}
+}
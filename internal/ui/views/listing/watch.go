@@ -1,6 +1,7 @@
 package listing
 
 import (
+	"fmt"
 	"time"
 
 	"fresh/internal/domain"
@@ -53,7 +54,7 @@ func (m *Model) startRefreshCycle(trigger PullRequestSyncTrigger) tea.Cmd {
 			continue
 		}
 		repo.Activity = &domain.RefreshingActivity{Spinner: common.NewRefreshSpinner()}
-		cmds = append(cmds, performRefresh(i, repo.Path))
+		cmds = append(cmds, performRefresh(m.Config, i, repo.Path))
 		cmds = append(cmds, repo.Activity.(*domain.RefreshingActivity).Spinner.Tick)
 	}
 
@@ -61,6 +62,10 @@ func (m *Model) startRefreshCycle(trigger PullRequestSyncTrigger) tea.Cmd {
 }
 
 func (m *Model) currentWatchInterval() time.Duration {
+	return m.stretchForRateLimit(m.backoffWatchInterval(), time.Now())
+}
+
+func (m *Model) backoffWatchInterval() time.Duration {
 	base, max := m.watchIntervals()
 	interval := base
 
@@ -78,6 +83,42 @@ func (m *Model) currentWatchInterval() time.Duration {
 	return interval
 }
 
+// stretchForRateLimit waits for the API budget to reset, up to the
+// maximum interval, once the last sync reported it running low.
+func (m *Model) stretchForRateLimit(interval time.Duration, now time.Time) time.Duration {
+	if !m.PRRateLimit.Low() {
+		return interval
+	}
+	_, max := m.watchIntervals()
+	untilReset := m.PRRateLimit.ResetAt.Sub(now)
+	if untilReset <= interval {
+		return interval
+	}
+	// Whole minutes keep the footer steady while the reset approaches.
+	untilReset = untilReset.Truncate(time.Minute) + time.Minute
+	if untilReset > max {
+		return max
+	}
+	return untilReset
+}
+
+// buildRateLimitStatus shows the API budget the last sync reported, with
+// the time it resets once it runs low.
+func (m *Model) buildRateLimitStatus() string {
+	budget := m.PRRateLimit
+	if !budget.Known() {
+		return ""
+	}
+	status := fmt.Sprintf("%s API %d/%d", budget.Host, budget.Remaining, budget.Limit)
+	if !budget.Low() {
+		return status
+	}
+	if !budget.ResetAt.IsZero() {
+		status += " until " + budget.ResetAt.Local().Format("15:04")
+	}
+	return common.PullOutputWarn.Render(status)
+}
+
 func (m *Model) watchIntervals() (time.Duration, time.Duration) {
 	base := m.WatchEvery
 	if base <= 0 {
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"fresh/internal/domain"
+	"fresh/internal/git"
 )
 
 func TestToggleWatchModeTurnsOnAndSchedulesTick(t *testing.T) {
@@ -230,3 +231,71 @@ func TestStaleWatchSyncStillReschedulesTick(t *testing.T) {
 		t.Fatalf("WatchBackoff = %d, want 2", m.WatchBackoff)
 	}
 }
+
+func TestStretchForRateLimitWaitsForReset(t *testing.T) {
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		budget git.RateLimit
+		want   time.Duration
+	}{
+		{
+			name:   "unknown budget",
+			budget: git.RateLimit{},
+			want:   time.Minute,
+		},
+		{
+			name:   "plenty left",
+			budget: git.RateLimit{Limit: 5000, Remaining: 4000, ResetAt: now.Add(30 * time.Minute)},
+			want:   time.Minute,
+		},
+		{
+			name:   "low until reset",
+			budget: git.RateLimit{Limit: 5000, Remaining: 100, ResetAt: now.Add(3*time.Minute + 20*time.Second)},
+			want:   4 * time.Minute,
+		},
+		{
+			name:   "low with reset past the maximum",
+			budget: git.RateLimit{Limit: 5000, Remaining: 0, ResetAt: now.Add(time.Hour)},
+			want:   8 * time.Minute,
+		},
+		{
+			name:   "low but resetting before the next tick",
+			budget: git.RateLimit{Limit: 30, Remaining: 1, ResetAt: now.Add(20 * time.Second)},
+			want:   time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(nil)
+			m.WatchEvery = time.Minute
+			m.WatchMaxEvery = 8 * time.Minute
+			m.PRRateLimit = tt.budget
+			if got := m.stretchForRateLimit(m.backoffWatchInterval(), now); got != tt.want {
+				t.Errorf("stretchForRateLimit() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildFooterShowsRateLimit(t *testing.T) {
+	m := New(nil)
+	if footer := m.buildFooter(); strings.Contains(footer, "API") {
+		t.Fatalf("footer = %q, want no budget before a sync reports one", footer)
+	}
+
+	m.Update(PullRequestStatesUpdatedMsg{
+		Generation: m.PRSyncGeneration,
+		RateLimit:  git.RateLimit{Host: "github.com", Limit: 5000, Remaining: 4210},
+	})
+	if footer := m.buildFooter(); !strings.Contains(footer, "github.com API 4210/5000") {
+		t.Fatalf("footer = %q, want the remaining budget", footer)
+	}
+
+	resetAt := time.Now().Add(10 * time.Minute)
+	m.PRRateLimit = git.RateLimit{Host: "github.com", Limit: 5000, Remaining: 12, ResetAt: resetAt}
+	if footer := m.buildFooter(); !strings.Contains(footer, "until "+resetAt.Local().Format("15:04")) {
+		t.Fatalf("footer = %q, want the reset time of a low budget", footer)
+	}
+}
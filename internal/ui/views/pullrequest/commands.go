@@ -0,0 +1,56 @@
+package pullrequest
+
+import (
+	"errors"
+
+	"fresh/internal/browser"
+	"fresh/internal/config"
+	"fresh/internal/domain"
+	"fresh/internal/git"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func performPullRequestLoad(providers *git.ProviderRegistry, repo domain.Repository, number int) tea.Cmd {
+	return func() tea.Msg {
+		pullRequest, err := providers.GetRepositoryPullRequest(repo, number)
+		msg := PullRequestLoadedMsg{
+			RepoPath:    repo.Path,
+			Number:      number,
+			PullRequest: pullRequest,
+		}
+		if err != nil {
+			msg.Error = err.Error()
+			msg.Unsupported = errors.Is(err, git.ErrPullRequestDetailsUnsupported)
+		}
+		return msg
+	}
+}
+
+// openPullRequestInBrowser opens the page the provider reported for the
+// pull request, or the one derived from the remote before it has loaded.
+func openPullRequestInBrowser(cfg *config.Config, providers *git.ProviderRegistry, repo domain.Repository, pullRequest domain.PullRequest, number int) tea.Cmd {
+	return func() tea.Msg {
+		url := pullRequest.URL
+		var err error
+		if url == "" {
+			url, err = providers.PullRequestWebURL(repo, number)
+		}
+		if err == nil {
+			err = browser.Open(cfg.Browser, url)
+		}
+		return browserOpenedMsg{Err: err}
+	}
+}
+
+func openURL(cfg *config.Config, url string) tea.Cmd {
+	return func() tea.Msg {
+		return browserOpenedMsg{Err: browser.Open(cfg.Browser, url)}
+	}
+}
+
+func backToPullRequests() tea.Cmd {
+	return func() tea.Msg {
+		return BackToPullRequestsMsg{}
+	}
+}
@@ -0,0 +1,17 @@
+package pullrequest
+
+import "fresh/internal/domain"
+
+type BackToPullRequestsMsg struct{}
+
+type PullRequestLoadedMsg struct {
+	RepoPath    string
+	Number      int
+	PullRequest domain.PullRequest
+	Unsupported bool
+	Error       string
+}
+
+type browserOpenedMsg struct {
+	Err error
+}
@@ -0,0 +1,219 @@
+package pullrequest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fresh/internal/browser"
+	"fresh/internal/config"
+	"fresh/internal/domain"
+	"fresh/internal/git"
+	"fresh/internal/ui/views/common"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/spinner"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+type pullRequestKeyMap struct {
+	openWeb      key.Binding
+	openCheckLog key.Binding
+	refresh      key.Binding
+	back         key.Binding
+}
+
+func newPullRequestKeyMap() *pullRequestKeyMap {
+	return &pullRequestKeyMap{
+		openWeb: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open in browser"),
+		),
+		openCheckLog: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "open failing check log"),
+		),
+		refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh pull request"),
+		),
+		back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back to pull requests"),
+		),
+	}
+}
+
+// chromeHeight covers the title above and the footer below the scrollable body.
+const chromeHeight = 6
+
+type Model struct {
+	Repo        domain.Repository
+	Number      int
+	PullRequest domain.PullRequest
+	Keys        *pullRequestKeyMap
+	Loading     bool
+	Loaded      bool
+	Unsupported bool
+	LoadError   string
+	// BrowserError is why the last page could not be opened in the browser.
+	BrowserError string
+	Spinner      spinner.Model
+	// Providers fetches the pull request.
+	Providers *git.ProviderRegistry
+	// Config names the browser pages open in.
+	Config        *config.Config
+	body          viewport.Model
+	width, height int
+}
+
+func New(repo domain.Repository, number int) *Model {
+	return &Model{
+		Repo:      repo,
+		Number:    number,
+		Keys:      newPullRequestKeyMap(),
+		Loading:   true,
+		Spinner:   common.NewPullRequestSpinner(),
+		Providers: git.DefaultProviders(config.DefaultConfig()),
+		Config:    config.DefaultConfig(),
+		body:      viewport.New(),
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.body.SetWidth(width)
+	m.body.SetHeight(max(1, height-chromeHeight))
+	m.refreshBody()
+}
+
+func (m *Model) Init() tea.Cmd {
+	return tea.Batch(performPullRequestLoad(m.Providers, m.Repo, m.Number), m.Spinner.Tick)
+}
+
+func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, m.Keys.back) || msg.Code == 27:
+			return m, backToPullRequests()
+		case key.Matches(msg, m.Keys.openWeb):
+			m.BrowserError = ""
+			return m, openPullRequestInBrowser(m.Config, m.Providers, m.Repo, m.PullRequest, m.Number)
+		case key.Matches(msg, m.Keys.openCheckLog):
+			m.BrowserError = ""
+			check, ok := failingCheck(m.PullRequest.Checks)
+			if !ok {
+				m.BrowserError = "no failing check links to a log"
+				return m, nil
+			}
+			return m, openURL(m.Config, check.URL)
+		case key.Matches(msg, m.Keys.refresh):
+			m.Loading = true
+			m.LoadError = ""
+			return m, tea.Batch(performPullRequestLoad(m.Providers, m.Repo, m.Number), m.Spinner.Tick)
+		}
+
+		var cmd tea.Cmd
+		m.body, cmd = m.body.Update(msg)
+		return m, cmd
+
+	case PullRequestLoadedMsg:
+		if msg.RepoPath != m.Repo.Path || msg.Number != m.Number {
+			return m, nil
+		}
+		m.Loading = false
+		m.Unsupported = msg.Unsupported
+		m.LoadError = msg.Error
+		if msg.Error == "" {
+			m.PullRequest = msg.PullRequest
+			m.Loaded = true
+		}
+		m.refreshBody()
+
+	case browserOpenedMsg:
+		m.BrowserError = ""
+		if msg.Err != nil {
+			m.BrowserError = msg.Err.Error()
+		}
+
+	case spinner.TickMsg:
+		if m.Loading {
+			var cmd tea.Cmd
+			m.Spinner, cmd = m.Spinner.Update(msg)
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Model) refreshBody() {
+	m.body.SetContent(renderPullRequest(m.PullRequest, time.Now()))
+}
+
+func (m *Model) View() string {
+	var s strings.Builder
+	title := fmt.Sprintf("\nPull request #%d in %s\n", m.Number, m.Repo.Name)
+	s.WriteString(common.HeaderStyle.Render(title))
+	s.WriteString("\n")
+
+	switch {
+	case m.Unsupported:
+		s.WriteString(lipgloss.NewStyle().Foreground(common.SubtleGray).Render(m.LoadError))
+	case !m.Loaded && m.Loading:
+		s.WriteString(common.TextGrey.Render(m.Spinner.View() + " Loading pull request..."))
+	case !m.Loaded && m.LoadError != "":
+		s.WriteString(lipgloss.NewStyle().Foreground(common.SubtleRed).Render("Failed to load pull request: " + m.LoadError))
+	case m.height > 0:
+		s.WriteString(m.body.View())
+	default:
+		s.WriteString(renderPullRequest(m.PullRequest, time.Now()))
+	}
+
+	if m.Loaded && m.LoadError != "" {
+		s.WriteString("\n")
+		s.WriteString(lipgloss.NewStyle().Foreground(common.SubtleRed).Render("Refresh error: " + m.LoadError))
+	}
+	if m.BrowserError != "" {
+		s.WriteString("\n")
+		s.WriteString(lipgloss.NewStyle().Foreground(common.SubtleRed).Render("Open in browser failed: " + m.BrowserError))
+	}
+
+	s.WriteString("\n\n")
+	s.WriteString(m.buildFooter())
+	return s.String()
+}
+
+func (m *Model) buildFooter() string {
+	hotkeys := []string{
+		"↑/↓ scroll",
+		"o open in browser",
+		"f open failing check",
+		"r refresh",
+		"esc back",
+		"q quit",
+	}
+	if m.Loaded && m.height > 0 && m.body.TotalLineCount() > m.body.VisibleLineCount() {
+		hotkeys = append([]string{fmt.Sprintf("%d%%", int(m.body.ScrollPercent()*100))}, hotkeys...)
+	}
+	return common.FooterStyle.Render(strings.Join(hotkeys, "  •  "))
+}
+
+// failingCheck returns the first failed check that links to its log on the
+// web. Links are set by CI, so ones that are not web pages are skipped.
+func failingCheck(checks []domain.CheckRun) (domain.CheckRun, bool) {
+	for _, check := range checks {
+		if check.Status == domain.CheckFailed && browser.CheckURL(check.URL) == nil {
+			return check, true
+		}
+	}
+	return domain.CheckRun{}, false
+}
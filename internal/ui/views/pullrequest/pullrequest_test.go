@@ -0,0 +1,144 @@
+package pullrequest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"fresh/internal/domain"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestUpdate_PullRequestLoadedMsgRendersSections(t *testing.T) {
+	t.Parallel()
+
+	repo := domain.Repository{Name: "demo", Path: "/tmp/demo", RemoteURL: "https://github.com/octo/demo"}
+	m := New(repo, 7)
+	started := time.Now().Add(-time.Hour)
+
+	m.Update(PullRequestLoadedMsg{
+		RepoPath: repo.Path,
+		Number:   7,
+		PullRequest: domain.PullRequest{
+			Number:     7,
+			Title:      "Add retries",
+			URL:        "https://github.com/octo/demo/pull/7",
+			Author:     "octocat",
+			HeadBranch: "retries",
+			BaseBranch: "main",
+			Mergeable:  domain.MergeableConflicting,
+			Additions:  120,
+			Deletions:  8,
+			Labels:     []string{"bug", "backend"},
+			Checks: []domain.CheckRun{
+				{Name: "test", Status: domain.CheckFailed, StartedAt: started, CompletedAt: started.Add(90 * time.Second), URL: "https://ci.example.com/1"},
+			},
+			Reviewers: []domain.Reviewer{{Login: "alice", State: domain.ReviewChangesRequested}},
+		},
+	})
+
+	if m.Loading || !m.Loaded {
+		t.Fatal("expected the pull request to be loaded")
+	}
+
+	view := m.View()
+	for _, want := range []string{"#7", "Add retries", "octocat", "retries", "main", "conflicts", "+120", "-8", "bug, backend", "test", "1m 30s", "https://ci.example.com/1", "alice", "changes requested"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
+
+func TestUpdate_IgnoresOtherPullRequests(t *testing.T) {
+	t.Parallel()
+
+	m := New(domain.Repository{Name: "demo", Path: "/tmp/demo"}, 7)
+	m.Update(PullRequestLoadedMsg{RepoPath: "/tmp/demo", Number: 8})
+	m.Update(PullRequestLoadedMsg{RepoPath: "/tmp/other", Number: 7})
+
+	if !m.Loading || m.Loaded {
+		t.Fatal("expected other pull requests to be ignored")
+	}
+}
+
+func TestUpdate_RefreshErrorKeepsLoadedPullRequest(t *testing.T) {
+	t.Parallel()
+
+	m := New(domain.Repository{Name: "demo", Path: "/tmp/demo"}, 7)
+	m.Update(PullRequestLoadedMsg{RepoPath: "/tmp/demo", Number: 7, PullRequest: domain.PullRequest{Number: 7, Title: "Add retries"}})
+	m.Update(PullRequestLoadedMsg{RepoPath: "/tmp/demo", Number: 7, Error: "timeout"})
+
+	view := m.View()
+	if !strings.Contains(view, "Add retries") || !strings.Contains(view, "Refresh error: timeout") {
+		t.Errorf("view = %q, want the loaded pull request and the refresh error", view)
+	}
+}
+
+func TestUpdate_EscapeReturnsToPullRequests(t *testing.T) {
+	t.Parallel()
+
+	m := New(domain.Repository{Name: "demo", Path: "/tmp/demo"}, 7)
+	_, cmd := m.Update(tea.KeyPressMsg{Code: 27})
+	if cmd == nil {
+		t.Fatal("expected back command on escape")
+	}
+	if _, ok := cmd().(BackToPullRequestsMsg); !ok {
+		t.Fatal("expected BackToPullRequestsMsg")
+	}
+}
+
+func TestUpdate_OpenFailingCheckWithoutOneReportsIt(t *testing.T) {
+	t.Parallel()
+
+	m := New(domain.Repository{Name: "demo", Path: "/tmp/demo"}, 7)
+	m.Update(PullRequestLoadedMsg{RepoPath: "/tmp/demo", Number: 7, PullRequest: domain.PullRequest{
+		Number: 7,
+		Checks: []domain.CheckRun{{Name: "test", Status: domain.CheckPassed, URL: "https://ci.example.com/1"}},
+	}})
+
+	if _, cmd := m.Update(tea.KeyPressMsg{Code: 'f', Text: "f"}); cmd != nil {
+		t.Fatal("expected no command without a failing check")
+	}
+	if !strings.Contains(m.View(), "no failing check links to a log") {
+		t.Errorf("view = %q, want the missing failing check reported", m.View())
+	}
+}
+
+func TestFailingCheck(t *testing.T) {
+	t.Parallel()
+
+	checks := []domain.CheckRun{
+		{Name: "lint", Status: domain.CheckFailed},
+		{Name: "scan", Status: domain.CheckFailed, URL: "file:///home/ci/scan.log"},
+		{Name: "test", Status: domain.CheckPassed, URL: "https://ci.example.com/1"},
+		{Name: "deploy", Status: domain.CheckFailed, URL: "https://ci.example.com/2"},
+	}
+	check, ok := failingCheck(checks)
+	if !ok || check.Name != "deploy" {
+		t.Fatalf("failingCheck() = %+v, %v, want deploy, the first failure with a log", check, ok)
+	}
+	if _, ok := failingCheck(checks[:3]); ok {
+		t.Fatal("failingCheck() found a failure without a web log link")
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		duration time.Duration
+		want     string
+	}{
+		{duration: 42 * time.Second, want: "42s"},
+		{duration: 59600 * time.Millisecond, want: "1m 00s"},
+		{duration: 3*time.Minute + 5*time.Second, want: "3m 05s"},
+		{duration: 2*time.Hour + 7*time.Minute + 40*time.Second, want: "2h 08m"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDuration(tt.duration); got != tt.want {
+			t.Errorf("formatDuration(%v) = %q, want %q", tt.duration, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package pullrequest
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fresh/internal/domain"
+	"fresh/internal/ui/views/common"
+
+	"charm.land/lipgloss/v2"
+)
+
+var (
+	sectionStyle = common.TableHeaderStyle.PaddingTop(1)
+	labelStyle   = lipgloss.NewStyle().Foreground(common.TextSecondary).Width(12)
+	valueStyle   = lipgloss.NewStyle().Foreground(common.TextPrimary)
+	branchStyle  = lipgloss.NewStyle().Foreground(common.TextBranch)
+	checkStyle   = lipgloss.NewStyle().Width(10)
+	reviewStyle  = lipgloss.NewStyle().Width(19)
+)
+
+func renderPullRequest(pr domain.PullRequest, now time.Time) string {
+	var lines []string
+	add := func(line string) { lines = append(lines, "  "+line) }
+	section := func(title string, count int) {
+		lines = append(lines, sectionStyle.Render(fmt.Sprintf(" %s (%d)", title, count)))
+	}
+	empty := func(text string) { add(common.TextGrey.Render(text)) }
+
+	lines = append(lines, sectionStyle.Render(" Overview"))
+	add(labelStyle.Render("Title") + valueStyle.Render(pr.Title))
+	add(labelStyle.Render("Author") + valueOrDash(pr.Author))
+	add(labelStyle.Render("Branches") + branchStyle.Render(pr.HeadBranch) + common.TextGrey.Render(" → ") + branchStyle.Render(pr.BaseBranch))
+	add(labelStyle.Render("Mergeable") + renderMergeable(pr))
+	add(labelStyle.Render("Changes") + common.TextGreen.Render(fmt.Sprintf("+%d", pr.Additions)) + " " + common.LocalStatusUntrackedItem.Render(fmt.Sprintf("-%d", pr.Deletions)))
+	labels := common.TextGrey.Render("-")
+	if len(pr.Labels) > 0 {
+		labels = common.TextBlue.Render(strings.Join(pr.Labels, ", "))
+	}
+	add(labelStyle.Render("Labels") + labels)
+	add(labelStyle.Render("URL") + valueOrDash(pr.URL))
+
+	section("Checks", len(pr.Checks))
+	if len(pr.Checks) == 0 {
+		empty("No checks reported")
+	}
+	for _, check := range pr.Checks {
+		line := renderCheckStatus(check.Status) + valueStyle.Render(check.Name)
+		if duration := check.Duration(now); duration > 0 {
+			line += common.TextGrey.Render("  " + formatDuration(duration))
+		}
+		if check.URL != "" {
+			line += common.TextGrey.Render("  " + check.URL)
+		}
+		add(line)
+	}
+
+	section("Reviewers", len(pr.Reviewers))
+	if len(pr.Reviewers) == 0 {
+		empty("No reviews or review requests")
+	}
+	for _, reviewer := range pr.Reviewers {
+		add(renderReviewState(reviewer.State) + valueStyle.Render(reviewer.Login))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func renderMergeable(pr domain.PullRequest) string {
+	var state string
+	switch pr.Mergeable {
+	case domain.MergeableClean:
+		state = common.TextGreen.Render("no conflicts")
+	case domain.MergeableConflicting:
+		state = common.LocalStatusUntrackedItem.Render("conflicts")
+	default:
+		state = common.TextGrey.Render("unknown")
+	}
+	if pr.Draft {
+		state += common.TextGrey.Render("  draft")
+	}
+	return state
+}
+
+func renderCheckStatus(status domain.CheckStatus) string {
+	style := common.TextGrey
+	switch status {
+	case domain.CheckPassed:
+		style = common.TextGreen
+	case domain.CheckFailed:
+		style = common.LocalStatusUntrackedItem
+	case domain.CheckRunning:
+		style = common.LocalStatusDirtyItem
+	}
+	return checkStyle.Inherit(style).Render(string(status))
+}
+
+func renderReviewState(state domain.ReviewState) string {
+	style := common.TextGrey
+	label := string(state)
+	switch state {
+	case domain.ReviewApproved:
+		style = common.TextGreen
+	case domain.ReviewChangesRequested:
+		style = common.LocalStatusUntrackedItem
+		label = "changes requested"
+	case domain.ReviewCommented:
+		style = common.TextBlue
+	case domain.ReviewPending:
+		style = common.LocalStatusDirtyItem
+	}
+	return reviewStyle.Inherit(style).Render(label)
+}
+
+// formatDuration rounds d to seconds under an hour and to minutes above.
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm %02ds", int(d.Minutes()), int(d.Seconds())%60)
+	default:
+		d = d.Round(time.Minute)
+		return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return common.TextGrey.Render("-")
+	}
+	return valueStyle.Render(value)
+}
@@ -3,15 +3,17 @@ package pullrequests
 import (
 	"errors"
 
+	"fresh/internal/browser"
+	"fresh/internal/config"
 	"fresh/internal/domain"
 	"fresh/internal/git"
 
 	tea "charm.land/bubbletea/v2"
 )
 
-func performPullRequestLoad(repo domain.Repository) tea.Cmd {
+func performPullRequestLoad(providers *git.ProviderRegistry, repo domain.Repository) tea.Cmd {
 	return func() tea.Msg {
-		rows, err := git.GetRepositoryPullRequests(repo)
+		rows, err := providers.GetRepositoryPullRequests(repo)
 		msg := PullRequestsLoadedMsg{
 			RepoPath:     repo.Path,
 			PullRequests: rows,
@@ -24,6 +26,22 @@ func performPullRequestLoad(repo domain.Repository) tea.Cmd {
 	}
 }
 
+func openPullRequest(repo domain.Repository, number int) tea.Cmd {
+	return func() tea.Msg {
+		return OpenPullRequestMsg{Repo: repo, Number: number}
+	}
+}
+
+func openPullRequestInBrowser(cfg *config.Config, providers *git.ProviderRegistry, repo domain.Repository, number int) tea.Cmd {
+	return func() tea.Msg {
+		url, err := providers.PullRequestWebURL(repo, number)
+		if err == nil {
+			err = browser.Open(cfg.Browser, url)
+		}
+		return browserOpenedMsg{Err: err}
+	}
+}
+
 func backToRepoList() tea.Cmd {
 	return func() tea.Msg {
 		return BackToRepoListMsg{}
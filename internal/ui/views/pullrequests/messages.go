@@ -10,6 +10,12 @@ import (
 
 type BackToRepoListMsg struct{}
 
+// OpenPullRequestMsg asks to show pull request Number of Repo in full.
+type OpenPullRequestMsg struct {
+	Repo   domain.Repository
+	Number int
+}
+
 type PullRequestsLoadedMsg struct {
 	RepoPath     string
 	PullRequests []domain.PullRequestDetails
@@ -17,6 +23,10 @@ type PullRequestsLoadedMsg struct {
 	Error        string
 }
 
+type browserOpenedMsg struct {
+	Err error
+}
+
 type pulseTickMsg struct{}
 
 func schedulePulseTick(interval time.Duration) tea.Cmd {
@@ -5,7 +5,9 @@ import (
 	"strings"
 	"time"
 
+	"fresh/internal/config"
 	"fresh/internal/domain"
+	"fresh/internal/git"
 	"fresh/internal/ui/views/common"
 
 	"charm.land/bubbles/v2/key"
@@ -15,12 +17,22 @@ import (
 )
 
 type prListKeyMap struct {
+	open    key.Binding
+	openWeb key.Binding
 	refresh key.Binding
 	back    key.Binding
 }
 
 func newPRListKeyMap() *prListKeyMap {
 	return &prListKeyMap{
+		open: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "open pull request"),
+		),
+		openWeb: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open in browser"),
+		),
 		refresh: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh pull requests"),
@@ -42,9 +54,16 @@ type Model struct {
 	Loading       bool
 	Unsupported   bool
 	LoadError     string
-	PulseOn       bool
-	PulseEvery    time.Duration
-	Spinner       spinner.Model
+	// BrowserError is why the last pull request could not be opened in the
+	// browser.
+	BrowserError string
+	PulseOn      bool
+	PulseEvery   time.Duration
+	Spinner      spinner.Model
+	// Providers lists the pull requests of Repo.
+	Providers *git.ProviderRegistry
+	// Config names the browser pull requests open in.
+	Config *config.Config
 }
 
 func New(repo domain.Repository, cached []domain.PullRequestDetails) *Model {
@@ -62,6 +81,8 @@ func New(repo domain.Repository, cached []domain.PullRequestDetails) *Model {
 		PulseOn:      false,
 		PulseEvery:   350 * time.Millisecond,
 		Spinner:      common.NewPullRequestSpinner(),
+		Providers:    git.DefaultProviders(config.DefaultConfig()),
+		Config:       config.DefaultConfig(),
 	}
 }
 
@@ -74,7 +95,7 @@ func (m *Model) SetSize(width, height int) {
 func (m *Model) Init() tea.Cmd {
 	return tea.Batch(
 		schedulePulseTick(m.PulseEvery),
-		performPullRequestLoad(m.Repo),
+		performPullRequestLoad(m.Providers, m.Repo),
 		m.Spinner.Tick,
 	)
 }
@@ -89,11 +110,20 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 		switch {
 		case key.Matches(msg, m.Keys.back) || msg.String() == "esc" || msg.Code == 27:
 			return m, backToRepoList()
+		case key.Matches(msg, m.Keys.open):
+			if m.Cursor < len(m.PullRequests) {
+				return m, openPullRequest(m.Repo, m.PullRequests[m.Cursor].Number)
+			}
+		case key.Matches(msg, m.Keys.openWeb):
+			if m.Cursor < len(m.PullRequests) {
+				m.BrowserError = ""
+				return m, openPullRequestInBrowser(m.Config, m.Providers, m.Repo, m.PullRequests[m.Cursor].Number)
+			}
 		case key.Matches(msg, m.Keys.refresh):
 			m.Loading = true
 			m.Unsupported = false
 			m.LoadError = ""
-			return m, tea.Batch(performPullRequestLoad(m.Repo), m.Spinner.Tick)
+			return m, tea.Batch(performPullRequestLoad(m.Providers, m.Repo), m.Spinner.Tick)
 		case msg.String() == "up", msg.String() == "k":
 			if m.Cursor > 0 {
 				m.Cursor--
@@ -124,6 +154,12 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 			m.Cursor = len(m.PullRequests) - 1
 		}
 
+	case browserOpenedMsg:
+		m.BrowserError = ""
+		if msg.Err != nil {
+			m.BrowserError = msg.Err.Error()
+		}
+
 	case pulseTickMsg:
 		m.PulseOn = !m.PulseOn
 		return m, schedulePulseTick(m.PulseEvery)
@@ -180,6 +216,10 @@ func (m *Model) View() string {
 		s.WriteString("\n")
 		s.WriteString(lipgloss.NewStyle().Foreground(common.SubtleRed).Render("Refresh error: " + m.LoadError))
 	}
+	if m.BrowserError != "" {
+		s.WriteString("\n")
+		s.WriteString(lipgloss.NewStyle().Foreground(common.SubtleRed).Render("Open in browser failed: " + m.BrowserError))
+	}
 
 	s.WriteString("\n\n")
 	s.WriteString(m.buildFooter())
@@ -188,9 +228,11 @@ func (m *Model) View() string {
 }
 
 func (m *Model) buildFooter() string {
-	legend := "my PRs highlighted in blue"
+	legend := "my PRs highlighted in blue, review requests in yellow"
 	hotkeys := []string{
 		"↑/↓ navigate",
+		"enter open",
+		"o open in browser",
 		"r refresh",
 		"esc back",
 		"q quit",
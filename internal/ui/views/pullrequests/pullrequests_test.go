@@ -64,6 +64,28 @@ func TestUpdate_EscapeReturnsBackCommand(t *testing.T) {
 	}
 }
 
+func TestUpdate_EnterOpensSelectedPullRequest(t *testing.T) {
+	t.Parallel()
+
+	repo := domain.Repository{Name: "demo", Path: "/tmp/demo", RemoteURL: "https://github.com/octo/demo"}
+	m := New(repo, []domain.PullRequestDetails{{Number: 42}, {Number: 7}})
+	m.Cursor = 1
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected open command on enter")
+	}
+	msg, ok := cmd().(OpenPullRequestMsg)
+	if !ok || msg.Number != 7 || msg.Repo.Path != repo.Path {
+		t.Fatalf("cmd() = %#v, want OpenPullRequestMsg for #7", msg)
+	}
+
+	empty := New(repo, nil)
+	if _, cmd := empty.Update(tea.KeyPressMsg{Code: tea.KeyEnter}); cmd != nil {
+		t.Fatal("expected no command on enter without pull requests")
+	}
+}
+
 func TestBuildCheckSummary_CompleteAndFailing(t *testing.T) {
 	t.Parallel()
 
@@ -81,3 +103,18 @@ func TestBuildCheckSummary_CompleteAndFailing(t *testing.T) {
 		t.Fatalf("summary = %q, want failing count", summary)
 	}
 }
+
+func TestBuildNumber_HighlightsMineAndReviewRequests(t *testing.T) {
+	t.Parallel()
+
+	other := buildNumber(domain.PullRequestDetails{Number: 7})
+	mine := buildNumber(domain.PullRequestDetails{Number: 7, IsMine: true})
+	review := buildNumber(domain.PullRequestDetails{Number: 7, ReviewRequested: true})
+
+	if mine == other || review == other || review == mine {
+		t.Fatalf("buildNumber() = %q, %q, %q, want three distinct styles", other, mine, review)
+	}
+	if !strings.Contains(review, "#7") {
+		t.Fatalf("buildNumber() = %q, want the number", review)
+	}
+}
@@ -38,8 +38,8 @@ func RenderTable(rows []domain.PullRequestDetails, cursor int, layout ColumnLayo
 func pullRequestToRow(row domain.PullRequestDetails, selected bool, layout ColumnLayout, pulse bool) []string {
 	return []string{
 		buildSelector(selected),
-		buildNumber(row.Number, row.IsMine),
-		buildTitle(row.Title, row.IsMine, selected, layout.TitleWidth),
+		buildNumber(row),
+		buildTitle(row, selected, layout.TitleWidth),
 		buildCheckBar(row.Checks, layout.CheckBarWidth, pulse),
 		buildCheckSummary(row.Checks, layout.SummaryWidth),
 	}
@@ -53,32 +53,40 @@ func buildSelector(selected bool) string {
 	return style.Render(" ")
 }
 
-func buildNumber(number int, mine bool) string {
+// buildNumber highlights the user's own pull requests in blue and those
+// waiting on their review in yellow.
+func buildNumber(row domain.PullRequestDetails) string {
 	style := lipgloss.NewStyle().
 		Width(NumberWidth).
 		MaxWidth(NumberWidth).
 		AlignHorizontal(lipgloss.Left)
-	if mine {
+	switch {
+	case row.IsMine:
 		style = style.Foreground(common.Blue).Bold(true)
-	} else {
+	case row.ReviewRequested:
+		style = style.Foreground(common.Yellow).Bold(true)
+	default:
 		style = style.Foreground(common.TextSecondary)
 	}
-	return style.Render(fmt.Sprintf("#%d", number))
+	return style.Render(fmt.Sprintf("#%d", row.Number))
 }
 
-func buildTitle(title string, mine bool, selected bool, width int) string {
+func buildTitle(row domain.PullRequestDetails, selected bool, width int) string {
 	style := lipgloss.NewStyle().
 		AlignHorizontal(lipgloss.Left)
-	if mine {
+	switch {
+	case row.IsMine:
 		style = style.Foreground(common.Blue)
-	} else {
+	case row.ReviewRequested:
+		style = style.Foreground(common.Yellow)
+	default:
 		style = style.Foreground(common.TextPrimary)
 	}
 	if selected {
 		style = style.Bold(true)
 	}
 
-	return common.RenderTruncatedText(title, width, style)
+	return common.RenderTruncatedText(row.Title, width, style)
 }
 
 func buildCheckBar(checks domain.PullRequestChecks, width int, pulse bool) string {
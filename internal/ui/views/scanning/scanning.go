@@ -13,20 +13,20 @@ import (
 	tea "charm.land/bubbletea/v2"
 )
 
-var cfg = config.DefaultConfig()
-
 type Model struct {
 	Repositories []domain.Repository
 	scanner      *scanner.Scanner
 	Spinner      spinner.Model
+	cfg          *config.Config
 }
 
-func New(scanDir string) *Model {
+func New(scanDir string, cfg *config.Config) *Model {
 	s := common.NewGreenDotSpinner()
 	return &Model{
 		Repositories: make([]domain.Repository, 0),
 		scanner:      scanner.New(scanDir),
 		Spinner:      s,
+		cfg:          cfg,
 	}
 }
 
@@ -42,7 +42,7 @@ func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case repoFoundMsg:
 		path := string(msg)
-		repo := git.RefreshRepository(path, cfg, git.RefreshRepositoryOptions{
+		repo := git.RefreshRepository(path, m.cfg, git.RefreshRepositoryOptions{
 			Mode: git.RefreshModeBuildOnly,
 		})
 		m.Repositories = append(m.Repositories, repo)
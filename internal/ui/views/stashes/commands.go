@@ -0,0 +1,54 @@
+package stashes
+
+import (
+	"fresh/internal/domain"
+	"fresh/internal/git"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func performStashLoad(repo domain.Repository) tea.Cmd {
+	return func() tea.Msg {
+		return StashesLoadedMsg{
+			RepoPath: repo.Path,
+			Stashes:  git.ListStashes(repo.Path),
+		}
+	}
+}
+
+func performDiffStatLoad(repoPath string, ref string) tea.Cmd {
+	return func() tea.Msg {
+		stat, err := git.StashDiffStat(repoPath, ref)
+		msg := StashDiffLoadedMsg{RepoPath: repoPath, Ref: ref, Stat: stat}
+		if err != nil {
+			msg.Error = err.Error()
+		}
+		return msg
+	}
+}
+
+func performStashAction(repoPath string, action StashAction, ref string) tea.Cmd {
+	return func() tea.Msg {
+		var outcome domain.CommandOutcome
+		switch action {
+		case StashApply:
+			outcome = git.ApplyStash(repoPath, ref)
+		case StashPop:
+			outcome = git.PopStash(repoPath, ref)
+		case StashDrop:
+			outcome = git.DropStash(repoPath, ref)
+		}
+		return StashActionCompleteMsg{
+			RepoPath: repoPath,
+			Action:   action,
+			Ref:      ref,
+			Outcome:  outcome,
+		}
+	}
+}
+
+func backToRepoList() tea.Cmd {
+	return func() tea.Msg {
+		return BackToRepoListMsg{}
+	}
+}
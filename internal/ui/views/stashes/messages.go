@@ -0,0 +1,60 @@
+package stashes
+
+import "fresh/internal/domain"
+
+type BackToRepoListMsg struct{}
+
+type StashesLoadedMsg struct {
+	RepoPath string
+	Stashes  []domain.StashEntry
+}
+
+type StashDiffLoadedMsg struct {
+	RepoPath string
+	Ref      string
+	Stat     string
+	Error    string
+}
+
+type StashAction int
+
+const (
+	StashApply StashAction = iota + 1
+	StashPop
+	StashDrop
+)
+
+func (a StashAction) String() string {
+	switch a {
+	case StashApply:
+		return "apply"
+	case StashPop:
+		return "pop"
+	case StashDrop:
+		return "drop"
+	default:
+		return "unknown"
+	}
+}
+
+func (a StashAction) pastTense() string {
+	switch a {
+	case StashApply:
+		return "Applied"
+	case StashPop:
+		return "Popped"
+	case StashDrop:
+		return "Dropped"
+	default:
+		return "Finished"
+	}
+}
+
+// StashActionCompleteMsg reports the result of applying, popping or dropping
+// a stash. The main model also uses it to refresh the repository's row.
+type StashActionCompleteMsg struct {
+	RepoPath string
+	Action   StashAction
+	Ref      string
+	Outcome  domain.CommandOutcome
+}
@@ -0,0 +1,362 @@
+package stashes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"fresh/internal/config"
+	"fresh/internal/domain"
+	"fresh/internal/ui/views/common"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/spinner"
+	tea "charm.land/bubbletea/v2"
+)
+
+type stashKeyMap struct {
+	diffStat key.Binding
+	apply    key.Binding
+	pop      key.Binding
+	drop     key.Binding
+	refresh  key.Binding
+	back     key.Binding
+}
+
+func newStashKeyMap() *stashKeyMap {
+	return &stashKeyMap{
+		diffStat: key.NewBinding(
+			key.WithKeys("d", "enter"),
+			key.WithHelp("d", "show diff stat"),
+		),
+		apply: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "apply stash"),
+		),
+		pop: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "pop stash"),
+		),
+		drop: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "drop stash"),
+		),
+		refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh stashes"),
+		),
+		back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back to repositories"),
+		),
+	}
+}
+
+type Model struct {
+	Repo          domain.Repository
+	Stashes       []domain.StashEntry
+	Cursor        int
+	Keys          *stashKeyMap
+	Loading       bool
+	Busy          bool
+	Spinner       spinner.Model
+	StaleAfter    time.Duration
+	DiffRef       string
+	DiffStat      string
+	DiffError     string
+	Status        string
+	StatusFailed  bool
+	PendingDrop   string
+	width, height int
+}
+
+func New(repo domain.Repository, cfg *config.Config) *Model {
+	return &Model{
+		Repo:       repo,
+		Keys:       newStashKeyMap(),
+		Loading:    true,
+		Spinner:    common.NewPullRequestSpinner(),
+		StaleAfter: cfg.StaleStashAge,
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+func (m *Model) Init() tea.Cmd {
+	return tea.Batch(performStashLoad(m.Repo), m.Spinner.Tick)
+}
+
+func (m *Model) Update(msg tea.Msg) (*Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.SetSize(msg.Width, msg.Height)
+		return m, nil
+
+	case tea.KeyPressMsg:
+		return m, m.handleKey(msg)
+
+	case StashesLoadedMsg:
+		if msg.RepoPath != m.Repo.Path {
+			return m, nil
+		}
+		m.Loading = false
+		m.Busy = false
+		m.Stashes = msg.Stashes
+		m.clampCursor()
+
+	case StashDiffLoadedMsg:
+		if msg.RepoPath != m.Repo.Path || msg.Ref != m.DiffRef {
+			return m, nil
+		}
+		m.DiffStat = msg.Stat
+		m.DiffError = msg.Error
+
+	case StashActionCompleteMsg:
+		if msg.RepoPath != m.Repo.Path {
+			return m, nil
+		}
+		if msg.Outcome.IsSuccess() {
+			m.Status = fmt.Sprintf("%s %s", msg.Action.pastTense(), msg.Ref)
+			m.StatusFailed = false
+		} else {
+			m.Status = fmt.Sprintf("Could not %s %s: %s", msg.Action, msg.Ref, msg.Outcome.FailureReason)
+			m.StatusFailed = true
+		}
+		// Popping or dropping renumbers the remaining stashes, so any diff
+		// stat on screen may now describe a different ref.
+		m.clearDiff()
+		m.Loading = true
+		return m, tea.Batch(performStashLoad(m.Repo), m.Spinner.Tick)
+
+	case spinner.TickMsg:
+		if m.Loading || m.Busy {
+			var cmd tea.Cmd
+			m.Spinner, cmd = m.Spinner.Update(msg)
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+func (m *Model) handleKey(msg tea.KeyPressMsg) tea.Cmd {
+	if key.Matches(msg, m.Keys.back) || msg.Code == 27 {
+		return backToRepoList()
+	}
+
+	// Dropping needs the same key twice in a row; anything else cancels.
+	pendingDrop := m.PendingDrop
+	m.PendingDrop = ""
+
+	switch {
+	case key.Matches(msg, m.Keys.refresh):
+		m.Loading = true
+		m.clearDiff()
+		return tea.Batch(performStashLoad(m.Repo), m.Spinner.Tick)
+
+	case msg.String() == "up", msg.String() == "k":
+		if m.Cursor > 0 {
+			m.Cursor--
+		}
+
+	case msg.String() == "down", msg.String() == "j":
+		if m.Cursor < len(m.Stashes)-1 {
+			m.Cursor++
+		}
+
+	case key.Matches(msg, m.Keys.diffStat):
+		stash, ok := m.selectedStash()
+		if !ok {
+			return nil
+		}
+		if m.DiffRef == stash.Ref {
+			m.clearDiff()
+			return nil
+		}
+		m.DiffRef = stash.Ref
+		m.DiffStat = ""
+		m.DiffError = ""
+		return performDiffStatLoad(m.Repo.Path, stash.Ref)
+
+	case key.Matches(msg, m.Keys.apply):
+		return m.startAction(StashApply)
+
+	case key.Matches(msg, m.Keys.pop):
+		return m.startAction(StashPop)
+
+	case key.Matches(msg, m.Keys.drop):
+		stash, ok := m.selectedStash()
+		if !ok || m.Busy {
+			return nil
+		}
+		if pendingDrop != stash.Ref {
+			m.PendingDrop = stash.Ref
+			return nil
+		}
+		return m.startAction(StashDrop)
+	}
+
+	return nil
+}
+
+func (m *Model) startAction(action StashAction) tea.Cmd {
+	stash, ok := m.selectedStash()
+	if !ok || m.Busy || m.Loading {
+		return nil
+	}
+	m.Busy = true
+	m.Status = fmt.Sprintf("Running git stash %s %s...", action, stash.Ref)
+	m.StatusFailed = false
+	return tea.Batch(performStashAction(m.Repo.Path, action, stash.Ref), m.Spinner.Tick)
+}
+
+func (m *Model) selectedStash() (domain.StashEntry, bool) {
+	if m.Cursor < 0 || m.Cursor >= len(m.Stashes) {
+		return domain.StashEntry{}, false
+	}
+	return m.Stashes[m.Cursor], true
+}
+
+func (m *Model) clampCursor() {
+	if m.Cursor >= len(m.Stashes) {
+		m.Cursor = len(m.Stashes) - 1
+	}
+	if m.Cursor < 0 {
+		m.Cursor = 0
+	}
+}
+
+func (m *Model) clearDiff() {
+	m.DiffRef = ""
+	m.DiffStat = ""
+	m.DiffError = ""
+}
+
+// IsStale reports whether a stash is older than the configured age.
+func (m *Model) IsStale(stash domain.StashEntry, now time.Time) bool {
+	if m.StaleAfter <= 0 || stash.CreatedAt.IsZero() {
+		return false
+	}
+	return now.Sub(stash.CreatedAt) > m.StaleAfter
+}
+
+func (m *Model) View() string {
+	var s strings.Builder
+	now := time.Now()
+
+	title := fmt.Sprintf("\nStashes for %s\n", m.Repo.Name)
+	s.WriteString(common.HeaderStyle.Render(title))
+	s.WriteString("\n")
+
+	switch {
+	case m.Loading && m.Stashes == nil:
+		s.WriteString(common.TextGrey.Render(m.Spinner.View() + " Loading stashes..."))
+	case len(m.Stashes) == 0:
+		s.WriteString(common.TextGrey.Render("  No stashes"))
+	default:
+		s.WriteString(RenderTable(m.Stashes, m.Cursor, m.width, func(stash domain.StashEntry) bool {
+			return m.IsStale(stash, now)
+		}, now))
+	}
+
+	if m.DiffRef != "" {
+		s.WriteString("\n\n")
+		s.WriteString(m.renderDiffPane())
+	}
+
+	if status := m.renderStatus(); status != "" {
+		s.WriteString("\n\n")
+		s.WriteString(status)
+	}
+
+	s.WriteString("\n\n")
+	s.WriteString(m.buildFooter(now))
+	return s.String()
+}
+
+func (m *Model) renderDiffPane() string {
+	header := common.TableHeaderStyle.Render(" Diff stat for " + m.DiffRef)
+	switch {
+	case m.DiffError != "":
+		return header + "\n  " + common.PullOutputError.Render(m.DiffError)
+	case m.DiffStat == "":
+		return header + "\n  " + common.TextGrey.Render("Loading...")
+	}
+
+	lines := strings.Split(m.DiffStat, "\n")
+	if limit := m.diffLineLimit(); limit > 0 && len(lines) > limit {
+		// Keep the trailing "N files changed" summary and elide the middle.
+		kept := limit - 2
+		hidden := len(lines) - 1 - kept
+		summary := lines[len(lines)-1]
+		lines = append(lines[:kept:kept], common.TextGrey.Render(fmt.Sprintf("… %d more files", hidden)), summary)
+	}
+	for i, line := range lines {
+		lines[i] = "  " + line
+	}
+	return header + "\n" + strings.Join(lines, "\n")
+}
+
+// diffLineLimit keeps the diff stat from pushing the footer off screen. It
+// returns 0 when the terminal height is unknown.
+func (m *Model) diffLineLimit() int {
+	if m.height <= 0 {
+		return 0
+	}
+	const chrome = 14
+	return max(4, m.height-chrome-len(m.Stashes))
+}
+
+func (m *Model) renderStatus() string {
+	switch {
+	case m.PendingDrop != "":
+		return common.PullOutputWarn.Render(fmt.Sprintf("  Press x again to drop %s", m.PendingDrop))
+	case m.Status == "":
+		return ""
+	case m.Busy:
+		return common.TextGrey.Render("  " + m.Spinner.View() + " " + m.Status)
+	case m.StatusFailed:
+		return common.PullOutputError.Render("  " + m.Status)
+	default:
+		return common.PullOutputSuccess.Render("  " + m.Status)
+	}
+}
+
+func (m *Model) buildFooter(now time.Time) string {
+	hotkeys := []string{
+		"↑/↓ navigate",
+		"d diff stat",
+		"a apply",
+		"p pop",
+		"x drop",
+		"r refresh",
+		"esc back",
+		"q quit",
+	}
+
+	stale := 0
+	for _, stash := range m.Stashes {
+		if m.IsStale(stash, now) {
+			stale++
+		}
+	}
+	if stale > 0 {
+		hotkeys = append([]string{fmt.Sprintf("%d older than %s", stale, formatAge(m.StaleAfter))}, hotkeys...)
+	}
+
+	style := common.FooterStyle
+	if m.width > 0 {
+		style = style.Width(m.width)
+	}
+	return style.Render(strings.Join(hotkeys, "  •  "))
+}
+
+func formatAge(d time.Duration) string {
+	if d >= 24*time.Hour && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	}
+	return d.String()
+}
@@ -0,0 +1,144 @@
+package stashes
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"fresh/internal/config"
+	"fresh/internal/domain"
+	"fresh/internal/testhelpers"
+)
+
+func loadedModel(t *testing.T, entries ...domain.StashEntry) *Model {
+	t.Helper()
+
+	m := New(domain.Repository{Name: "demo", Path: "/tmp/demo"}, config.DefaultConfig())
+	m.Update(StashesLoadedMsg{RepoPath: "/tmp/demo", Stashes: entries})
+	return m
+}
+
+func TestUpdate_StashesLoadedRendersEntries(t *testing.T) {
+	t.Parallel()
+
+	m := loadedModel(t,
+		domain.StashEntry{Ref: "stash@{0}", Branch: "main", Message: "half-done refactor", CreatedAt: time.Now()},
+	)
+
+	if m.Loading {
+		t.Fatal("expected loading to stop")
+	}
+	view := m.View()
+	for _, want := range []string{"stash@{0}", "main", "half-done refactor"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view missing %q", want)
+		}
+	}
+}
+
+func TestIsStale_UsesConfiguredAge(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	m := loadedModel(t)
+	m.StaleAfter = 7 * 24 * time.Hour
+
+	if m.IsStale(domain.StashEntry{CreatedAt: now.Add(-24 * time.Hour)}, now) {
+		t.Fatal("day-old stash should not be stale")
+	}
+	if !m.IsStale(domain.StashEntry{CreatedAt: now.Add(-8 * 24 * time.Hour)}, now) {
+		t.Fatal("eight-day-old stash should be stale")
+	}
+	if m.IsStale(domain.StashEntry{}, now) {
+		t.Fatal("stash without a date should not be stale")
+	}
+
+	m.Stashes = []domain.StashEntry{{Ref: "stash@{0}", CreatedAt: now.Add(-8 * 24 * time.Hour)}}
+	if !strings.Contains(m.View(), "1 older than 7d") {
+		t.Fatal("expected footer to count stale stashes")
+	}
+}
+
+func TestUpdate_DropRequiresConfirmation(t *testing.T) {
+	t.Parallel()
+
+	m := loadedModel(t, domain.StashEntry{Ref: "stash@{0}"})
+
+	if cmd := testhelpers.Press(m, "x"); cmd != nil {
+		t.Fatal("first x should only ask for confirmation")
+	}
+	if m.PendingDrop != "stash@{0}" {
+		t.Fatalf("pending drop = %q, want stash@{0}", m.PendingDrop)
+	}
+
+	if cmd := testhelpers.Press(m, "x"); cmd == nil {
+		t.Fatal("second x should drop the stash")
+	}
+	if !m.Busy {
+		t.Fatal("expected model to be busy while dropping")
+	}
+}
+
+func TestUpdate_OtherKeyCancelsPendingDrop(t *testing.T) {
+	t.Parallel()
+
+	m := loadedModel(t, domain.StashEntry{Ref: "stash@{0}"}, domain.StashEntry{Ref: "stash@{1}"})
+	testhelpers.Press(m, "x")
+	testhelpers.Press(m, "j")
+
+	if m.PendingDrop != "" {
+		t.Fatal("expected navigation to cancel the pending drop")
+	}
+	if cmd := testhelpers.Press(m, "x"); cmd != nil || m.PendingDrop != "stash@{1}" {
+		t.Fatal("expected x on a new stash to ask for confirmation again")
+	}
+}
+
+func TestUpdate_ActionCompleteReportsAndReloads(t *testing.T) {
+	t.Parallel()
+
+	m := loadedModel(t, domain.StashEntry{Ref: "stash@{0}"})
+	m.DiffRef = "stash@{0}"
+
+	_, cmd := m.Update(StashActionCompleteMsg{
+		RepoPath: "/tmp/demo",
+		Action:   StashPop,
+		Ref:      "stash@{0}",
+		Outcome:  domain.CommandOutcome{ExitCode: 1, FailureReason: "conflict in main.go"},
+	})
+
+	if cmd == nil || !m.Loading {
+		t.Fatal("expected stash list to reload after an action")
+	}
+	if !m.StatusFailed || !strings.Contains(m.Status, "conflict in main.go") {
+		t.Fatalf("status = %q, want failure reason", m.Status)
+	}
+	if m.DiffRef != "" {
+		t.Fatal("expected diff stat to be cleared after an action")
+	}
+}
+
+func TestUpdate_IgnoresDiffForOtherRef(t *testing.T) {
+	t.Parallel()
+
+	m := loadedModel(t, domain.StashEntry{Ref: "stash@{0}"})
+	m.DiffRef = "stash@{0}"
+	m.Update(StashDiffLoadedMsg{RepoPath: "/tmp/demo", Ref: "stash@{1}", Stat: "other"})
+
+	if m.DiffStat != "" {
+		t.Fatal("expected stale diff result to be ignored")
+	}
+}
+
+func TestUpdate_EscapeReturnsBackCommand(t *testing.T) {
+	t.Parallel()
+
+	m := loadedModel(t)
+	cmd := testhelpers.Press(m, "esc")
+	if cmd == nil {
+		t.Fatal("expected back command on escape")
+	}
+	if _, ok := cmd().(BackToRepoListMsg); !ok {
+		t.Fatal("expected BackToRepoListMsg")
+	}
+}
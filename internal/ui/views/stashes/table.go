@@ -0,0 +1,94 @@
+package stashes
+
+import (
+	"time"
+
+	"fresh/internal/domain"
+	"fresh/internal/ui/views/common"
+
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/table"
+)
+
+const (
+	SelectorWidth = 2
+	RefWidth      = 11
+	BranchWidth   = 20
+	AgeWidth      = 10
+	MarkerWidth   = 6
+	// minMessageWidth applies when the terminal width is unknown or narrow.
+	minMessageWidth = 30
+	tableOverhead   = 12
+)
+
+var (
+	refStyle     = lipgloss.NewStyle().Foreground(common.Yellow)
+	branchStyle  = lipgloss.NewStyle().Foreground(common.TextBranch)
+	messageStyle = lipgloss.NewStyle().Foreground(common.TextPrimary)
+	ageStyle     = lipgloss.NewStyle().Foreground(common.TextSecondary)
+	staleStyle   = lipgloss.NewStyle().Foreground(common.Yellow).Bold(true)
+)
+
+func RenderTable(stashes []domain.StashEntry, cursor int, width int, isStale func(domain.StashEntry) bool, now time.Time) string {
+	headers := []string{"", "Ref", "Branch", "Message", "Age", ""}
+	messageWidth := messageColumnWidth(width)
+
+	rows := make([][]string, len(stashes))
+	for i, stash := range stashes {
+		rows[i] = stashToRow(stash, i == cursor, isStale(stash), messageWidth, now)
+	}
+
+	t := table.New().
+		Border(lipgloss.HiddenBorder()).
+		Headers(headers...).
+		Rows(rows...).
+		BorderStyle(common.TableBorderStyle).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return common.TableHeaderStyle
+			}
+			return lipgloss.NewStyle()
+		})
+
+	return t.Render()
+}
+
+func messageColumnWidth(width int) int {
+	fixed := SelectorWidth + RefWidth + BranchWidth + AgeWidth + MarkerWidth + tableOverhead
+	return max(minMessageWidth, width-fixed)
+}
+
+func stashToRow(stash domain.StashEntry, selected bool, stale bool, messageWidth int, now time.Time) []string {
+	selector := common.SelectorStyle.Width(SelectorWidth).Render(" ")
+	if selected {
+		selector = common.SelectorStyle.Width(SelectorWidth).Render(common.IconSelector)
+	}
+
+	message := messageStyle
+	if selected {
+		message = message.Bold(true)
+	}
+
+	age := ageStyle
+	marker := ""
+	if stale {
+		age = staleStyle
+		marker = staleStyle.Render("stale")
+	}
+
+	return []string{
+		selector,
+		common.RenderTruncatedText(stash.Ref, RefWidth, refStyle),
+		common.RenderTruncatedText(valueOrDash(stash.Branch), BranchWidth, branchStyle),
+		common.RenderTruncatedText(stash.Message, messageWidth, message),
+		common.RenderTruncatedText(common.FormatRelativeTime(stash.CreatedAt, now), AgeWidth, age),
+		lipgloss.NewStyle().Width(MarkerWidth).Render(marker),
+	}
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}